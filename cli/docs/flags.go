@@ -32,16 +32,18 @@ const (
 )
 
 const (
-	Mvn    = "mvn"
-	Gradle = "gradle"
-	Npm    = "npm"
-	Pnpm   = "pnpm"
-	Yarn   = "yarn"
-	Nuget  = "nuget"
-	Go     = "go"
-	Pip    = "pip"
-	Pipenv = "pipenv"
-	Poetry = "poetry"
+	Mvn      = "mvn"
+	Gradle   = "gradle"
+	Npm      = "npm"
+	Pnpm     = "pnpm"
+	Yarn     = "yarn"
+	Nuget    = "nuget"
+	Go       = "go"
+	Pip      = "pip"
+	Pipenv   = "pipenv"
+	Poetry   = "poetry"
+	Cargo    = "cargo"
+	Composer = "composer"
 )
 
 const (
@@ -93,6 +95,11 @@ const (
 	FixableOnly         = "fixable-only"
 	Rescan              = "rescan"
 	Vuln                = "vuln"
+	Output              = "output"
+	Baseline            = "baseline"
+	FailOn              = "fail-on"
+	FailOnSecrets       = "fail-on-secrets"
+	FailOnCvss          = "fail-on-cvss"
 
 	// Unique audit flags
 	auditPrefix                  = "audit-"
@@ -103,6 +110,14 @@ const (
 	ThirdPartyContextualAnalysis = "third-party-contextual-analysis"
 	RequirementsFile             = "requirements-file"
 	WorkingDirs                  = "working-dirs"
+	ReportFile                   = "report-file"
+	CsvDir                       = "csv-dir"
+	Scanners                     = "scanners"
+	Timeout                      = "timeout"
+	ChangedSince                 = "changed-since"
+	ExportDeps                   = "export-deps"
+	FromDeps                     = "from-deps"
+	AuditThreads                 = auditPrefix + Threads
 
 	// Unique curation flags
 	CurationOutput  = "curation-format"
@@ -115,17 +130,20 @@ var commandFlags = map[string][]string{
 	OfflineUpdate: {LicenseId, From, To, Version, Target, Stream, Periodic},
 	XrScan: {
 		url, user, password, accessToken, ServerId, SpecFlag, Threads, scanRecursive, scanRegexp, scanAnt,
-		Project, Watches, RepoPath, Licenses, OutputFormat, Fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly,
+		Project, Watches, RepoPath, Licenses, OutputFormat, Output, Fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly, Baseline,
+		FailOn, FailOnSecrets, FailOnCvss,
 	},
 	BuildScan: {
-		url, user, password, accessToken, ServerId, Project, Vuln, OutputFormat, Fail, ExtendedTable, Rescan,
+		url, user, password, accessToken, ServerId, Project, Vuln, OutputFormat, Output, Fail, ExtendedTable, Rescan,
 	},
 	DockerScan: {
-		ServerId, Project, Watches, RepoPath, Licenses, OutputFormat, Fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly,
+		ServerId, Project, Watches, RepoPath, Licenses, OutputFormat, Output, Fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly,
 	},
 	Audit: {
 		url, user, password, accessToken, ServerId, InsecureTls, Project, Watches, RepoPath, Licenses, OutputFormat, ExcludeTestDeps,
-		useWrapperAudit, DepType, RequirementsFile, Fail, ExtendedTable, WorkingDirs, ExclusionsAudit, Mvn, Gradle, Npm, Pnpm, Yarn, Go, Nuget, Pip, Pipenv, Poetry, MinSeverity, FixableOnly, ThirdPartyContextualAnalysis,
+		useWrapperAudit, DepType, RequirementsFile, Fail, ExtendedTable, WorkingDirs, ExclusionsAudit, Mvn, Gradle, Npm, Pnpm, Yarn, Go, Nuget, Pip, Pipenv, Poetry, Cargo, Composer, MinSeverity, FixableOnly, ThirdPartyContextualAnalysis,
+		ReportFile, CsvDir, Output, Baseline, AuditThreads, Scanners, Timeout, ChangedSince, ExportDeps, FromDeps,
+		FailOn, FailOnSecrets, FailOnCvss,
 	},
 	CurationAudit: {
 		CurationOutput, WorkingDirs, CurationThreads, RequirementsFile,
@@ -179,10 +197,26 @@ var flagsMap = map[string]components.Flag{
 	Licenses:      components.NewBoolFlag(Licenses, "Set to true if you'd like to receive licenses from Xray scanning."),
 	OutputFormat: components.NewStringFlag(
 		OutputFormat,
-		"Defines the output format of the command. Acceptable values are: table, json, simple-json and sarif. Note: the json format doesn't include information about scans that are included as part of the Advanced Security package.",
+		"Defines the output format of the command. Acceptable values are: table, json, simple-json, sarif, cyclonedx, spdx, junit, html, markdown, openvex, gitlab-dependency-scanning, gitlab-sast and gitlab-secret-detection. Note: the json format doesn't include information about scans that are included as part of the Advanced Security package.",
 		components.WithStrDefaultValue("table"),
 	),
-	Fail:                components.NewBoolFlag(Fail, "Set to false if you do not wish the command to return exit code 3, even if the 'Fail Build' rule is matched by Xray.", components.WithBoolDefaultValue(true)),
+	Output: components.NewStringFlag(
+		Output,
+		"A comma-separated list of <format>=<file path> pairs, to write the results to files in addition to the output printed according to the 'format' option. For example: 'sarif=results.sarif,simple-json=results.json'. All the values of the 'format' option are accepted, except for table.",
+	),
+	Baseline: components.NewStringFlag(
+		Baseline,
+		"Path to the results of a previous scan, in the simple-json or sarif format. Findings that exist in this file are excluded from the output and the exit code, so only new findings are reported. The existing findings are listed in the 'existing' section of the simple-json format, and marked as unchanged in the sarif format.",
+	),
+	Fail: components.NewBoolFlag(Fail, "Set to false if you do not wish the command to return exit code 3, even if the 'Fail Build' rule is matched by Xray.", components.WithBoolDefaultValue(true)),
+	FailOn: components.NewStringFlag(
+		FailOn,
+		"A semicolon-separated list of local conditions that fail the command with exit code 3 if any finding matches them, regardless of the Xray watches. For example: 'severity>=high && applicable!=not_applicable'. "+
+			"A condition is a list of <field><operator><value> comparisons joined by '&&' and '||'. The supported fields are: type (vulnerability, violation, secret, iac or sast), severity (low, medium, high or critical), "+
+			"cvss (0-10), applicable (applicable, not_applicable, undetermined, not_covered or not_scanned) and fixable (true or false).",
+	),
+	FailOnSecrets:       components.NewBoolFlag(FailOnSecrets, "Set to true to fail the command with exit code 3 if any secret is found, regardless of the Xray watches."),
+	FailOnCvss:          components.NewStringFlag(FailOnCvss, "A CVSS score between 0 and 10. Fails the command with exit code 3 if any vulnerability or violation has a CVSS score that is at least the score, regardless of the Xray watches."),
 	ExtendedTable:       components.NewBoolFlag(ExtendedTable, "Set to true if you'd like the table to include extended fields such as 'CVSS' & 'Xray Issue Id'. Ignored if provided 'format' is not 'table'."),
 	BypassArchiveLimits: components.NewBoolFlag(BypassArchiveLimits, "Set to true to bypass the indexer-app archive limits."),
 	MinSeverity:         components.NewStringFlag(MinSeverity, "Set the minimum severity of issues to display. The following values are accepted: Low, Medium, High or Critical."),
@@ -202,22 +236,32 @@ var flagsMap = map[string]components.Flag{
 		"List of exclusions separated by semicolons, utilized to skip sub-projects from undergoing an audit. These exclusions may incorporate the * and ? wildcards.",
 		components.WithStrDefaultValue(strings.Join(sca.DefaultExcludePatterns, ";")),
 	),
-	Mvn:     components.NewBoolFlag(Mvn, "Set to true to request audit for a Maven project."),
-	Gradle:  components.NewBoolFlag(Gradle, "Set to true to request audit for a Gradle project."),
-	Npm:     components.NewBoolFlag(Npm, "Set to true to request audit for a npm project."),
-	Pnpm:    components.NewBoolFlag(Pnpm, "Set to true to request audit for a Pnpm project."),
-	Yarn:    components.NewBoolFlag(Yarn, "Set to true to request audit for a Yarn project."),
-	Nuget:   components.NewBoolFlag(Nuget, "Set to true to request audit for a .NET project."),
-	Pip:     components.NewBoolFlag(Pip, "Set to true to request audit for a Pip project."),
-	Pipenv:  components.NewBoolFlag(Pipenv, "Set to true to request audit for a Pipenv project."),
-	Poetry:  components.NewBoolFlag(Poetry, "Set to true to request audit for a Poetry project."),
-	Go:      components.NewBoolFlag(Go, "Set to true to request audit for a Go project."),
-	DepType: components.NewStringFlag(DepType, "[npm] Defines npm dependencies type. Possible values are: all, devOnly and prodOnly."),
+	Mvn:      components.NewBoolFlag(Mvn, "Set to true to request audit for a Maven project."),
+	Gradle:   components.NewBoolFlag(Gradle, "Set to true to request audit for a Gradle project."),
+	Npm:      components.NewBoolFlag(Npm, "Set to true to request audit for a npm project."),
+	Pnpm:     components.NewBoolFlag(Pnpm, "Set to true to request audit for a Pnpm project."),
+	Yarn:     components.NewBoolFlag(Yarn, "Set to true to request audit for a Yarn project."),
+	Nuget:    components.NewBoolFlag(Nuget, "Set to true to request audit for a .NET project."),
+	Pip:      components.NewBoolFlag(Pip, "Set to true to request audit for a Pip project."),
+	Pipenv:   components.NewBoolFlag(Pipenv, "Set to true to request audit for a Pipenv project."),
+	Poetry:   components.NewBoolFlag(Poetry, "Set to true to request audit for a Poetry project."),
+	Go:       components.NewBoolFlag(Go, "Set to true to request audit for a Go project."),
+	Cargo:    components.NewBoolFlag(Cargo, "Set to true to request audit for a Cargo project."),
+	Composer: components.NewBoolFlag(Composer, "Set to true to request audit for a Composer project."),
+	DepType:  components.NewStringFlag(DepType, "[npm] Defines npm dependencies type. Possible values are: all, devOnly and prodOnly."),
 	ThirdPartyContextualAnalysis: components.NewBoolFlag(
 		ThirdPartyContextualAnalysis,
 		"[npm] when set, the Contextual Analysis scan also uses the code of the project dependencies to determine the applicability of the vulnerability.",
 		components.SetHiddenBoolFlag(),
 	),
+	ReportFile:       components.NewStringFlag(ReportFile, "Path to a file in which a self-contained HTML report of the audit results will be written, in addition to the output printed according to the 'format' option."),
+	CsvDir:           components.NewStringFlag(CsvDir, "Path to a directory in which the audit results will be written as CSV files, one file for each section of the results (vulnerabilities, licenses, secrets, etc.)."),
+	Scanners:         components.NewStringFlag(Scanners, "A comma-separated list of the scanners to run. Acceptable values are: sca, contextual-analysis, secrets, iac and sast. All the scanners run by default. The JFrog Advanced Security scanners run only if the server is entitled for them."),
+	ChangedSince:     components.NewStringFlag(ChangedSince, "A git reference (branch, tag or commit) to compare the working directories to. Only the projects whose descriptors or lock files changed since the reference are scanned by the SCA scan, and only the directories of the changed files are scanned by the secrets, IaC and SAST scanners. The changes are compared to the merge base of the reference and HEAD, and include uncommitted and untracked files."),
+	ExportDeps:       components.NewStringFlag(ExportDeps, "Path to a JSON file to export the resolved dependency trees of the detected projects to, instead of scanning them. The exported trees can be scanned later, on another machine, with the --from-deps option."),
+	FromDeps:         components.NewStringFlag(FromDeps, "Path to a JSON file of dependency trees that were exported with the --export-deps option. The trees are scanned instead of the detected projects, without running the package managers."),
+	Timeout:          components.NewStringFlag(Timeout, "Stops the scans that didn't complete within the timeout, and prints the results that were collected so far. Either a duration that limits the whole audit, for example: '30m', or a comma-separated list of <phase>=<duration> pairs, where the phases are total, sca and jas. For example: 'total=30m,sca=10m,jas=20m'. A duration of 0 disables the timeout. The JAS scanners run concurrently with the SCA scan, so their timeout is counted from the beginning of the audit as well."),
+	AuditThreads:     components.NewStringFlag(Threads, "Number of working threads. The SCA scans of the working directories run in parallel, but the dependency trees of Pip projects, and of Poetry, npm, Yarn and Go projects that resolve their dependencies from Artifactory, are built one at a time, since their package managers are configured through the process environment variables or working directory.", components.WithIntDefaultValue(cliutils.Threads)),
 	RequirementsFile: components.NewStringFlag(RequirementsFile, "[Pip] Defines pip requirements file name. For example: 'requirements.txt'."),
 	CurationThreads:  components.NewStringFlag(Threads, "Number of working threads.", components.WithIntDefaultValue(curation.TotalConcurrentRequests)),
 	CurationOutput:   components.NewStringFlag(OutputFormat, "Defines the output format of the command. Acceptable values are: table, json.", components.WithStrDefaultValue("table")),
@@ -1,5 +1,5 @@
 package audit
 
 func GetDescription() string {
-	return "Audit your local project's dependencies by generating a dependency tree and scanning it with Xray."
+	return "Audit your local project's dependencies by generating a dependency tree and scanning it with Xray. The defaults of the options can be set in a .jfrog/security.yml file in the current directory."
 }
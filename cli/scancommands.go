@@ -179,7 +179,11 @@ func ScanCmd(c *components.Context) error {
 	if err != nil {
 		return err
 	}
-	format, err := outputFormat.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	format, err := utils.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	if err != nil {
+		return err
+	}
+	outputFiles, err := utils.ParseOutputFiles(c.GetStringFlagValue(flags.Output))
 	if err != nil {
 		return err
 	}
@@ -188,15 +192,22 @@ func ScanCmd(c *components.Context) error {
 	if err != nil {
 		return err
 	}
+	failConditions, err := getFailConditions(c)
+	if err != nil {
+		return err
+	}
 	scanCmd := scan.NewScanCommand().
 		SetServerDetails(serverDetails).
 		SetThreads(threads).
 		SetSpec(specFile).
 		SetOutputFormat(format).
+		SetOutputFiles(outputFiles).
+		SetBaselineFile(c.GetStringFlagValue(flags.Baseline)).
 		SetProject(c.GetStringFlagValue(flags.Project)).
 		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(c)).
 		SetIncludeLicenses(c.GetBoolFlagValue(flags.Licenses)).
 		SetFail(c.GetBoolFlagValue(flags.Fail)).
+		SetFailConditions(failConditions).
 		SetPrintExtendedTable(c.GetBoolFlagValue(flags.ExtendedTable)).
 		SetBypassArchiveLimits(c.GetBoolFlagValue(flags.BypassArchiveLimits)).
 		SetFixableOnly(c.GetBoolFlagValue(flags.FixableOnly)).
@@ -211,7 +222,7 @@ func createServerDetailsWithConfigOffer(c *components.Context) (*coreConfig.Serv
 	return pluginsCommon.CreateServerDetailsWithConfigOffer(c, true, cliutils.Xr)
 }
 
-func validateXrayContext(c *components.Context, serverDetails *coreConfig.ServerDetails) error {
+func validateXrayContext(c flagValues, serverDetails *coreConfig.ServerDetails) error {
 	if serverDetails.XrayUrl == "" {
 		return errorutils.CheckErrorf("JFrog Xray URL must be provided in order run this command. Use the 'jf c add' command to set the Xray server details.")
 	}
@@ -231,14 +242,14 @@ func validateXrayContext(c *components.Context, serverDetails *coreConfig.Server
 	return nil
 }
 
-func isProjectProvided(c *components.Context) bool {
+func isProjectProvided(c flagValues) bool {
 	if c.IsFlagSet(flags.Project) {
 		return c.GetStringFlagValue(flags.Project) != ""
 	}
 	return os.Getenv(coreutils.Project) != ""
 }
 
-func addTrailingSlashToRepoPathIfNeeded(c *components.Context) string {
+func addTrailingSlashToRepoPathIfNeeded(c flagValues) string {
 	repoPath := c.GetStringFlagValue(flags.RepoPath)
 	if repoPath != "" && !strings.Contains(repoPath, "/") {
 		// In case only repo name was provided (no path) we are adding a trailing slash.
@@ -259,11 +270,36 @@ func createDefaultScanSpec(c *components.Context, defaultTarget string) *spec.Sp
 		BuildSpec()
 }
 
-func shouldIncludeVulnerabilities(c *components.Context) bool {
+func shouldIncludeVulnerabilities(c flagValues) bool {
 	// If no context was provided by the user, no Violations will be triggered by Xray, so include general vulnerabilities in the command output
 	return c.GetStringFlagValue(flags.Watches) == "" && !isProjectProvided(c) && c.GetStringFlagValue(flags.RepoPath) == ""
 }
 
+// Returns the local fail conditions of the --fail-on, --fail-on-secrets and --fail-on-cvss flags.
+func getFailConditions(c flagValues) (conditions []*utils.FailCondition, err error) {
+	for _, expression := range strings.Split(c.GetStringFlagValue(flags.FailOn), ";") {
+		if strings.TrimSpace(expression) == "" {
+			continue
+		}
+		condition, err := utils.ParseFailCondition(expression)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	if c.GetBoolFlagValue(flags.FailOnSecrets) {
+		conditions = append(conditions, utils.NewFailOnSecretsCondition())
+	}
+	if score := c.GetStringFlagValue(flags.FailOnCvss); score != "" {
+		condition, err := utils.ParseFailOnCvssCondition(score)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return
+}
+
 func splitByCommaAndTrim(paramValue string) (res []string) {
 	args := strings.Split(paramValue, ",")
 	res = make([]string, len(args))
@@ -290,7 +326,11 @@ func BuildScan(c *components.Context) error {
 	if err != nil {
 		return err
 	}
-	format, err := outputFormat.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	format, err := utils.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	if err != nil {
+		return err
+	}
+	outputFiles, err := utils.ParseOutputFiles(c.GetStringFlagValue(flags.Output))
 	if err != nil {
 		return err
 	}
@@ -299,6 +339,7 @@ func BuildScan(c *components.Context) error {
 		SetFailBuild(c.GetBoolFlagValue(flags.Fail)).
 		SetBuildConfiguration(buildConfiguration).
 		SetOutputFormat(format).
+		SetOutputFiles(outputFiles).
 		SetPrintExtendedTable(c.GetBoolFlagValue(flags.ExtendedTable)).
 		SetRescan(c.GetBoolFlagValue(flags.Rescan))
 	if format != outputFormat.Sarif {
@@ -315,7 +356,7 @@ func AuditCmd(c *components.Context) error {
 	}
 
 	// Check if user used specific technologies flags
-	allTechnologies := coreutils.GetAllTechnologiesList()
+	allTechnologies := utils.GetAllTechnologiesList()
 	technologies := []string{}
 	for _, tech := range allTechnologies {
 		var techExists bool
@@ -329,7 +370,10 @@ func AuditCmd(c *components.Context) error {
 			technologies = append(technologies, tech.String())
 		}
 	}
-	auditCmd.SetTechnologies(technologies)
+	if len(technologies) > 0 {
+		// The technologies flags override the technologies of the security configuration file.
+		auditCmd.SetTechnologies(technologies)
+	}
 	err = progressbar.ExecWithProgress(auditCmd)
 
 	// Reporting error if Xsc service is enabled
@@ -358,45 +402,90 @@ func createAuditCmd(c *components.Context) (*audit.AuditCommand, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = validateXrayContext(c, serverDetails)
+	securityConfig, err := utils.LoadSecurityConfig(utils.SecurityConfigFilePath)
 	if err != nil {
 		return nil, err
 	}
-	format, err := outputFormat.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	var auditConfig *utils.AuditConfig
+	if securityConfig != nil {
+		log.Debug("Using the audit options of the security configuration file:", utils.SecurityConfigFilePath)
+		auditConfig = &securityConfig.Audit
+		auditCmd.SetTechnologies(auditConfig.Technologies)
+	}
+	auditFlags := newAuditFlagValues(newCommandLineFlagValues(c, os.Args[1:]), auditConfig)
+	err = validateXrayContext(auditFlags, serverDetails)
 	if err != nil {
 		return nil, err
 	}
-	minSeverity, err := utils.GetSeveritiesFormat(c.GetStringFlagValue(flags.MinSeverity))
+	format, err := utils.GetOutputFormat(auditFlags.GetStringFlagValue(flags.OutputFormat))
 	if err != nil {
 		return nil, err
 	}
+	outputFiles, err := utils.ParseOutputFiles(auditFlags.GetStringFlagValue(flags.Output))
+	if err != nil {
+		return nil, err
+	}
+	minSeverity, err := utils.GetSeveritiesFormat(auditFlags.GetStringFlagValue(flags.MinSeverity))
+	if err != nil {
+		return nil, err
+	}
+	threads, err := cliutils.GetThreadsCount(auditFlags.GetStringFlagValue(flags.Threads))
+	if err != nil {
+		return nil, err
+	}
+	scansToPerform, err := utils.ParseSubScans(auditFlags.GetStringFlagValue(flags.Scanners))
+	if err != nil {
+		return nil, err
+	}
+	timeouts, err := utils.ParseAuditTimeouts(auditFlags.GetStringFlagValue(flags.Timeout))
+	if err != nil {
+		return nil, err
+	}
+	failConditions, err := getFailConditions(auditFlags)
+	if err != nil {
+		return nil, err
+	}
+	if auditFlags.GetStringFlagValue(flags.ExportDeps) != "" && auditFlags.GetStringFlagValue(flags.FromDeps) != "" {
+		return nil, errorutils.CheckErrorf("the --%s and --%s options can't be used together", flags.ExportDeps, flags.FromDeps)
+	}
 	auditCmd.SetAnalyticsMetricsService(utils.NewAnalyticsMetricsService(serverDetails))
 
-	auditCmd.SetTargetRepoPath(addTrailingSlashToRepoPathIfNeeded(c)).
-		SetProject(c.GetStringFlagValue(flags.Project)).
-		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(c)).
-		SetIncludeLicenses(c.GetBoolFlagValue(flags.Licenses)).
-		SetFail(c.GetBoolFlagValue(flags.Fail)).
-		SetPrintExtendedTable(c.GetBoolFlagValue(flags.ExtendedTable)).
+	auditCmd.SetTargetRepoPath(addTrailingSlashToRepoPathIfNeeded(auditFlags)).
+		SetProject(auditFlags.GetStringFlagValue(flags.Project)).
+		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(auditFlags)).
+		SetIncludeLicenses(auditFlags.GetBoolFlagValue(flags.Licenses)).
+		SetFail(auditFlags.GetBoolFlagValue(flags.Fail)).
+		SetFailConditions(failConditions).
+		SetPrintExtendedTable(auditFlags.GetBoolFlagValue(flags.ExtendedTable)).
+		SetReportFile(auditFlags.GetStringFlagValue(flags.ReportFile)).
+		SetCsvDir(auditFlags.GetStringFlagValue(flags.CsvDir)).
+		SetOutputFiles(outputFiles).
+		SetBaselineFile(auditFlags.GetStringFlagValue(flags.Baseline)).
+		SetChangedSince(auditFlags.GetStringFlagValue(flags.ChangedSince)).
+		SetExportDepsFile(auditFlags.GetStringFlagValue(flags.ExportDeps)).
+		SetFromDepsFile(auditFlags.GetStringFlagValue(flags.FromDeps)).
 		SetMinSeverityFilter(minSeverity).
-		SetFixableOnly(c.GetBoolFlagValue(flags.FixableOnly)).
-		SetThirdPartyApplicabilityScan(c.GetBoolFlagValue(flags.ThirdPartyContextualAnalysis))
+		SetFixableOnly(auditFlags.GetBoolFlagValue(flags.FixableOnly)).
+		SetThirdPartyApplicabilityScan(auditFlags.GetBoolFlagValue(flags.ThirdPartyContextualAnalysis)).
+		SetThreads(threads).
+		SetScansToPerform(scansToPerform).
+		SetTimeouts(timeouts)
 
-	if c.GetStringFlagValue(flags.Watches) != "" {
-		auditCmd.SetWatches(splitByCommaAndTrim(c.GetStringFlagValue(flags.Watches)))
+	if auditFlags.GetStringFlagValue(flags.Watches) != "" {
+		auditCmd.SetWatches(splitByCommaAndTrim(auditFlags.GetStringFlagValue(flags.Watches)))
 	}
 
-	if c.GetStringFlagValue(flags.WorkingDirs) != "" {
-		auditCmd.SetWorkingDirs(splitByCommaAndTrim(c.GetStringFlagValue(flags.WorkingDirs)))
+	if auditFlags.GetStringFlagValue(flags.WorkingDirs) != "" {
+		auditCmd.SetWorkingDirs(splitByCommaAndTrim(auditFlags.GetStringFlagValue(flags.WorkingDirs)))
 	}
 	auditCmd.SetServerDetails(serverDetails).
-		SetExcludeTestDependencies(c.GetBoolFlagValue(flags.ExcludeTestDeps)).
+		SetExcludeTestDependencies(auditFlags.GetBoolFlagValue(flags.ExcludeTestDeps)).
 		SetOutputFormat(format).
-		SetUseWrapper(c.GetBoolFlagValue(flags.UseWrapper)).
-		SetInsecureTls(c.GetBoolFlagValue(flags.InsecureTls)).
-		SetNpmScope(c.GetStringFlagValue(flags.DepType)).
-		SetPipRequirementsFile(c.GetStringFlagValue(flags.RequirementsFile)).
-		SetExclusions(pluginsCommon.GetStringsArrFlagValue(c, flags.Exclusions))
+		SetUseWrapper(auditFlags.GetBoolFlagValue(flags.UseWrapper)).
+		SetInsecureTls(auditFlags.GetBoolFlagValue(flags.InsecureTls)).
+		SetNpmScope(auditFlags.GetStringFlagValue(flags.DepType)).
+		SetPipRequirementsFile(auditFlags.GetStringFlagValue(flags.RequirementsFile)).
+		SetExclusions(getStringsArrFlagValue(auditFlags, flags.Exclusions))
 	return auditCmd, err
 }
 
@@ -478,7 +567,11 @@ func DockerScan(c *components.Context, image string) error {
 		return err
 	}
 	containerScanCommand := scan.NewDockerScanCommand()
-	format, err := outputFormat.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	format, err := utils.GetOutputFormat(c.GetStringFlagValue(flags.OutputFormat))
+	if err != nil {
+		return err
+	}
+	outputFiles, err := utils.ParseOutputFiles(c.GetStringFlagValue(flags.Output))
 	if err != nil {
 		return err
 	}
@@ -490,6 +583,7 @@ func DockerScan(c *components.Context, image string) error {
 		SetTargetRepoPath(addTrailingSlashToRepoPathIfNeeded(c)).
 		SetServerDetails(serverDetails).
 		SetOutputFormat(format).
+		SetOutputFiles(outputFiles).
 		SetProject(c.GetStringFlagValue(flags.Project)).
 		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(c)).
 		SetIncludeLicenses(c.GetBoolFlagValue(flags.Licenses)).
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	flags "github.com/jfrog/jfrog-cli-security/cli/docs"
+	"github.com/jfrog/jfrog-cli-security/utils"
+	"golang.org/x/exp/slices"
+)
+
+// The flag values that the audit command reads.
+type flagValues interface {
+	GetStringFlagValue(flagName string) string
+	GetBoolFlagValue(flagName string) bool
+	IsFlagSet(flagName string) bool
+}
+
+// The flag values of a command context, where a flag is set only if it was provided in the command line arguments.
+// The command context holds all the flags of the command, with the default values of the flags that weren't provided, and reports all of them as set.
+type commandLineFlagValues struct {
+	flagValues
+	args []string
+}
+
+func newCommandLineFlagValues(c flagValues, args []string) *commandLineFlagValues {
+	return &commandLineFlagValues{flagValues: c, args: args}
+}
+
+func (values *commandLineFlagValues) IsFlagSet(flagName string) bool {
+	for _, arg := range values.args {
+		if arg == "--" {
+			// The rest of the arguments aren't flags.
+			return false
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "="); name == flagName {
+			return true
+		}
+	}
+	return false
+}
+
+// Reads the flags of the audit command, with the values of the security configuration file as the defaults of the flags.
+// A flag that was provided overrides the configuration, even if its value is the default value of the flag.
+// The flags that were neither provided nor configured hold their default values.
+type auditFlagValues struct {
+	flagValues
+	defaultStringValues map[string]string
+	defaultBoolValues   map[string]bool
+	configStringValues  map[string]string
+	configBoolValues    map[string]bool
+}
+
+func newAuditFlagValues(c flagValues, config *utils.AuditConfig) *auditFlagValues {
+	values := &auditFlagValues{
+		flagValues:          c,
+		defaultStringValues: map[string]string{},
+		defaultBoolValues:   map[string]bool{},
+		configStringValues:  map[string]string{},
+		configBoolValues:    map[string]bool{},
+	}
+	for _, flag := range flags.GetCommandFlags(flags.Audit) {
+		switch typedFlag := flag.(type) {
+		case components.StringFlag:
+			values.defaultStringValues[typedFlag.Name] = typedFlag.DefaultValue
+		case components.BoolFlag:
+			values.defaultBoolValues[typedFlag.Name] = typedFlag.DefaultValue
+		}
+	}
+	if config == nil {
+		return values
+	}
+	setString := func(flagName, value string) {
+		if value != "" {
+			values.configStringValues[flagName] = value
+		}
+	}
+	setString(flags.Watches, strings.Join(config.Watches, ","))
+	setString(flags.Project, config.Project)
+	setString(flags.RepoPath, config.RepoPath)
+	setString(flags.OutputFormat, config.Format)
+	setString(flags.Output, config.OutputFlagValue())
+	setString(flags.ReportFile, config.ReportFile)
+	setString(flags.CsvDir, config.CsvDir)
+	setString(flags.Baseline, config.Baseline)
+	setString(flags.ChangedSince, config.ChangedSince)
+	setString(flags.ExportDeps, config.ExportDeps)
+	setString(flags.FromDeps, config.FromDeps)
+	setString(flags.DepType, config.DepType)
+	setString(flags.RequirementsFile, config.RequirementsFile)
+	setString(flags.WorkingDirs, strings.Join(config.WorkingDirs, ","))
+	setString(flags.Exclusions, strings.Join(config.Exclusions, ";"))
+	setString(flags.MinSeverity, config.MinSeverity)
+	setString(flags.Scanners, strings.Join(config.Scanners, ","))
+	setString(flags.Timeout, config.Timeout)
+	setString(flags.FailOn, strings.Join(config.FailOn, ";"))
+	if config.Threads != nil {
+		setString(flags.Threads, strconv.Itoa(*config.Threads))
+	}
+	if config.FailOnCvss != nil {
+		setString(flags.FailOnCvss, strconv.FormatFloat(*config.FailOnCvss, 'f', -1, 64))
+	}
+	setBool := func(flagName string, value *bool) {
+		if value != nil {
+			values.configBoolValues[flagName] = *value
+		}
+	}
+	setBool(flags.Licenses, config.Licenses)
+	setBool(flags.ExcludeTestDeps, config.ExcludeTestDeps)
+	setBool(flags.UseWrapper, config.UseWrapper)
+	setBool(flags.Fail, config.Fail)
+	setBool(flags.FailOnSecrets, config.FailOnSecrets)
+	setBool(flags.ExtendedTable, config.ExtendedTable)
+	setBool(flags.FixableOnly, config.FixableOnly)
+	setBool(flags.ThirdPartyContextualAnalysis, config.ThirdPartyContextualAnalysis)
+	setBool(flags.InsecureTls, config.InsecureTls)
+	// Only one Xray context can be used, so a context that was provided replaces the whole context of the configuration.
+	xrayContextFlags := []string{flags.Watches, flags.Project, flags.RepoPath}
+	if slices.ContainsFunc(xrayContextFlags, c.IsFlagSet) {
+		for _, flagName := range xrayContextFlags {
+			delete(values.configStringValues, flagName)
+		}
+	}
+	return values
+}
+
+func (values *auditFlagValues) GetStringFlagValue(flagName string) string {
+	if configValue, exists := values.configStringValues[flagName]; exists && !values.flagValues.IsFlagSet(flagName) {
+		return configValue
+	}
+	return values.flagValues.GetStringFlagValue(flagName)
+}
+
+func (values *auditFlagValues) GetBoolFlagValue(flagName string) bool {
+	if configValue, exists := values.configBoolValues[flagName]; exists && !values.flagValues.IsFlagSet(flagName) {
+		return configValue
+	}
+	return values.flagValues.GetBoolFlagValue(flagName)
+}
+
+// A flag is set if it was provided, if it is in the configuration, or if it has a default value.
+func (values *auditFlagValues) IsFlagSet(flagName string) bool {
+	if values.flagValues.IsFlagSet(flagName) {
+		return true
+	}
+	if _, exists := values.configStringValues[flagName]; exists {
+		return true
+	}
+	if _, exists := values.configBoolValues[flagName]; exists {
+		return true
+	}
+	if _, exists := values.defaultBoolValues[flagName]; exists {
+		return true
+	}
+	return values.defaultStringValues[flagName] != ""
+}
+
+func getStringsArrFlagValue(c flagValues, flagName string) (resultArray []string) {
+	if c.IsFlagSet(flagName) {
+		resultArray = append(resultArray, strings.Split(c.GetStringFlagValue(flagName), ";")...)
+	}
+	return
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"testing"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	flags "github.com/jfrog/jfrog-cli-security/cli/docs"
+	"github.com/jfrog/jfrog-cli-security/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slices"
+)
+
+// The values hold the default values of the flags that weren't provided, like the command context.
+type testFlagValues struct {
+	stringValues  map[string]string
+	boolValues    map[string]bool
+	providedFlags []string
+}
+
+func (f testFlagValues) GetStringFlagValue(flagName string) string {
+	return f.stringValues[flagName]
+}
+
+func (f testFlagValues) GetBoolFlagValue(flagName string) bool {
+	return f.boolValues[flagName]
+}
+
+func (f testFlagValues) IsFlagSet(flagName string) bool {
+	return slices.Contains(f.providedFlags, flagName)
+}
+
+func TestAuditFlagValuesPrecedence(t *testing.T) {
+	fail, licenses, threads := false, true, 8
+	config := &utils.AuditConfig{
+		Watches:     []string{"watch1", "watch2"},
+		MinSeverity: "High",
+		Format:      "sarif",
+		Exclusions:  []string{"*dist*", "*test*"},
+		Fail:        &fail,
+		Licenses:    &licenses,
+		Threads:     &threads,
+	}
+	cliValues := testFlagValues{
+		stringValues:  map[string]string{flags.MinSeverity: "Critical", flags.OutputFormat: "table", flags.Threads: "3", flags.Exclusions: "*node_modules*"},
+		boolValues:    map[string]bool{flags.Fail: true, flags.Licenses: false, flags.FixableOnly: true},
+		providedFlags: []string{flags.MinSeverity, flags.Exclusions, flags.FixableOnly},
+	}
+	auditFlags := newAuditFlagValues(cliValues, config)
+	// Provided flags.
+	assert.Equal(t, "Critical", auditFlags.GetStringFlagValue(flags.MinSeverity))
+	assert.Equal(t, []string{"*node_modules*"}, getStringsArrFlagValue(auditFlags, flags.Exclusions))
+	assert.True(t, auditFlags.GetBoolFlagValue(flags.FixableOnly))
+	// Flags with their default values.
+	assert.Equal(t, "watch1,watch2", auditFlags.GetStringFlagValue(flags.Watches))
+	assert.Equal(t, "sarif", auditFlags.GetStringFlagValue(flags.OutputFormat))
+	assert.Equal(t, "8", auditFlags.GetStringFlagValue(flags.Threads))
+	assert.False(t, auditFlags.GetBoolFlagValue(flags.Fail))
+	assert.True(t, auditFlags.GetBoolFlagValue(flags.Licenses))
+	assert.False(t, shouldIncludeVulnerabilities(auditFlags))
+	// Not in the configuration.
+	assert.Equal(t, "", auditFlags.GetStringFlagValue(flags.RepoPath))
+	assert.False(t, auditFlags.IsFlagSet(flags.RepoPath))
+
+	// Provided flags override the configuration even if their values are the default values.
+	cliValues.stringValues[flags.Watches] = ""
+	cliValues.providedFlags = []string{flags.OutputFormat, flags.Threads, flags.Watches, flags.Fail, flags.Licenses}
+	auditFlags = newAuditFlagValues(cliValues, config)
+	assert.Equal(t, "table", auditFlags.GetStringFlagValue(flags.OutputFormat))
+	assert.Equal(t, "3", auditFlags.GetStringFlagValue(flags.Threads))
+	assert.Equal(t, "", auditFlags.GetStringFlagValue(flags.Watches))
+	assert.True(t, auditFlags.GetBoolFlagValue(flags.Fail))
+	assert.False(t, auditFlags.GetBoolFlagValue(flags.Licenses))
+	assert.True(t, shouldIncludeVulnerabilities(auditFlags))
+	// The configuration is used for the flags that weren't provided.
+	assert.Equal(t, "High", auditFlags.GetStringFlagValue(flags.MinSeverity))
+	assert.Equal(t, []string{"*dist*", "*test*"}, getStringsArrFlagValue(auditFlags, flags.Exclusions))
+
+	// A provided Xray context replaces all the Xray context values of the configuration.
+	config.Project, config.RepoPath = "config-project", "config/path"
+	for _, contextFlag := range []string{flags.Project, flags.RepoPath} {
+		contextValues := testFlagValues{stringValues: map[string]string{contextFlag: "cli-context"}, providedFlags: []string{contextFlag}}
+		auditFlags = newAuditFlagValues(contextValues, config)
+		assert.Equal(t, "cli-context", auditFlags.GetStringFlagValue(contextFlag))
+		for _, flagName := range []string{flags.Watches, flags.Project, flags.RepoPath} {
+			if flagName != contextFlag {
+				assert.Equal(t, "", auditFlags.GetStringFlagValue(flagName))
+				assert.False(t, auditFlags.IsFlagSet(flagName))
+			}
+		}
+		assert.NoError(t, validateXrayContext(auditFlags, &coreConfig.ServerDetails{XrayUrl: "http://localhost/xray/"}))
+	}
+
+	// Without a configuration file, the flag values are used as is.
+	cliValues.providedFlags = nil
+	auditFlags = newAuditFlagValues(cliValues, nil)
+	assert.Equal(t, "table", auditFlags.GetStringFlagValue(flags.OutputFormat))
+	assert.True(t, auditFlags.GetBoolFlagValue(flags.Fail))
+	assert.True(t, shouldIncludeVulnerabilities(auditFlags))
+}
+
+func TestCommandLineFlagValues(t *testing.T) {
+	values := newCommandLineFlagValues(testFlagValues{}, []string{"audit", "--format=table", "--fail=true", "-threads", "3", "--watches=", "--", "--project=my-project"})
+	assert.True(t, values.IsFlagSet(flags.OutputFormat))
+	assert.True(t, values.IsFlagSet(flags.Fail))
+	assert.True(t, values.IsFlagSet(flags.Threads))
+	assert.True(t, values.IsFlagSet(flags.Watches))
+	// Not provided, or provided after the end of the flags.
+	assert.False(t, values.IsFlagSet(flags.MinSeverity))
+	assert.False(t, values.IsFlagSet(flags.Project))
+}
@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -13,6 +14,7 @@ import (
 	xscservices "github.com/jfrog/jfrog-client-go/xsc/services"
 	"golang.org/x/sync/errgroup"
 	"os"
+	"os/signal"
 
 	xrayutils "github.com/jfrog/jfrog-cli-security/utils"
 )
@@ -25,6 +27,14 @@ type AuditCommand struct {
 	IncludeLicenses         bool
 	Fail                    bool
 	PrintExtendedTable      bool
+	reportFile              string
+	csvDir                  string
+	outputFiles             []xrayutils.OutputFile
+	baselineFile            string
+	changedSince            string
+	exportDepsFile          string
+	fromDepsFile            string
+	failConditions          []*xrayutils.FailCondition
 	analyticsMetricsService *xrayutils.AnalyticsMetricsService
 	AuditParams
 }
@@ -63,11 +73,52 @@ func (auditCmd *AuditCommand) SetFail(fail bool) *AuditCommand {
 	return auditCmd
 }
 
+// The local conditions that fail the build if any finding matches them, regardless of the Xray watches.
+func (auditCmd *AuditCommand) SetFailConditions(failConditions []*xrayutils.FailCondition) *AuditCommand {
+	auditCmd.failConditions = failConditions
+	return auditCmd
+}
+
 func (auditCmd *AuditCommand) SetPrintExtendedTable(printExtendedTable bool) *AuditCommand {
 	auditCmd.PrintExtendedTable = printExtendedTable
 	return auditCmd
 }
 
+func (auditCmd *AuditCommand) SetReportFile(reportFile string) *AuditCommand {
+	auditCmd.reportFile = reportFile
+	return auditCmd
+}
+
+func (auditCmd *AuditCommand) SetCsvDir(csvDir string) *AuditCommand {
+	auditCmd.csvDir = csvDir
+	return auditCmd
+}
+
+func (auditCmd *AuditCommand) SetBaselineFile(baselineFile string) *AuditCommand {
+	auditCmd.baselineFile = baselineFile
+	return auditCmd
+}
+
+func (auditCmd *AuditCommand) SetChangedSince(changedSince string) *AuditCommand {
+	auditCmd.changedSince = changedSince
+	return auditCmd
+}
+
+func (auditCmd *AuditCommand) SetExportDepsFile(exportDepsFile string) *AuditCommand {
+	auditCmd.exportDepsFile = exportDepsFile
+	return auditCmd
+}
+
+func (auditCmd *AuditCommand) SetFromDepsFile(fromDepsFile string) *AuditCommand {
+	auditCmd.fromDepsFile = fromDepsFile
+	return auditCmd
+}
+
+func (auditCmd *AuditCommand) SetOutputFiles(outputFiles []xrayutils.OutputFile) *AuditCommand {
+	auditCmd.outputFiles = outputFiles
+	return auditCmd
+}
+
 func (auditCmd *AuditCommand) SetAnalyticsMetricsService(analyticsMetricsService *xrayutils.AnalyticsMetricsService) *AuditCommand {
 	auditCmd.analyticsMetricsService = analyticsMetricsService
 	return auditCmd
@@ -107,6 +158,35 @@ func (auditCmd *AuditCommand) Run() (err error) {
 	if err != nil {
 		return
 	}
+	var baseline *xrayutils.Baseline
+	if auditCmd.baselineFile != "" {
+		if baseline, err = xrayutils.LoadBaseline(auditCmd.baselineFile); err != nil {
+			return
+		}
+	}
+	// The suppressions file is validated before the scans start.
+	suppressions, err := xrayutils.LoadSuppressions(xrayutils.SuppressionsFileName)
+	if err != nil {
+		return
+	}
+	var changedFiles []string
+	if auditCmd.changedSince != "" {
+		if changedFiles, err = xrayutils.GetChangedFiles(workingDirs, auditCmd.changedSince); err != nil {
+			return
+		}
+		log.Info(fmt.Sprintf("Auditing only the projects and files that changed since '%s' (%d changed files)", auditCmd.changedSince, len(changedFiles)))
+	}
+	// The paths of the exported dependency trees are relative to the current directory.
+	currentDir, err := coreutils.GetWorkingDirectory()
+	if err != nil {
+		return
+	}
+	var dependencyTrees []ProjectDependencyTree
+	if auditCmd.fromDepsFile != "" {
+		if dependencyTrees, err = loadDependencyTrees(currentDir, auditCmd.fromDepsFile); err != nil {
+			return
+		}
+	}
 
 	// Should be called before creating the audit params, so the params will contain XSC information.
 	auditCmd.analyticsMetricsService.AddGeneralEvent(auditCmd.analyticsMetricsService.CreateGeneralEvent(xscservices.CliProduct, xscservices.CliEventType))
@@ -116,13 +196,31 @@ func (auditCmd *AuditCommand) Run() (err error) {
 		SetMinSeverityFilter(auditCmd.minSeverityFilter).
 		SetFixableOnly(auditCmd.fixableOnly).
 		SetGraphBasicParams(auditCmd.AuditBasicParams).
-		SetThirdPartyApplicabilityScan(auditCmd.thirdPartyApplicabilityScan)
+		SetThirdPartyApplicabilityScan(auditCmd.thirdPartyApplicabilityScan).
+		SetThreads(auditCmd.threads).
+		SetScansToPerform(auditCmd.scansToPerform).
+		SetTimeouts(auditCmd.timeouts).
+		SetChangedFiles(changedFiles).
+		SetDependencyTrees(dependencyTrees)
 	auditParams.SetIsRecursiveScan(isRecursiveScan).SetExclusions(auditCmd.Exclusions())
 
-	auditResults, err := RunAudit(auditParams)
+	// Stop the scans on interrupt, so the results that were collected so far are printed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if auditCmd.exportDepsFile != "" {
+		// Only the dependencies are resolved, the trees are scanned later with --from-deps.
+		return exportDependencyTrees(ctx, auditParams, currentDir, auditCmd.exportDepsFile)
+	}
+	auditResults, err := RunAudit(ctx, auditParams)
 	if err != nil {
 		return
 	}
+	if suppressions != nil {
+		suppressions.SuppressFindings(auditResults)
+	}
+	if baseline != nil {
+		baseline.ExcludeExistingFindings(auditResults)
+	}
 	auditCmd.analyticsMetricsService.UpdateGeneralEvent(auditCmd.analyticsMetricsService.CreateXscAnalyticsGeneralEventFinalizeFromAuditResults(auditResults))
 	if auditCmd.Progress() != nil {
 		if err = auditCmd.Progress().Quit(); err != nil {
@@ -133,10 +231,11 @@ func (auditCmd *AuditCommand) Run() (err error) {
 	if !auditResults.ExtendedScanResults.EntitledForJas {
 		messages = []string{coreutils.PrintTitle("The ‘jf audit’ command also supports JFrog Advanced Security features, such as 'Contextual Analysis', 'Secret Detection', 'IaC Scan' and ‘SAST’.\nThis feature isn't enabled on your system. Read more - ") + coreutils.PrintLink("https://jfrog.com/xray/")}
 	}
-	// Print Scan results on all cases except if errors accrued on SCA scan and no security/license issues found.
-	printScanResults := !(auditResults.ScaError != nil && !auditResults.IsScaIssuesFound())
+	// Print Scan results on all cases except if errors accrued on SCA scan and no project was scanned successfully.
+	// Partial results are printed when the scans were stopped by a timeout or an interrupt.
+	printScanResults := auditResults.ScaError == nil || auditResults.IsAnyScaScanSucceeded() || isCanceledError(auditResults.ScaError)
 	if printScanResults {
-		if err = xrayutils.NewResultsWriter(auditResults).
+		resultsWriter := xrayutils.NewResultsWriter(auditResults).
 			SetIsMultipleRootProject(auditResults.IsMultipleProject()).
 			SetIncludeVulnerabilities(auditCmd.IncludeVulnerabilities).
 			SetIncludeLicenses(auditCmd.IncludeLicenses).
@@ -144,17 +243,36 @@ func (auditCmd *AuditCommand) Run() (err error) {
 			SetPrintExtendedTable(auditCmd.PrintExtendedTable).
 			SetExtraMessages(messages).
 			SetScanType(services.Dependency).
-			PrintScanResults(); err != nil {
+			SetOutputFiles(auditCmd.outputFiles)
+		if err = resultsWriter.PrintScanResults(); err != nil {
 			return
 		}
+		if err = resultsWriter.WriteScanResultsToFiles(); err != nil {
+			return
+		}
+		if auditCmd.reportFile != "" {
+			if err = resultsWriter.WriteHtmlReport(auditCmd.reportFile); err != nil {
+				return
+			}
+		}
+		if auditCmd.csvDir != "" {
+			if err = resultsWriter.WriteCsvFiles(auditCmd.csvDir); err != nil {
+				return
+			}
+		}
 	}
-	if err = errors.Join(auditResults.ScaError, auditResults.JasError); err != nil {
+	err = errors.Join(auditResults.ScaError, auditResults.JasError)
+	if err != nil && !printScanResults {
 		return
 	}
 
 	// Only in case Xray's context was given (!auditCmd.IncludeVulnerabilities), and the user asked to fail the build accordingly, do so.
+	// The build fails on the results of the projects that were scanned successfully, even if the scans of other projects failed.
 	if auditCmd.Fail && !auditCmd.IncludeVulnerabilities && xrayutils.CheckIfFailBuild(auditResults.GetScaScansXrayResults()) {
-		err = xrayutils.NewFailBuildError()
+		err = errors.Join(xrayutils.NewFailBuildError(), err)
+	}
+	if failErr := xrayutils.CheckFailConditions(auditResults, auditCmd.failConditions); failErr != nil {
+		err = errors.Join(failErr, err)
 	}
 	return
 }
@@ -166,9 +284,12 @@ func (auditCmd *AuditCommand) CommandName() string {
 // Runs an audit scan based on the provided auditParams.
 // Returns an audit Results object containing all the scan results.
 // If the current server is entitled for JAS, the advanced security results will be included in the scan results.
-func RunAudit(auditParams *AuditParams) (results *xrayutils.Results, err error) {
+// When the context is done or a timeout expires, the scans that didn't complete are stopped, and the results that were collected so far are returned along with the scan errors.
+func RunAudit(ctx context.Context, auditParams *AuditParams) (results *xrayutils.Results, err error) {
 	// Initialize Results struct
 	results = xrayutils.NewAuditResults()
+	ctx, cancel := xrayutils.WithTimeout(ctx, auditParams.timeouts.Total, "audit")
+	defer cancel()
 
 	serverDetails, err := auditParams.ServerDetails()
 	if err != nil {
@@ -186,30 +307,62 @@ func RunAudit(auditParams *AuditParams) (results *xrayutils.Results, err error)
 	if err != nil {
 		return
 	}
+	if err = xrayutils.CheckSubScansEntitlement(auditParams.scansToPerform, results.ExtendedScanResults.EntitledForJas); err != nil {
+		return
+	}
 
+	runJas := results.ExtendedScanResults.EntitledForJas && xrayutils.IsJasSubScanRequested(auditParams.scansToPerform)
 	errGroup := new(errgroup.Group)
-	if results.ExtendedScanResults.EntitledForJas {
+	if runJas {
 		// Download (if needed) the analyzer manager in a background routine.
 		errGroup.Go(utils.DownloadAnalyzerManagerIfNeeded)
 	}
 
 	results.MultiScanId = auditParams.XrayGraphScanParams().MultiScanId
 
+	// Run scanners only if the user is entitled for Advanced Security.
+	// The secrets, IaC and SAST scanners don't depend on the SCA results, so they run concurrently with the sca scan.
+	var runApplicabilityScanAndWait func(directDependencies []string) error
+	jasCtx, cancelJas := xrayutils.WithTimeout(ctx, auditParams.timeouts.Jas, "JAS scan")
+	defer cancelJas()
+	if runJas {
+		runApplicabilityScanAndWait = runJasScanners(jasCtx, results, serverDetails, auditParams.workingDirs, auditParams.changedFiles, auditParams.scansToPerform, auditParams.Progress(), auditParams.thirdPartyApplicabilityScan, results.MultiScanId, errGroup.Wait)
+	}
+
 	// The sca scan doesn't require the analyzer manager, so it can run separately from the analyzer manager download routine.
-	results.ScaError = runScaScan(auditParams, results)
+	if xrayutils.IsSubScanRequested(auditParams.scansToPerform, xrayutils.ScaScan) {
+		scaCtx, cancelSca := xrayutils.WithTimeout(ctx, auditParams.timeouts.Sca, "SCA scan")
+		results.ScaError = errors.Join(runScaScan(scaCtx, auditParams, results), getTimeoutError(scaCtx))
+		cancelSca()
+	} else {
+		log.Info("Skipping SCA scanning")
+	}
 
 	// Wait for the Download of the AnalyzerManager to complete.
 	if err = errGroup.Wait(); err != nil {
 		err = errors.New("failed while trying to get Analyzer Manager: " + err.Error())
 	}
 
-	// Run scanners only if the user is entitled for Advanced Security
-	if results.ExtendedScanResults.EntitledForJas {
-		results.JasError = runJasScannersAndSetResults(results, auditParams.DirectDependencies(), serverDetails, auditParams.workingDirs, auditParams.Progress(), auditParams.thirdPartyApplicabilityScan, auditParams.XrayGraphScanParams().MultiScanId)
+	// The contextual analysis scan requires the CVEs that were found by the sca scan.
+	if runApplicabilityScanAndWait != nil {
+		results.JasError = errors.Join(runApplicabilityScanAndWait(auditParams.DirectDependencies()), getTimeoutError(jasCtx))
 	}
 	return
 }
 
+// Returns the reason for the context to be done, or nil if it isn't.
+func getTimeoutError(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
+
+// Returns true if the error was caused by an expired timeout or by a cancellation.
+func isCanceledError(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
 func isEntitledForJas(xrayManager *xray.XrayServicesManager, xrayVersion string) (entitled bool, err error) {
 	if e := clientutils.ValidateMinimumVersion(clientutils.Xray, xrayVersion, xrayutils.EntitlementsMinVersion); e != nil {
 		log.Debug(e)
@@ -15,12 +15,23 @@ type AuditParams struct {
 	xrayVersion string
 	// Include third party dependencies source code in the applicability scan.
 	thirdPartyApplicabilityScan bool
+	// The number of SCA scans that run concurrently.
+	threads int
+	// The scanners to run. All the scanners run if empty.
+	scansToPerform []xrayutils.SubScanType
+	timeouts       xrayutils.AuditTimeouts
+	// The files that changed since the git reference the audit is compared to.
+	// Only the projects and the source files that are affected by the changes are scanned. All of them are scanned if nil.
+	changedFiles []string
+	// The dependency trees that were imported with --from-deps. The imported trees are scanned instead of the detected projects if not nil.
+	dependencyTrees []ProjectDependencyTree
 }
 
 func NewAuditParams() *AuditParams {
 	return &AuditParams{
 		xrayGraphScanParams: &services.XrayGraphScanParams{},
 		AuditBasicParams:    &xrayutils.AuditBasicParams{},
+		threads:             1,
 	}
 }
 
@@ -83,6 +94,51 @@ func (params *AuditParams) SetThirdPartyApplicabilityScan(includeThirdPartyDeps
 	return params
 }
 
+func (params *AuditParams) Threads() int {
+	return params.threads
+}
+
+func (params *AuditParams) SetThreads(threads int) *AuditParams {
+	params.threads = threads
+	return params
+}
+
+func (params *AuditParams) ScansToPerform() []xrayutils.SubScanType {
+	return params.scansToPerform
+}
+
+func (params *AuditParams) SetScansToPerform(scansToPerform []xrayutils.SubScanType) *AuditParams {
+	params.scansToPerform = scansToPerform
+	return params
+}
+
+func (params *AuditParams) Timeouts() xrayutils.AuditTimeouts {
+	return params.timeouts
+}
+
+func (params *AuditParams) SetTimeouts(timeouts xrayutils.AuditTimeouts) *AuditParams {
+	params.timeouts = timeouts
+	return params
+}
+
+func (params *AuditParams) ChangedFiles() []string {
+	return params.changedFiles
+}
+
+func (params *AuditParams) SetChangedFiles(changedFiles []string) *AuditParams {
+	params.changedFiles = changedFiles
+	return params
+}
+
+func (params *AuditParams) DependencyTrees() []ProjectDependencyTree {
+	return params.dependencyTrees
+}
+
+func (params *AuditParams) SetDependencyTrees(dependencyTrees []ProjectDependencyTree) *AuditParams {
+	params.dependencyTrees = dependencyTrees
+	return params
+}
+
 func (params *AuditParams) SetDepsRepo(depsRepo string) *AuditParams {
 	params.AuditBasicParams.SetDepsRepo(depsRepo)
 	return params
@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayutils "github.com/jfrog/jfrog-cli-security/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The resolved dependency trees of the projects of an audit.
+// The trees are exported with --export-deps on a machine that can resolve the dependencies, and scanned with --from-deps without resolving them again.
+type DependencyTreesFile struct {
+	Trees []ProjectDependencyTree `json:"trees"`
+}
+
+type ProjectDependencyTree struct {
+	Technology coreutils.Technology `json:"technology"`
+	// The working directory and the descriptors are relative to the directory the trees were exported from, if they are inside it.
+	WorkingDirectory string   `json:"workingDirectory"`
+	Descriptors      []string `json:"descriptors,omitempty"`
+	DependencyTreeResult
+}
+
+// Builds the dependency trees of the detected projects and writes them to the given file, without scanning them.
+// The trees of the projects that were resolved are exported even if other projects failed, and the errors of the failed projects are returned.
+func exportDependencyTrees(ctx context.Context, params *AuditParams, baseDir, path string) (err error) {
+	scans := getScaScansToPreform(params)
+	if len(scans) == 0 {
+		return errorutils.CheckErrorf("couldn't determine a package manager or build tool used by this project, no dependency trees to export")
+	}
+	trees := make([]DependencyTreeResult, len(scans))
+	buildErrors := runConcurrently(params.Threads(), len(scans), func(index int) (buildErr error) {
+		if buildErr = ctx.Err(); buildErr != nil {
+			return
+		}
+		// The resolution repository is detected for each working directory, so each tree is built with its own copy of the params.
+		scanParams := *params.AuditBasicParams
+		trees[index], buildErr = GetTechDependencyTree(ctx, &scanParams, scans[index].WorkingDirectory, scans[index].Technology)
+		if buildErr == nil && (trees[index].FlatTree == nil || len(trees[index].FlatTree.Nodes) == 0) {
+			buildErr = errorutils.CheckErrorf("no dependencies were found. Please try to build your project and re-run the audit command")
+		}
+		return
+	})
+	treesFile := DependencyTreesFile{Trees: []ProjectDependencyTree{}}
+	for i, scan := range scans {
+		if buildErrors[i] != nil {
+			err = errors.Join(err, fmt.Errorf("failed while building '%s' dependency tree in '%s':\n%s", scan.Technology, scan.WorkingDirectory, buildErrors[i].Error()))
+			continue
+		}
+		tree := ProjectDependencyTree{Technology: scan.Technology, WorkingDirectory: toExportedPath(baseDir, scan.WorkingDirectory), DependencyTreeResult: trees[i]}
+		for _, descriptor := range scan.Descriptors {
+			tree.Descriptors = append(tree.Descriptors, toExportedPath(baseDir, descriptor))
+		}
+		treesFile.Trees = append(treesFile.Trees, tree)
+	}
+	content, marshalErr := json.MarshalIndent(treesFile, "", "  ")
+	if marshalErr != nil {
+		return errors.Join(err, errorutils.CheckError(marshalErr))
+	}
+	if writeErr := os.WriteFile(path, content, 0644); writeErr != nil {
+		return errors.Join(err, errorutils.CheckError(writeErr))
+	}
+	log.Info(fmt.Sprintf("Exported %d dependency trees to %s", len(treesFile.Trees), path))
+	return
+}
+
+// Loads the dependency trees that were exported with --export-deps, with their paths resolved relative to the given directory.
+func loadDependencyTrees(baseDir, path string) ([]ProjectDependencyTree, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var treesFile DependencyTreesFile
+	if err = json.Unmarshal(content, &treesFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the dependency trees file %s: %s", path, err.Error())
+	}
+	if treesFile.Trees == nil {
+		// The imported trees are scanned instead of the detected projects, even if there are none.
+		treesFile.Trees = []ProjectDependencyTree{}
+	}
+	for i := range treesFile.Trees {
+		tree := &treesFile.Trees[i]
+		if tree.Technology == "" || tree.FlatTree == nil {
+			return nil, errorutils.CheckErrorf("invalid dependency trees file %s: the technology and the flat tree of each project are required", path)
+		}
+		tree.WorkingDirectory = fromExportedPath(baseDir, tree.WorkingDirectory)
+		for j := range tree.Descriptors {
+			tree.Descriptors[j] = fromExportedPath(baseDir, tree.Descriptors[j])
+		}
+	}
+	return treesFile.Trees, nil
+}
+
+// The paths are exported relative to the base directory, so the trees can be scanned from a checkout of the project in another location.
+func toExportedPath(baseDir, path string) string {
+	if xrayutils.IsPathInDir(path, baseDir) {
+		if relative, err := filepath.Rel(baseDir, path); err == nil {
+			return filepath.ToSlash(relative)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+func fromExportedPath(baseDir, path string) string {
+	path = filepath.FromSlash(path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayutils "github.com/jfrog/jfrog-cli-security/utils"
+	xrayCmdUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportedPaths(t *testing.T) {
+	baseDir := filepath.Join(string(filepath.Separator)+"root", "project")
+	insidePath := filepath.Join(baseDir, "web", "package.json")
+	outsidePath := filepath.Join(string(filepath.Separator)+"root", "other", "go.mod")
+
+	assert.Equal(t, "web/package.json", toExportedPath(baseDir, insidePath))
+	assert.Equal(t, ".", toExportedPath(baseDir, baseDir))
+	assert.Equal(t, filepath.ToSlash(outsidePath), toExportedPath(baseDir, outsidePath))
+
+	otherBaseDir := filepath.Join(string(filepath.Separator)+"agent", "checkout")
+	assert.Equal(t, filepath.Join(otherBaseDir, "web", "package.json"), fromExportedPath(otherBaseDir, toExportedPath(baseDir, insidePath)))
+	assert.Equal(t, otherBaseDir, fromExportedPath(otherBaseDir, toExportedPath(baseDir, baseDir)))
+	assert.Equal(t, outsidePath, fromExportedPath(otherBaseDir, toExportedPath(baseDir, outsidePath)))
+}
+
+func TestLoadDependencyTrees(t *testing.T) {
+	baseDir := t.TempDir()
+	flatTree := &xrayCmdUtils.GraphNode{Id: "root", Nodes: []*xrayCmdUtils.GraphNode{{Id: "npm://lodash:4.17.0"}}}
+	fullTree := &xrayCmdUtils.GraphNode{Id: "npm://web:1.0.0", Nodes: []*xrayCmdUtils.GraphNode{{Id: "npm://lodash:4.17.0"}}}
+	content, err := json.Marshal(DependencyTreesFile{Trees: []ProjectDependencyTree{{
+		Technology:           coreutils.Npm,
+		WorkingDirectory:     "web",
+		Descriptors:          []string{"web/package.json"},
+		DependencyTreeResult: DependencyTreeResult{FlatTree: flatTree, FullDepTrees: []*xrayCmdUtils.GraphNode{fullTree}},
+	}}})
+	assert.NoError(t, err)
+	treesPath := filepath.Join(baseDir, "deps.json")
+	assert.NoError(t, os.WriteFile(treesPath, content, 0644))
+
+	trees, err := loadDependencyTrees(baseDir, treesPath)
+	assert.NoError(t, err)
+	if assert.Len(t, trees, 1) {
+		assert.Equal(t, coreutils.Npm, trees[0].Technology)
+		assert.Equal(t, filepath.Join(baseDir, "web"), trees[0].WorkingDirectory)
+		assert.Equal(t, []string{filepath.Join(baseDir, "web", "package.json")}, trees[0].Descriptors)
+		assert.Equal(t, flatTree, trees[0].FlatTree)
+		assert.Equal(t, []*xrayCmdUtils.GraphNode{fullTree}, trees[0].FullDepTrees)
+	}
+
+	// The trees of the file are scanned even if there are none.
+	assert.NoError(t, os.WriteFile(treesPath, []byte("{}"), 0644))
+	trees, err = loadDependencyTrees(baseDir, treesPath)
+	assert.NoError(t, err)
+	assert.NotNil(t, trees)
+	assert.Empty(t, trees)
+
+	assert.NoError(t, os.WriteFile(treesPath, []byte(`{"trees": [{"technology": "npm", "workingDirectory": "web"}]}`), 0644))
+	_, err = loadDependencyTrees(baseDir, treesPath)
+	assert.ErrorContains(t, err, "the technology and the flat tree of each project are required")
+
+	_, err = loadDependencyTrees(baseDir, filepath.Join(baseDir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestGetImportedScaScans(t *testing.T) {
+	trees := []ProjectDependencyTree{
+		{Technology: coreutils.Npm, WorkingDirectory: "web", Descriptors: []string{"web/package.json"}, DependencyTreeResult: DependencyTreeResult{FlatTree: &xrayCmdUtils.GraphNode{Id: "web"}}},
+		{Technology: coreutils.Go, WorkingDirectory: "api", DependencyTreeResult: DependencyTreeResult{FlatTree: &xrayCmdUtils.GraphNode{Id: "api"}}},
+	}
+	scans, scansTrees := getImportedScaScans(trees)
+	assert.Equal(t, []*xrayutils.ScaScanResult{
+		{Technology: coreutils.Npm, WorkingDirectory: "web", Descriptors: []string{"web/package.json"}},
+		{Technology: coreutils.Go, WorkingDirectory: "api"},
+	}, scans)
+	if assert.Len(t, scansTrees, 2) {
+		assert.Equal(t, "web", scansTrees[0].FlatTree.Id)
+		assert.Equal(t, "api", scansTrees[1].FlatTree.Id)
+	}
+}
+
+func TestRunConcurrently(t *testing.T) {
+	var tasksRun atomic.Int32
+	tasksErrors := runConcurrently(3, 5, func(index int) error {
+		tasksRun.Add(1)
+		if index%2 == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	assert.Equal(t, int32(5), tasksRun.Load())
+	assert.Equal(t, []error{nil, errors.New("failed"), nil, errors.New("failed"), nil}, tasksErrors)
+	assert.Empty(t, runConcurrently(1, 0, func(int) error { return nil }))
+}
@@ -1,6 +1,7 @@
 package applicability
 
 import (
+	"context"
 	"path/filepath"
 
 	jfrogappsconfig "github.com/jfrog/jfrog-apps-config/go"
@@ -40,14 +41,14 @@ type ApplicabilityScanManager struct {
 // map[string]string: A map containing the applicability result of each XRAY CVE.
 // bool: true if the user is entitled to the applicability scan, false otherwise.
 // error: An error object (if any).
-func RunApplicabilityScan(xrayResults []services.ScanResponse, directDependencies []string,
+func RunApplicabilityScan(ctx context.Context, xrayResults []services.ScanResponse, directDependencies []string,
 	scannedTechnologies []coreutils.Technology, scanner *jas.JasScanner, thirdPartyContextualAnalysis bool) (results []*sarif.Run, err error) {
 	applicabilityScanManager := newApplicabilityScanManager(xrayResults, directDependencies, scanner, thirdPartyContextualAnalysis)
 	if !applicabilityScanManager.cvesExists() {
 		log.Debug("We couldn't find any vulnerable dependencies. Skipping....")
 		return
 	}
-	if err = applicabilityScanManager.scanner.Run(applicabilityScanManager); err != nil {
+	if err = applicabilityScanManager.scanner.Run(ctx, applicabilityScanManager); err != nil {
 		err = utils.ParseAnalyzerManagerError(utils.Applicability, err)
 		return
 	}
@@ -62,7 +63,7 @@ func newApplicabilityScanManager(xrayScanResults []services.ScanResponse, direct
 		directDependenciesCves:   directDependenciesCves,
 		indirectDependenciesCves: indirectDependenciesCves,
 		xrayResults:              xrayScanResults,
-		scanner:                  scanner,
+		scanner:                  scanner.ForScanType(utils.Applicability),
 		thirdPartyScan:           thirdPartyScan,
 	}
 }
@@ -109,7 +110,7 @@ func isDirectComponents(components []string, directDependencies []string) bool {
 	return false
 }
 
-func (asm *ApplicabilityScanManager) Run(module jfrogappsconfig.Module) (err error) {
+func (asm *ApplicabilityScanManager) Run(ctx context.Context, module jfrogappsconfig.Module) (err error) {
 	if jas.ShouldSkipScanner(module, utils.Applicability) {
 		return
 	}
@@ -121,7 +122,7 @@ func (asm *ApplicabilityScanManager) Run(module jfrogappsconfig.Module) (err err
 	if err = asm.createConfigFile(module); err != nil {
 		return
 	}
-	if err = asm.runAnalyzerManager(); err != nil {
+	if err = asm.runAnalyzerManager(ctx); err != nil {
 		return
 	}
 	workingDirResults, err := jas.ReadJasScanRunsFromFile(asm.scanner.ResultsFileName, module.SourceRoot, applicabilityDocsUrlSuffix)
@@ -178,8 +179,8 @@ func (asm *ApplicabilityScanManager) createConfigFile(module jfrogappsconfig.Mod
 
 // Runs the analyzerManager app and returns a boolean to indicate whether the user is entitled for
 // advance security feature
-func (asm *ApplicabilityScanManager) runAnalyzerManager() error {
-	return asm.scanner.AnalyzerManager.Exec(asm.scanner.ConfigFileName, applicabilityScanCommand, filepath.Dir(asm.scanner.AnalyzerManager.AnalyzerManagerFullPath), asm.scanner.ServerDetails)
+func (asm *ApplicabilityScanManager) runAnalyzerManager(ctx context.Context) error {
+	return asm.scanner.AnalyzerManager.Exec(ctx, asm.scanner.ConfigFileName, applicabilityScanCommand, filepath.Dir(asm.scanner.AnalyzerManager.AnalyzerManagerFullPath), asm.scanner.ServerDetails)
 }
 
 func removeElementFromSlice(skipDirs []string, element string) []string {
@@ -1,6 +1,7 @@
 package jas
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -48,32 +49,64 @@ type JasScanner struct {
 	ServerDetails         *config.ServerDetails
 	JFrogAppsConfig       *jfrogappsconfig.JFrogAppsConfig
 	ScannerDirCleanupFunc func() error
+	tempDir               string
+	// The files that changed since the git reference the audit is compared to. All the files are scanned if nil.
+	changedFiles []string
 }
 
-func NewJasScanner(workingDirs []string, serverDetails *config.ServerDetails) (scanner *JasScanner, err error) {
+func NewJasScanner(jfrogAppsConfig *jfrogappsconfig.JFrogAppsConfig, serverDetails *config.ServerDetails) (scanner *JasScanner, err error) {
 	scanner = &JasScanner{}
 	if scanner.AnalyzerManager.AnalyzerManagerFullPath, err = utils.GetAnalyzerManagerExecutable(); err != nil {
 		return
 	}
-	var tempDir string
-	if tempDir, err = fileutils.CreateTempDir(); err != nil {
+	if scanner.tempDir, err = fileutils.CreateTempDir(); err != nil {
 		return
 	}
 	scanner.ScannerDirCleanupFunc = func() error {
-		return fileutils.RemoveTempDir(tempDir)
+		return fileutils.RemoveTempDir(scanner.tempDir)
 	}
 	scanner.ServerDetails = serverDetails
-	scanner.ConfigFileName = filepath.Join(tempDir, "config.yaml")
-	scanner.ResultsFileName = filepath.Join(tempDir, "results.sarif")
-	scanner.JFrogAppsConfig, err = createJFrogAppsConfig(workingDirs)
+	scanner.JFrogAppsConfig = jfrogAppsConfig
 	return
 }
 
-func createJFrogAppsConfig(workingDirs []string) (*jfrogappsconfig.JFrogAppsConfig, error) {
+// Returns a copy of the scanner with its own config and results files, so that scanners of different types can run concurrently.
+func (a *JasScanner) ForScanType(scanType utils.JasScanType) *JasScanner {
+	scanner := *a
+	prefix := strings.ToLower(scanType.String())
+	scanner.ConfigFileName = filepath.Join(a.tempDir, prefix+"_config.yaml")
+	scanner.ResultsFileName = filepath.Join(a.tempDir, prefix+"_results.sarif")
+	return &scanner
+}
+
+// Returns a copy of the scanner that scans only the directories of the given changed files.
+func (a *JasScanner) ForChangedFiles(changedFiles []string) *JasScanner {
+	scanner := *a
+	scanner.changedFiles = changedFiles
+	return &scanner
+}
+
+// Returns the source roots of the module for the given scanner, limited to the directories of the changed files if the scanner is limited to them.
+func (a *JasScanner) GetSourceRoots(module jfrogappsconfig.Module, scanner *jfrogappsconfig.Scanner) ([]string, error) {
+	roots, err := GetSourceRoots(module, scanner)
+	if err != nil || a.changedFiles == nil {
+		return roots, err
+	}
+	return utils.GetChangedDirs(roots, a.changedFiles), nil
+}
+
+// Loads the jfrog-apps-config.yml of the process working directory, or creates a config with a module for each working directory if it doesn't exist.
+// The source roots of the modules are absolute, so the config doesn't depend on the process working directory once it is created.
+func CreateJFrogAppsConfig(workingDirs []string) (*jfrogappsconfig.JFrogAppsConfig, error) {
 	if jfrogAppsConfig, err := jfrogappsconfig.LoadConfigIfExist(); err != nil {
 		return nil, errorutils.CheckError(err)
 	} else if jfrogAppsConfig != nil {
 		// jfrog-apps-config.yml exist in the workspace
+		for i := range jfrogAppsConfig.Modules {
+			if jfrogAppsConfig.Modules[i].SourceRoot, err = filepath.Abs(jfrogAppsConfig.Modules[i].SourceRoot); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+		}
 		return jfrogAppsConfig, nil
 	}
 
@@ -90,16 +123,27 @@ func createJFrogAppsConfig(workingDirs []string) (*jfrogappsconfig.JFrogAppsConf
 }
 
 type ScannerCmd interface {
-	Run(module jfrogappsconfig.Module) (err error)
+	Run(ctx context.Context, module jfrogappsconfig.Module) (err error)
 }
 
-func (a *JasScanner) Run(scannerCmd ScannerCmd) (err error) {
+// Runs the scanner on each of the modules. Stops if the context is done.
+func (a *JasScanner) Run(ctx context.Context, scannerCmd ScannerCmd) (err error) {
 	for _, module := range a.JFrogAppsConfig.Modules {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Join(err, ctxErr)
+		}
+		if roots, rootsErr := a.GetSourceRoots(module, nil); rootsErr != nil || len(roots) == 0 {
+			if rootsErr != nil {
+				return errors.Join(err, rootsErr)
+			}
+			log.Debug(fmt.Sprintf("Skipping the module in %s, no files were changed in it", module.SourceRoot))
+			continue
+		}
 		func() {
 			defer func() {
 				err = errors.Join(err, deleteJasProcessFiles(a.ConfigFileName, a.ResultsFileName))
 			}()
-			if err = scannerCmd.Run(module); err != nil {
+			if err = scannerCmd.Run(ctx, module); err != nil {
 				return
 			}
 		}()
@@ -230,7 +274,9 @@ var FakeBasicXrayResults = []services.ScanResponse{
 
 func InitJasTest(t *testing.T, workingDirs ...string) (*JasScanner, func()) {
 	assert.NoError(t, utils.DownloadAnalyzerManagerIfNeeded())
-	scanner, err := NewJasScanner(workingDirs, &FakeServerDetails)
+	jfrogAppsConfig, err := CreateJFrogAppsConfig(workingDirs)
+	assert.NoError(t, err)
+	scanner, err := NewJasScanner(jfrogAppsConfig, &FakeServerDetails)
 	assert.NoError(t, err)
 	return scanner, func() {
 		assert.NoError(t, scanner.ScannerDirCleanupFunc())
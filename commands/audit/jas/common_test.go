@@ -3,6 +3,7 @@ package jas
 import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jfrog/jfrog-cli-security/utils"
@@ -129,3 +130,15 @@ func TestSetAnalyticsMetricsDataForAnalyzerManager(t *testing.T) {
 		})
 	}
 }
+
+func TestForScanType(t *testing.T) {
+	scanner := &JasScanner{tempDir: "temp"}
+	secretsScanner := scanner.ForScanType(utils.Secrets)
+	sastScanner := scanner.ForScanType(utils.Sast)
+	assert.Equal(t, filepath.Join("temp", "secrets_config.yaml"), secretsScanner.ConfigFileName)
+	assert.Equal(t, filepath.Join("temp", "secrets_results.sarif"), secretsScanner.ResultsFileName)
+	assert.NotEqual(t, secretsScanner.ConfigFileName, sastScanner.ConfigFileName)
+	assert.NotEqual(t, secretsScanner.ResultsFileName, sastScanner.ResultsFileName)
+	// The original scanner is not modified.
+	assert.Empty(t, scanner.ConfigFileName)
+}
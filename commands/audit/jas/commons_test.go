@@ -27,7 +27,7 @@ func TestCreateJFrogAppsConfig(t *testing.T) {
 
 	for _, testCase := range createJFrogAppsConfigCases {
 		t.Run(fmt.Sprintf("%v", testCase.workingDirs), func(t *testing.T) {
-			jfrogAppsConfig, err := createJFrogAppsConfig(testCase.workingDirs)
+			jfrogAppsConfig, err := CreateJFrogAppsConfig(testCase.workingDirs)
 			assert.NoError(t, err)
 			assert.NotNil(t, jfrogAppsConfig)
 			if len(testCase.workingDirs) == 0 {
@@ -49,11 +49,14 @@ func TestCreateJFrogAppsConfigWithConfig(t *testing.T) {
 	chdirCallback := clientTestUtils.ChangeDirWithCallback(t, wd, "testdata")
 	defer chdirCallback()
 
-	jfrogAppsConfig, err := createJFrogAppsConfig([]string{})
+	jfrogAppsConfig, err := CreateJFrogAppsConfig([]string{})
 	assert.NoError(t, err)
 	assert.NotNil(t, jfrogAppsConfig)
 	assert.Equal(t, "1.0", jfrogAppsConfig.Version)
-	assert.Len(t, jfrogAppsConfig.Modules, 1)
+	if assert.Len(t, jfrogAppsConfig.Modules, 1) {
+		// The source root is resolved in the directory of the config.
+		assert.Equal(t, filepath.Join(wd, "testdata", "src"), jfrogAppsConfig.Modules[0].SourceRoot)
+	}
 }
 
 func TestShouldSkipScanner(t *testing.T) {
@@ -127,3 +130,28 @@ func TestGetExcludePatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestJasScannerGetSourceRootsForChangedFiles(t *testing.T) {
+	sourceRoot := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(sourceRoot, "working-dir", "src"), 0755))
+	module := jfrogappsconfig.Module{SourceRoot: sourceRoot}
+	scanner := &JasScanner{}
+
+	roots, err := scanner.GetSourceRoots(module, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sourceRoot}, roots)
+
+	changedFilesScanner := scanner.ForChangedFiles([]string{filepath.Join(sourceRoot, "working-dir", "src", "main.go")})
+	roots, err = changedFilesScanner.GetSourceRoots(module, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(sourceRoot, "working-dir", "src")}, roots)
+	roots, err = changedFilesScanner.GetSourceRoots(module, &jfrogappsconfig.Scanner{WorkingDirs: []string{"other-dir"}})
+	assert.NoError(t, err)
+	assert.Empty(t, roots)
+	// The original scanner isn't limited to the changed files.
+	assert.Nil(t, scanner.changedFiles)
+
+	roots, err = scanner.ForChangedFiles([]string{}).GetSourceRoots(module, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, roots)
+}
@@ -1,6 +1,7 @@
 package iac
 
 import (
+	"context"
 	"path/filepath"
 
 	jfrogappsconfig "github.com/jfrog/jfrog-apps-config/go"
@@ -30,10 +31,10 @@ type IacScanManager struct {
 // []utils.SourceCodeScanResult: a list of the iac violations that were found.
 // bool: true if the user is entitled to iac scan, false otherwise.
 // error: An error object (if any).
-func RunIacScan(scanner *jas.JasScanner) (results []*sarif.Run, err error) {
+func RunIacScan(ctx context.Context, scanner *jas.JasScanner) (results []*sarif.Run, err error) {
 	iacScanManager := newIacScanManager(scanner)
 	log.Info("Running IaC scanning...")
-	if err = iacScanManager.scanner.Run(iacScanManager); err != nil {
+	if err = iacScanManager.scanner.Run(ctx, iacScanManager); err != nil {
 		err = utils.ParseAnalyzerManagerError(utils.IaC, err)
 		return
 	}
@@ -47,18 +48,18 @@ func RunIacScan(scanner *jas.JasScanner) (results []*sarif.Run, err error) {
 func newIacScanManager(scanner *jas.JasScanner) (manager *IacScanManager) {
 	return &IacScanManager{
 		iacScannerResults: []*sarif.Run{},
-		scanner:           scanner,
+		scanner:           scanner.ForScanType(utils.IaC),
 	}
 }
 
-func (iac *IacScanManager) Run(module jfrogappsconfig.Module) (err error) {
+func (iac *IacScanManager) Run(ctx context.Context, module jfrogappsconfig.Module) (err error) {
 	if jas.ShouldSkipScanner(module, utils.IaC) {
 		return
 	}
 	if err = iac.createConfigFile(module); err != nil {
 		return
 	}
-	if err = iac.runAnalyzerManager(); err != nil {
+	if err = iac.runAnalyzerManager(ctx); err != nil {
 		return
 	}
 	workingDirResults, err := jas.ReadJasScanRunsFromFile(iac.scanner.ResultsFileName, module.SourceRoot, iacDocsUrlSuffix)
@@ -81,7 +82,7 @@ type iacScanConfiguration struct {
 }
 
 func (iac *IacScanManager) createConfigFile(module jfrogappsconfig.Module) error {
-	roots, err := jas.GetSourceRoots(module, module.Scanners.Iac)
+	roots, err := iac.scanner.GetSourceRoots(module, module.Scanners.Iac)
 	if err != nil {
 		return err
 	}
@@ -98,6 +99,6 @@ func (iac *IacScanManager) createConfigFile(module jfrogappsconfig.Module) error
 	return jas.CreateScannersConfigFile(iac.scanner.ConfigFileName, configFileContent, utils.IaC)
 }
 
-func (iac *IacScanManager) runAnalyzerManager() error {
-	return iac.scanner.AnalyzerManager.Exec(iac.scanner.ConfigFileName, iacScanCommand, filepath.Dir(iac.scanner.AnalyzerManager.AnalyzerManagerFullPath), iac.scanner.ServerDetails)
+func (iac *IacScanManager) runAnalyzerManager(ctx context.Context) error {
+	return iac.scanner.AnalyzerManager.Exec(ctx, iac.scanner.ConfigFileName, iacScanCommand, filepath.Dir(iac.scanner.AnalyzerManager.AnalyzerManagerFullPath), iac.scanner.ServerDetails)
 }
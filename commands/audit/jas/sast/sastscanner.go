@@ -1,6 +1,7 @@
 package sast
 
 import (
+	"context"
 	"fmt"
 
 	"path/filepath"
@@ -24,10 +25,10 @@ type SastScanManager struct {
 	scanner            *jas.JasScanner
 }
 
-func RunSastScan(scanner *jas.JasScanner) (results []*sarif.Run, err error) {
+func RunSastScan(ctx context.Context, scanner *jas.JasScanner) (results []*sarif.Run, err error) {
 	sastScanManager := newSastScanManager(scanner)
 	log.Info("Running SAST scanning...")
-	if err = sastScanManager.scanner.Run(sastScanManager); err != nil {
+	if err = sastScanManager.scanner.Run(ctx, sastScanManager); err != nil {
 		err = utils.ParseAnalyzerManagerError(utils.Sast, err)
 		return
 	}
@@ -41,11 +42,11 @@ func RunSastScan(scanner *jas.JasScanner) (results []*sarif.Run, err error) {
 func newSastScanManager(scanner *jas.JasScanner) (manager *SastScanManager) {
 	return &SastScanManager{
 		sastScannerResults: []*sarif.Run{},
-		scanner:            scanner,
+		scanner:            scanner.ForScanType(utils.Sast),
 	}
 }
 
-func (ssm *SastScanManager) Run(module jfrogappsconfig.Module) (err error) {
+func (ssm *SastScanManager) Run(ctx context.Context, module jfrogappsconfig.Module) (err error) {
 	if jas.ShouldSkipScanner(module, utils.Sast) {
 		return
 	}
@@ -53,7 +54,7 @@ func (ssm *SastScanManager) Run(module jfrogappsconfig.Module) (err error) {
 		return
 	}
 	scanner := ssm.scanner
-	if err = ssm.runAnalyzerManager(filepath.Dir(ssm.scanner.AnalyzerManager.AnalyzerManagerFullPath)); err != nil {
+	if err = ssm.runAnalyzerManager(ctx, filepath.Dir(ssm.scanner.AnalyzerManager.AnalyzerManagerFullPath)); err != nil {
 		return
 	}
 	workingDirRuns, err := jas.ReadJasScanRunsFromFile(scanner.ResultsFileName, module.SourceRoot, sastDocsUrlSuffix)
@@ -82,7 +83,7 @@ func (ssm *SastScanManager) createConfigFile(module jfrogappsconfig.Module) erro
 	if sastScanner == nil {
 		sastScanner = &jfrogappsconfig.SastScanner{}
 	}
-	roots, err := jas.GetSourceRoots(module, &sastScanner.Scanner)
+	roots, err := ssm.scanner.GetSourceRoots(module, &sastScanner.Scanner)
 	if err != nil {
 		return err
 	}
@@ -100,8 +101,8 @@ func (ssm *SastScanManager) createConfigFile(module jfrogappsconfig.Module) erro
 	return jas.CreateScannersConfigFile(ssm.scanner.ConfigFileName, configFileContent, utils.Sast)
 }
 
-func (ssm *SastScanManager) runAnalyzerManager(wd string) error {
-	return ssm.scanner.AnalyzerManager.ExecWithOutputFile(ssm.scanner.ConfigFileName, sastScanCommand, wd, ssm.scanner.ResultsFileName, ssm.scanner.ServerDetails)
+func (ssm *SastScanManager) runAnalyzerManager(ctx context.Context, wd string) error {
+	return ssm.scanner.AnalyzerManager.ExecWithOutputFile(ctx, ssm.scanner.ConfigFileName, sastScanCommand, wd, ssm.scanner.ResultsFileName, ssm.scanner.ServerDetails)
 }
 
 // In the Sast scanner, there can be multiple results with the same location.
@@ -1,6 +1,7 @@
 package secrets
 
 import (
+	"context"
 	"path/filepath"
 	"strings"
 
@@ -29,10 +30,10 @@ type SecretScanManager struct {
 // Return values:
 // []utils.IacOrSecretResult: a list of the secrets that were found.
 // error: An error object (if any).
-func RunSecretsScan(scanner *jas.JasScanner) (results []*sarif.Run, err error) {
+func RunSecretsScan(ctx context.Context, scanner *jas.JasScanner) (results []*sarif.Run, err error) {
 	secretScanManager := newSecretsScanManager(scanner)
 	log.Info("Running secrets scanning...")
-	if err = secretScanManager.scanner.Run(secretScanManager); err != nil {
+	if err = secretScanManager.scanner.Run(ctx, secretScanManager); err != nil {
 		err = utils.ParseAnalyzerManagerError(utils.Secrets, err)
 		return
 	}
@@ -46,18 +47,18 @@ func RunSecretsScan(scanner *jas.JasScanner) (results []*sarif.Run, err error) {
 func newSecretsScanManager(scanner *jas.JasScanner) (manager *SecretScanManager) {
 	return &SecretScanManager{
 		secretsScannerResults: []*sarif.Run{},
-		scanner:               scanner,
+		scanner:               scanner.ForScanType(utils.Secrets),
 	}
 }
 
-func (ssm *SecretScanManager) Run(module jfrogappsconfig.Module) (err error) {
+func (ssm *SecretScanManager) Run(ctx context.Context, module jfrogappsconfig.Module) (err error) {
 	if jas.ShouldSkipScanner(module, utils.Secrets) {
 		return
 	}
 	if err = ssm.createConfigFile(module); err != nil {
 		return
 	}
-	if err = ssm.runAnalyzerManager(); err != nil {
+	if err = ssm.runAnalyzerManager(ctx); err != nil {
 		return
 	}
 	workingDirRuns, err := jas.ReadJasScanRunsFromFile(ssm.scanner.ResultsFileName, module.SourceRoot, secretsDocsUrlSuffix)
@@ -80,7 +81,7 @@ type secretsScanConfiguration struct {
 }
 
 func (s *SecretScanManager) createConfigFile(module jfrogappsconfig.Module) error {
-	roots, err := jas.GetSourceRoots(module, module.Scanners.Secrets)
+	roots, err := s.scanner.GetSourceRoots(module, module.Scanners.Secrets)
 	if err != nil {
 		return err
 	}
@@ -97,8 +98,8 @@ func (s *SecretScanManager) createConfigFile(module jfrogappsconfig.Module) erro
 	return jas.CreateScannersConfigFile(s.scanner.ConfigFileName, configFileContent, utils.Secrets)
 }
 
-func (s *SecretScanManager) runAnalyzerManager() error {
-	return s.scanner.AnalyzerManager.Exec(s.scanner.ConfigFileName, secretsScanCommand, filepath.Dir(s.scanner.AnalyzerManager.AnalyzerManagerFullPath), s.scanner.ServerDetails)
+func (s *SecretScanManager) runAnalyzerManager(ctx context.Context) error {
+	return s.scanner.AnalyzerManager.Exec(ctx, s.scanner.ConfigFileName, secretsScanCommand, filepath.Dir(s.scanner.AnalyzerManager.AnalyzerManagerFullPath), s.scanner.ServerDetails)
 }
 
 func maskSecret(secret string) string {
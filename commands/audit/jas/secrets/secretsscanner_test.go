@@ -1,6 +1,7 @@
 package secrets
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -54,7 +55,7 @@ func TestRunAnalyzerManager_ReturnsGeneralError(t *testing.T) {
 	defer cleanUp()
 
 	secretScanManager := newSecretsScanManager(scanner)
-	assert.Error(t, secretScanManager.runAnalyzerManager())
+	assert.Error(t, secretScanManager.runAnalyzerManager(context.Background()))
 }
 
 func TestParseResults_EmptyResults(t *testing.T) {
@@ -107,7 +108,7 @@ func TestGetSecretsScanResults_AnalyzerManagerReturnsError(t *testing.T) {
 	scanner, cleanUp := jas.InitJasTest(t)
 	defer cleanUp()
 
-	secretsResults, err := RunSecretsScan(scanner)
+	secretsResults, err := RunSecretsScan(context.Background(), scanner)
 
 	assert.Error(t, err)
 	assert.ErrorContains(t, err, "failed to run Secrets scan")
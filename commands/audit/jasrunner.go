@@ -1,7 +1,11 @@
 package audit
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
+
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/jas"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/jas/applicability"
@@ -13,51 +17,97 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-func runJasScannersAndSetResults(scanResults *utils.Results, directDependencies []string,
-	serverDetails *config.ServerDetails, workingDirs []string, progress io.ProgressMgr, thirdPartyApplicabilityScan bool, msi string) (err error) {
+// Starts the JAS scanners that don't depend on the SCA results (secrets, IaC and SAST) in a background routine, once the analyzer manager is ready.
+// Returns a function that runs the contextual analysis scan on the SCA results, waits for the rest of the scanners and returns their errors.
+// Only the scanners in scansToPerform run, or all of them if it is empty. The scanners are stopped when the context is done.
+// If changedFiles isn't nil, the source code scanners scan only the directories of the changed files.
+// The modules to scan are resolved before returning, since the process working directory may change while the SCA scan builds the dependency trees.
+func runJasScanners(ctx context.Context, scanResults *utils.Results, serverDetails *config.ServerDetails, workingDirs []string, changedFiles []string, scansToPerform []utils.SubScanType, progress io.ProgressMgr,
+	thirdPartyApplicabilityScan bool, msi string, waitForAnalyzerManager func() error) (runApplicabilityScanAndWait func(directDependencies []string) error) {
 	if serverDetails == nil || len(serverDetails.Url) == 0 {
 		log.Warn("To include 'Advanced Security' scan as part of the audit output, please run the 'jf c add' command before running this command.")
-		return
+		return func([]string) error { return nil }
 	}
-	scanner, err := jas.NewJasScanner(workingDirs, serverDetails)
+	jfrogAppsConfig, err := jas.CreateJFrogAppsConfig(workingDirs)
 	if err != nil {
-		return
-	}
-	defer func() {
-		cleanup := scanner.ScannerDirCleanupFunc
-		err = errors.Join(err, cleanup())
-	}()
-	if progress != nil {
-		progress.SetHeadlineMsg("Running applicability scanning")
+		return func([]string) error { return err }
 	}
 	// Set environments variables for analytics in analyzers manager.
-	callback := jas.SetAnalyticsMetricsDataForAnalyzerManager(msi, scanResults.GetScaScannedTechnologies())
-	defer callback()
-	scanResults.ExtendedScanResults.ApplicabilityScanResults, err = applicability.RunApplicabilityScan(scanResults.GetScaScansXrayResults(), directDependencies, scanResults.GetScaScannedTechnologies(), scanner, thirdPartyApplicabilityScan)
-	if err != nil {
-		return
-	}
-	// Don't execute other scanners when scanning third party dependencies.
-	if thirdPartyApplicabilityScan {
-		return
-	}
-	if progress != nil {
-		progress.SetHeadlineMsg("Running secrets scanning")
-	}
-	scanResults.ExtendedScanResults.SecretsScanResults, err = secrets.RunSecretsScan(scanner)
-	if err != nil {
+	callback := jas.SetAnalyticsMetricsDataForAnalyzerManager(msi, nil)
+	var scanner *jas.JasScanner
+	var scannerErr, sourceCodeScansErr error
+	scannerReady := make(chan struct{})
+	sourceCodeScansDone := make(chan struct{})
+	go func() {
+		defer close(sourceCodeScansDone)
+		if scannerErr = waitForAnalyzerManager(); scannerErr == nil {
+			scanner, scannerErr = jas.NewJasScanner(jfrogAppsConfig, serverDetails)
+		}
+		close(scannerReady)
+		// Don't execute other scanners when scanning third party dependencies.
+		if scannerErr != nil || thirdPartyApplicabilityScan {
+			return
+		}
+		if changedFiles != nil {
+			// The contextual analysis keeps scanning all the files, since the changed code may use code that didn't change.
+			sourceCodeScansErr = runSourceCodeScans(ctx, scanResults, scanner.ForChangedFiles(changedFiles), scansToPerform, progress)
+			return
+		}
+		sourceCodeScansErr = runSourceCodeScans(ctx, scanResults, scanner, scansToPerform, progress)
+	}()
+	return func(directDependencies []string) (err error) {
+		defer callback()
+		<-scannerReady
+		if scannerErr != nil {
+			<-sourceCodeScansDone
+			return scannerErr
+		}
+		defer func() {
+			<-sourceCodeScansDone
+			err = errors.Join(err, sourceCodeScansErr, scanner.ScannerDirCleanupFunc())
+		}()
+		if !utils.IsSubScanRequested(scansToPerform, utils.ContextualAnalysisScan) {
+			log.Info("Skipping contextual analysis scanning")
+			return
+		}
+		if progress != nil {
+			progress.SetHeadlineMsg("Running applicability scanning")
+		}
+		// The technologies are known only after the SCA scan.
+		technologiesCallback := jas.SetAnalyticsMetricsDataForAnalyzerManager(msi, scanResults.GetScaScannedTechnologies())
+		defer technologiesCallback()
+		scanResults.ExtendedScanResults.ApplicabilityScanResults, err = applicability.RunApplicabilityScan(ctx, scanResults.GetScaScansXrayResults(), directDependencies, scanResults.GetScaScannedTechnologies(), scanner, thirdPartyApplicabilityScan)
 		return
 	}
+}
+
+// Runs the requested secrets, IaC and SAST scanners concurrently, each with its own config and results files.
+func runSourceCodeScans(ctx context.Context, scanResults *utils.Results, scanner *jas.JasScanner, scansToPerform []utils.SubScanType, progress io.ProgressMgr) error {
 	if progress != nil {
-		progress.SetHeadlineMsg("Running IaC scanning")
+		progress.SetHeadlineMsg("Running source code scanning")
 	}
-	scanResults.ExtendedScanResults.IacScanResults, err = iac.RunIacScan(scanner)
-	if err != nil {
-		return
-	}
-	if progress != nil {
-		progress.SetHeadlineMsg("Running SAST scanning")
+	var wg sync.WaitGroup
+	var secretsErr, iacErr, sastErr error
+	runIfRequested := func(subScan utils.SubScanType, scan func()) {
+		if !utils.IsSubScanRequested(scansToPerform, subScan) {
+			log.Info(fmt.Sprintf("Skipping %s scanning", subScan))
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scan()
+		}()
 	}
-	scanResults.ExtendedScanResults.SastScanResults, err = sast.RunSastScan(scanner)
-	return
+	runIfRequested(utils.SecretsScan, func() {
+		scanResults.ExtendedScanResults.SecretsScanResults, secretsErr = secrets.RunSecretsScan(ctx, scanner)
+	})
+	runIfRequested(utils.IacScan, func() {
+		scanResults.ExtendedScanResults.IacScanResults, iacErr = iac.RunIacScan(ctx, scanner)
+	})
+	runIfRequested(utils.SastScan, func() {
+		scanResults.ExtendedScanResults.SastScanResults, sastErr = sast.RunSastScan(ctx, scanner)
+	})
+	wg.Wait()
+	return errors.Join(secretsErr, iacErr, sastErr)
 }
@@ -1,14 +1,18 @@
 package audit
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/jas"
 	"github.com/jfrog/jfrog-cli-security/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	clientTestUtils "github.com/jfrog/jfrog-client-go/utils/tests"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetExtendedScanResults_AnalyzerManagerDoesntExist(t *testing.T) {
@@ -22,23 +26,66 @@ func TestGetExtendedScanResults_AnalyzerManagerDoesntExist(t *testing.T) {
 		assert.NoError(t, os.Unsetenv(coreutils.HomeDir))
 	}()
 	scanResults := &utils.Results{ScaResults: []utils.ScaScanResult{{Technology: coreutils.Yarn, XrayResults: jas.FakeBasicXrayResults}}, ExtendedScanResults: &utils.ExtendedScanResults{}}
-	err = runJasScannersAndSetResults(scanResults, []string{"issueId_1_direct_dependency", "issueId_2_direct_dependency"}, &jas.FakeServerDetails, nil, nil, false, "")
+	err = runJasScanners(context.Background(), scanResults, &jas.FakeServerDetails, nil, nil, nil, nil, false, "", func() error { return nil })([]string{"issueId_1_direct_dependency", "issueId_2_direct_dependency"})
 	// Expect error:
 	assert.Error(t, err)
 }
 
 func TestGetExtendedScanResults_ServerNotValid(t *testing.T) {
 	scanResults := &utils.Results{ScaResults: []utils.ScaScanResult{{Technology: coreutils.Pip, XrayResults: jas.FakeBasicXrayResults}}, ExtendedScanResults: &utils.ExtendedScanResults{}}
-	err := runJasScannersAndSetResults(scanResults, []string{"issueId_1_direct_dependency", "issueId_2_direct_dependency"}, nil, nil, nil, false, "")
+	err := runJasScanners(context.Background(), scanResults, nil, nil, nil, nil, nil, false, "", utils.DownloadAnalyzerManagerIfNeeded)([]string{"issueId_1_direct_dependency", "issueId_2_direct_dependency"})
 	assert.NoError(t, err)
 }
 
 func TestGetExtendedScanResults_AnalyzerManagerReturnsError(t *testing.T) {
-	assert.NoError(t, utils.DownloadAnalyzerManagerIfNeeded())
-
 	scanResults := &utils.Results{ScaResults: []utils.ScaScanResult{{Technology: coreutils.Yarn, XrayResults: jas.FakeBasicXrayResults}}, ExtendedScanResults: &utils.ExtendedScanResults{}}
-	err := runJasScannersAndSetResults(scanResults, []string{"issueId_2_direct_dependency", "issueId_1_direct_dependency"}, &jas.FakeServerDetails, nil, nil, false, "")
+	err := runJasScanners(context.Background(), scanResults, &jas.FakeServerDetails, nil, nil, nil, nil, false, "", utils.DownloadAnalyzerManagerIfNeeded)([]string{"issueId_2_direct_dependency", "issueId_1_direct_dependency"})
 
 	// Expect error:
 	assert.ErrorContains(t, err, "failed to run Applicability scan")
+	// The source code scanners run regardless of the applicability scan, and their errors are returned as well.
+	assert.ErrorContains(t, err, "failed to run Secrets scan")
+}
+
+func TestRunJasScannersWhileBuildingPipTree(t *testing.T) {
+	if coreutils.IsWindows() {
+		t.Skip("The fake analyzer manager is a shell script")
+	}
+	// The fake analyzer manager logs the config of the scan and fails.
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	analyzerManagerPath, err := utils.GetAnalyzerManagerDirAbsolutePath()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(analyzerManagerPath, 0755))
+	configLogPath := filepath.Join(homeDir, "config.log")
+	require.NoError(t, os.WriteFile(filepath.Join(analyzerManagerPath, utils.GetAnalyzerManagerExecutableName()), []byte("#!/bin/sh\ncat \"$2\" >> "+configLogPath+"\nexit 1\n"), 0755))
+
+	// The audited working directory is relative to the directory the audit runs in.
+	auditDir := t.TempDir()
+	pipProjectDir := filepath.Join(auditDir, "pip-project")
+	require.NoError(t, os.MkdirAll(pipProjectDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(pipProjectDir, "requirements.txt"), []byte{}, 0644))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallback := clientTestUtils.ChangeDirWithCallback(t, wd, auditDir)
+	defer chdirCallback()
+
+	// The pip tree is built while the scanners wait for the analyzer manager, and while the process working directory is changed like some package managers do.
+	var pipTree DependencyTreeResult
+	waitForAnalyzerManager := func() (err error) {
+		if err = os.Chdir(t.TempDir()); err != nil {
+			return
+		}
+		pipTree, err = GetTechDependencyTree(context.Background(), &utils.AuditBasicParams{}, pipProjectDir, coreutils.Pip)
+		return
+	}
+	scanResults := &utils.Results{ExtendedScanResults: &utils.ExtendedScanResults{}}
+	err = runJasScanners(context.Background(), scanResults, &jas.FakeServerDetails, []string{"pip-project"}, nil, []utils.SubScanType{utils.ScaScan, utils.SecretsScan}, nil, false, "", waitForAnalyzerManager)(nil)
+	assert.ErrorContains(t, err, "failed to run Secrets scan")
+	assert.NotEmpty(t, pipTree.FullDepTrees)
+
+	// The secrets scanner scanned the audited working directory.
+	configLog, err := os.ReadFile(configLogPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(configLog), pipProjectDir)
 }
@@ -0,0 +1,347 @@
+package cargo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/gofrog/datastructures"
+	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-cli-security/commands/audit/sca"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	cargoPackageTypeIdentifier = "cargo://"
+	cargoExecutable            = "cargo"
+	manifestFileName           = "Cargo.toml"
+	lockFileName               = "Cargo.lock"
+)
+
+// The parts of a Cargo.toml manifest that are needed to detect the packages of a workspace.
+type cargoManifest struct {
+	Package *struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+type cargoLock struct {
+	Packages []cargoLockPackage `toml:"package"`
+}
+
+type cargoLockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// Empty for the packages of the workspace and the path dependencies.
+	Source string `toml:"source"`
+	// Each dependency is '<name>', '<name> <version>' or '<name> <version> (<source>)', the name alone is used when it is unambiguous.
+	Dependencies []string `toml:"dependencies"`
+}
+
+// The parts of the output of 'cargo metadata --format-version 1' that are needed to build the dependency trees.
+type cargoMetadata struct {
+	Packages []struct {
+		Id      string `json:"id"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"packages"`
+	WorkspaceMembers []string `json:"workspace_members"`
+	Resolve          *struct {
+		Nodes []struct {
+			Id   string `json:"id"`
+			Deps []struct {
+				Pkg string `json:"pkg"`
+			} `json:"deps"`
+		} `json:"nodes"`
+	} `json:"resolve"`
+}
+
+// DetectProjects returns the directories of the Cargo projects in the given path, mapped to their Cargo.toml manifests.
+// The packages of a workspace are scanned as part of the workspace, so their manifests are added to the manifests of the workspace root.
+func DetectProjects(path string, recursive bool, excludePattern string) (map[string][]string, error) {
+	files, err := fspatterns.ListFiles(path, recursive, false, true, true, excludePattern)
+	if err != nil {
+		return nil, err
+	}
+	var manifestDirs []string
+	for _, file := range files {
+		if filepath.Base(file) == manifestFileName {
+			manifestDirs = append(manifestDirs, filepath.Dir(file))
+		}
+	}
+	sort.Strings(manifestDirs)
+	projects := map[string][]string{}
+	workspaceRootByMemberDir := map[string]string{}
+	for _, dir := range manifestDirs {
+		if _, isMember := workspaceRootByMemberDir[dir]; isMember {
+			continue
+		}
+		projects[dir] = append(projects[dir], filepath.Join(dir, manifestFileName))
+		membersDirs, err := getWorkspaceMembersDirs(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, memberDir := range membersDirs {
+			if memberDir == dir {
+				continue
+			}
+			workspaceRootByMemberDir[memberDir] = dir
+			if _, detected := projects[memberDir]; detected {
+				// A member in a sub directory of the workspace root, that was detected before the root.
+				delete(projects, memberDir)
+			}
+			projects[dir] = append(projects[dir], filepath.Join(memberDir, manifestFileName))
+		}
+	}
+	return projects, nil
+}
+
+// BuildDependencyTree builds a dependency tree for each package of the Cargo project or workspace in the working directory.
+// The dependencies are resolved by 'cargo metadata' when cargo is installed, or parsed from the Cargo.lock file otherwise.
+func BuildDependencyTree(ctx context.Context, workingDir string) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	treeMap, membersIds, err := getDependenciesFromMetadata(ctx, workingDir)
+	if err != nil || treeMap == nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		if err != nil {
+			log.Warn(fmt.Sprintf("Couldn't resolve the Cargo dependencies of %s with 'cargo metadata', the dependencies are parsed from %s: %s", workingDir, lockFileName, err.Error()))
+		}
+		if treeMap, membersIds, err = getDependenciesFromLockFile(workingDir); err != nil {
+			return
+		}
+	}
+	dependencyTrees, uniqueDeps = buildWorkspaceDependencyTrees(treeMap, membersIds)
+	return
+}
+
+// Builds a separate dependency tree for each package of the workspace.
+func buildWorkspaceDependencyTrees(treeMap map[string]coreXray.DepTreeNode, membersIds []string) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string) {
+	uniqueDepsSet := datastructures.MakeSet[string]()
+	for _, memberId := range membersIds {
+		memberTree, memberUniqueDeps := coreXray.BuildXrayDependencyTree(treeMap, memberId)
+		dependencyTrees = append(dependencyTrees, memberTree)
+		uniqueDepsSet.AddElements(maps.Keys(memberUniqueDeps)...)
+	}
+	uniqueDeps = uniqueDepsSet.ToSlice()
+	return
+}
+
+// Returns nil if cargo isn't installed.
+func getDependenciesFromMetadata(ctx context.Context, workingDir string) (treeMap map[string]coreXray.DepTreeNode, membersIds []string, err error) {
+	if _, lookErr := exec.LookPath(cargoExecutable); lookErr != nil {
+		log.Debug("cargo isn't installed, the Cargo dependencies are parsed from", lockFileName)
+		return
+	}
+	sca.LogExecutableVersion(cargoExecutable)
+	args := []string{"metadata", "--format-version", "1"}
+	lockFileExists, err := fileutils.IsFileExists(filepath.Join(workingDir, lockFileName), false)
+	if err != nil {
+		return
+	}
+	if lockFileExists {
+		// The locked versions are scanned, the lock file isn't updated.
+		args = append(args, "--locked")
+	}
+	command := exec.CommandContext(ctx, cargoExecutable, args...)
+	command.Dir = workingDir
+	var stdout, stderr bytes.Buffer
+	command.Stdout, command.Stderr = &stdout, &stderr
+	if err = command.Run(); err != nil {
+		err = errorutils.CheckErrorf("'cargo metadata' command failed: %s - %s", err.Error(), strings.TrimSpace(stderr.String()))
+		return
+	}
+	return parseCargoMetadata(stdout.Bytes())
+}
+
+func parseCargoMetadata(content []byte) (treeMap map[string]coreXray.DepTreeNode, membersIds []string, err error) {
+	var metadata cargoMetadata
+	if err = json.Unmarshal(content, &metadata); err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to parse the output of 'cargo metadata': %s", err.Error())
+	}
+	if metadata.Resolve == nil {
+		return nil, nil, errorutils.CheckErrorf("the output of 'cargo metadata' has no resolved dependencies")
+	}
+	componentIds := map[string]string{}
+	for _, cargoPackage := range metadata.Packages {
+		componentIds[cargoPackage.Id] = getComponentId(cargoPackage.Name, cargoPackage.Version)
+	}
+	treeMap = map[string]coreXray.DepTreeNode{}
+	for _, node := range metadata.Resolve.Nodes {
+		var children []string
+		for _, dependency := range node.Deps {
+			children = append(children, componentIds[dependency.Pkg])
+		}
+		treeMap[componentIds[node.Id]] = newDepTreeNode(children)
+	}
+	for _, memberId := range metadata.WorkspaceMembers {
+		membersIds = append(membersIds, componentIds[memberId])
+	}
+	sort.Strings(membersIds)
+	return
+}
+
+func getDependenciesFromLockFile(workingDir string) (treeMap map[string]coreXray.DepTreeNode, membersIds []string, err error) {
+	lockFilePath := filepath.Join(workingDir, lockFileName)
+	exists, err := fileutils.IsFileExists(lockFilePath, false)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = errorutils.CheckErrorf("%s wasn't found in %s and cargo isn't available to resolve the dependencies. Run 'cargo generate-lockfile' to create it", lockFileName, workingDir)
+		return
+	}
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		err = errorutils.CheckError(err)
+		return
+	}
+	var lock cargoLock
+	if _, err = toml.Decode(string(content), &lock); err != nil {
+		err = errorutils.CheckErrorf("failed to parse %s: %s", lockFilePath, err.Error())
+		return
+	}
+	membersDirs, err := getWorkspaceMembersDirs(workingDir)
+	if err != nil {
+		return
+	}
+	var membersNames []string
+	for _, memberDir := range membersDirs {
+		manifest, _, manifestErr := readManifest(memberDir)
+		if manifestErr != nil {
+			return nil, nil, manifestErr
+		}
+		if manifest.Package != nil {
+			membersNames = append(membersNames, manifest.Package.Name)
+		}
+	}
+	treeMap, membersIds = parseCargoLock(lock, membersNames)
+	return
+}
+
+// Builds the dependencies map of the packages of the lock file. The workspace members are the packages without a source that have the names of the members.
+func parseCargoLock(lock cargoLock, membersNames []string) (treeMap map[string]coreXray.DepTreeNode, membersIds []string) {
+	packagesByName := map[string][]cargoLockPackage{}
+	for _, lockPackage := range lock.Packages {
+		packagesByName[lockPackage.Name] = append(packagesByName[lockPackage.Name], lockPackage)
+	}
+	treeMap = map[string]coreXray.DepTreeNode{}
+	for _, lockPackage := range lock.Packages {
+		var children []string
+		for _, dependency := range lockPackage.Dependencies {
+			if dependencyPackage, found := findLockDependency(packagesByName, dependency); found {
+				children = append(children, getComponentId(dependencyPackage.Name, dependencyPackage.Version))
+			}
+		}
+		componentId := getComponentId(lockPackage.Name, lockPackage.Version)
+		treeMap[componentId] = newDepTreeNode(children)
+		if lockPackage.Source == "" && slices.Contains(membersNames, lockPackage.Name) {
+			membersIds = append(membersIds, componentId)
+		}
+	}
+	sort.Strings(membersIds)
+	return
+}
+
+func findLockDependency(packagesByName map[string][]cargoLockPackage, dependency string) (cargoLockPackage, bool) {
+	fields := strings.Fields(dependency)
+	if len(fields) == 0 {
+		return cargoLockPackage{}, false
+	}
+	candidates := packagesByName[fields[0]]
+	if len(fields) == 1 {
+		// The name alone is used only when a single version of the package is locked.
+		if len(candidates) != 1 {
+			return cargoLockPackage{}, false
+		}
+		return candidates[0], true
+	}
+	source := strings.TrimSuffix(strings.TrimPrefix(strings.Join(fields[2:], " "), "("), ")")
+	for _, candidate := range candidates {
+		if candidate.Version == fields[1] && (source == "" || candidate.Source == source) {
+			return candidate, true
+		}
+	}
+	return cargoLockPackage{}, false
+}
+
+// Returns the directories of the packages of the workspace that the given directory is the root of, including the root if it is a package itself.
+// If the directory isn't a workspace root, only the directory itself is returned.
+func getWorkspaceMembersDirs(workingDir string) (membersDirs []string, err error) {
+	manifest, exists, err := readManifest(workingDir)
+	if err != nil || !exists {
+		return
+	}
+	if manifest.Package != nil {
+		membersDirs = append(membersDirs, workingDir)
+	}
+	if manifest.Workspace == nil {
+		return
+	}
+	var excludedDirs []string
+	for _, exclude := range manifest.Workspace.Exclude {
+		excludedDirs = append(excludedDirs, filepath.Join(workingDir, filepath.FromSlash(exclude)))
+	}
+	for _, memberPattern := range manifest.Workspace.Members {
+		matches, globErr := filepath.Glob(filepath.Join(workingDir, filepath.FromSlash(memberPattern)))
+		if globErr != nil {
+			return nil, errorutils.CheckErrorf("invalid workspace member '%s' in %s: %s", memberPattern, filepath.Join(workingDir, manifestFileName), globErr.Error())
+		}
+		sort.Strings(matches)
+		for _, memberDir := range matches {
+			if slices.Contains(membersDirs, memberDir) || slices.Contains(excludedDirs, memberDir) {
+				continue
+			}
+			if isPackage, checkErr := fileutils.IsFileExists(filepath.Join(memberDir, manifestFileName), false); checkErr != nil || !isPackage {
+				if checkErr != nil {
+					return nil, checkErr
+				}
+				continue
+			}
+			membersDirs = append(membersDirs, memberDir)
+		}
+	}
+	return
+}
+
+func readManifest(dir string) (manifest cargoManifest, exists bool, err error) {
+	manifestPath := filepath.Join(dir, manifestFileName)
+	if exists, err = fileutils.IsFileExists(manifestPath, false); err != nil || !exists {
+		return
+	}
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		err = errorutils.CheckError(err)
+		return
+	}
+	if _, err = toml.Decode(string(content), &manifest); err != nil {
+		err = errorutils.CheckErrorf("failed to parse %s: %s", manifestPath, err.Error())
+	}
+	return
+}
+
+func newDepTreeNode(children []string) coreXray.DepTreeNode {
+	sort.Strings(children)
+	return coreXray.DepTreeNode{Children: slices.Compact(children)}
+}
+
+func getComponentId(name, version string) string {
+	return cargoPackageTypeIdentifier + name + ":" + version
+}
@@ -0,0 +1,92 @@
+package cargo
+
+import (
+	"path/filepath"
+	"testing"
+
+	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestProjectDir(t *testing.T) string {
+	projectDir, err := filepath.Abs(filepath.Join("..", "..", "..", "..", "tests", "testdata", "projects", "package-managers", "cargo", "cargo-workspace"))
+	require.NoError(t, err)
+	return projectDir
+}
+
+func TestDetectProjects(t *testing.T) {
+	projectDir := getTestProjectDir(t)
+	projects, err := DetectProjects(projectDir, true, "")
+	require.NoError(t, err)
+	// The excluded package isn't a member of the workspace, so it is detected as a project of its own.
+	assert.Equal(t, map[string][]string{
+		projectDir: {
+			filepath.Join(projectDir, "Cargo.toml"),
+			filepath.Join(projectDir, "crates", "app", "Cargo.toml"),
+			filepath.Join(projectDir, "crates", "utils", "Cargo.toml"),
+		},
+		filepath.Join(projectDir, "crates", "experimental"): {filepath.Join(projectDir, "crates", "experimental", "Cargo.toml")},
+	}, projects)
+
+	projects, err = DetectProjects(projectDir, false, "")
+	require.NoError(t, err)
+	assert.Len(t, projects, 1)
+}
+
+func TestGetDependenciesFromLockFile(t *testing.T) {
+	treeMap, membersIds, err := getDependenciesFromLockFile(getTestProjectDir(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cargo://app:0.1.0", "cargo://utils:0.2.0"}, membersIds)
+	assert.Equal(t, map[string]coreXray.DepTreeNode{
+		"cargo://aho-corasick:1.1.3": {Children: []string{"cargo://memchr:2.7.2"}},
+		"cargo://app:0.1.0":          {Children: []string{"cargo://serde:1.0.197", "cargo://utils:0.2.0"}},
+		"cargo://memchr:2.7.2":       {},
+		"cargo://regex:1.10.4":       {Children: []string{"cargo://aho-corasick:1.1.3", "cargo://memchr:2.7.2", "cargo://regex-syntax:0.8.3"}},
+		"cargo://regex-syntax:0.8.3": {},
+		"cargo://serde:1.0.197":      {},
+		"cargo://utils:0.2.0":        {Children: []string{"cargo://regex:1.10.4"}},
+	}, treeMap)
+
+	// Each package of the workspace has a dependency tree of its own.
+	dependencyTrees, uniqueDeps := buildWorkspaceDependencyTrees(treeMap, membersIds)
+	require.Len(t, dependencyTrees, 2)
+	assert.Equal(t, "cargo://app:0.1.0", dependencyTrees[0].Id)
+	require.Len(t, dependencyTrees[0].Nodes, 2)
+	assert.Equal(t, "cargo://utils:0.2.0", dependencyTrees[0].Nodes[1].Id)
+	assert.Equal(t, "cargo://utils:0.2.0", dependencyTrees[1].Id)
+	require.Len(t, dependencyTrees[1].Nodes, 1)
+	assert.Len(t, dependencyTrees[1].Nodes[0].Nodes, 3)
+	assert.Len(t, uniqueDeps, 7)
+
+	_, _, err = getDependenciesFromLockFile(t.TempDir())
+	assert.ErrorContains(t, err, "Cargo.lock wasn't found")
+}
+
+func TestParseCargoMetadata(t *testing.T) {
+	treeMap, membersIds, err := parseCargoMetadata([]byte(`{
+  "packages": [
+    {"id": "path+file:///ws/app#0.1.0", "name": "app", "version": "0.1.0"},
+    {"id": "registry+https://github.com/rust-lang/crates.io-index#serde@1.0.197", "name": "serde", "version": "1.0.197"},
+    {"id": "registry+https://github.com/rust-lang/crates.io-index#serde_derive@1.0.197", "name": "serde_derive", "version": "1.0.197"}
+  ],
+  "workspace_members": ["path+file:///ws/app#0.1.0"],
+  "resolve": {
+    "nodes": [
+      {"id": "path+file:///ws/app#0.1.0", "deps": [{"pkg": "registry+https://github.com/rust-lang/crates.io-index#serde@1.0.197"}]},
+      {"id": "registry+https://github.com/rust-lang/crates.io-index#serde@1.0.197", "deps": [{"pkg": "registry+https://github.com/rust-lang/crates.io-index#serde_derive@1.0.197"}]},
+      {"id": "registry+https://github.com/rust-lang/crates.io-index#serde_derive@1.0.197", "deps": []}
+    ]
+  }
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cargo://app:0.1.0"}, membersIds)
+	assert.Equal(t, map[string]coreXray.DepTreeNode{
+		"cargo://app:0.1.0":            {Children: []string{"cargo://serde:1.0.197"}},
+		"cargo://serde:1.0.197":        {Children: []string{"cargo://serde_derive:1.0.197"}},
+		"cargo://serde_derive:1.0.197": {},
+	}, treeMap)
+
+	_, _, err = parseCargoMetadata([]byte(`{"packages": [], "workspace_members": []}`))
+	assert.ErrorContains(t, err, "has no resolved dependencies")
+}
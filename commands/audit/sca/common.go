@@ -1,6 +1,7 @@
 package sca
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -33,7 +34,7 @@ func GetExcludePattern(params utils.AuditParams) string {
 	return fspatterns.PrepareExcludePathPattern(exclusions, clientutils.WildCardPattern, params.IsRecursiveScan())
 }
 
-func RunXrayDependenciesTreeScanGraph(dependencyTree *xrayUtils.GraphNode, progress ioUtils.ProgressMgr, technology coreutils.Technology, scanGraphParams *scangraph.ScanGraphParams) (results []services.ScanResponse, err error) {
+func RunXrayDependenciesTreeScanGraph(ctx context.Context, dependencyTree *xrayUtils.GraphNode, progress ioUtils.ProgressMgr, technology coreutils.Technology, scanGraphParams *scangraph.ScanGraphParams) (results []services.ScanResponse, err error) {
 	scanGraphParams.XrayGraphScanParams().DependenciesGraph = dependencyTree
 	xscGitInfoContext := scanGraphParams.XrayGraphScanParams().XscGitInfoContext
 	if xscGitInfoContext != nil {
@@ -45,11 +46,11 @@ func RunXrayDependenciesTreeScanGraph(dependencyTree *xrayUtils.GraphNode, progr
 	}
 	log.Info(scanMessage + "...")
 	var scanResults *services.ScanResponse
-	xrayManager, err := utils.CreateXrayServiceManager(scanGraphParams.ServerDetails())
+	xrayManager, err := utils.CreateXrayServiceManagerWithContext(ctx, scanGraphParams.ServerDetails())
 	if err != nil {
 		return nil, err
 	}
-	scanResults, err = scangraph.RunScanGraphAndGetResults(scanGraphParams, xrayManager)
+	scanResults, err = scangraph.RunScanGraphAndGetResults(ctx, scanGraphParams, xrayManager)
 	if err != nil {
 		err = errorutils.CheckErrorf("scanning %s dependencies failed with error: %s", string(technology), err.Error())
 		return
@@ -0,0 +1,196 @@
+package composer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/gofrog/datastructures"
+	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	composerPackageTypeIdentifier = "composer://"
+	manifestFileName              = "composer.json"
+	lockFileName                  = "composer.lock"
+	vendorDirName                 = "vendor"
+
+	// The types of the dependencies, according to the section of the lock file they are locked in.
+	prodDependencyType = "prod"
+	devDependencyType  = "dev"
+)
+
+type composerManifest struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+type composerLock struct {
+	Packages    []composerLockPackage `json:"packages"`
+	PackagesDev []composerLockPackage `json:"packages-dev"`
+}
+
+type composerLockPackage struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Require map[string]string `json:"require"`
+	// The packages that this package replaces or provides an implementation of, which are required by their names.
+	Replace map[string]string `json:"replace"`
+	Provide map[string]string `json:"provide"`
+}
+
+// DetectProjects returns the directories of the Composer projects in the given path, mapped to their composer.json manifests.
+// The packages that are installed in the vendor directories of the projects aren't detected as projects.
+func DetectProjects(path string, recursive bool, excludePattern string) (map[string][]string, error) {
+	files, err := fspatterns.ListFiles(path, recursive, false, true, true, excludePattern)
+	if err != nil {
+		return nil, err
+	}
+	projects := map[string][]string{}
+	for _, file := range files {
+		if filepath.Base(file) != manifestFileName || isInVendorDir(path, file) {
+			continue
+		}
+		projects[filepath.Dir(file)] = []string{file}
+	}
+	return projects, nil
+}
+
+func isInVendorDir(path, file string) bool {
+	relativePath, err := filepath.Rel(path, file)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(strings.Split(filepath.ToSlash(relativePath), "/"), vendorDirName)
+}
+
+// BuildDependencyTree builds the dependency tree of the Composer project in the working directory from its composer.lock and composer.json files, without running PHP.
+// The dependencies are typed by the section of the lock file they are locked in: 'prod' for 'packages' and 'dev' for 'packages-dev'.
+func BuildDependencyTree(workingDir string) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps map[string][]string, err error) {
+	manifest, err := readManifest(workingDir)
+	if err != nil {
+		return
+	}
+	lock, err := readLockFile(workingDir)
+	if err != nil {
+		return
+	}
+	treeMap, rootId := parseComposerLock(manifest, lock, filepath.Base(workingDir))
+	dependencyTree, uniqueDeps := coreXray.BuildXrayDependencyTree(treeMap, rootId)
+	dependencyTrees = []*xrayUtils.GraphNode{dependencyTree}
+	return
+}
+
+func readManifest(workingDir string) (manifest composerManifest, err error) {
+	manifestPath := filepath.Join(workingDir, manifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		err = errorutils.CheckError(err)
+		return
+	}
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		err = errorutils.CheckErrorf("failed to parse %s: %s", manifestPath, err.Error())
+	}
+	return
+}
+
+func readLockFile(workingDir string) (lock composerLock, err error) {
+	lockFilePath := filepath.Join(workingDir, lockFileName)
+	exists, err := fileutils.IsFileExists(lockFilePath, false)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = errorutils.CheckErrorf("%s wasn't found in %s. Run 'composer update --lock' to create it", lockFileName, workingDir)
+		return
+	}
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		err = errorutils.CheckError(err)
+		return
+	}
+	if err = json.Unmarshal(content, &lock); err != nil {
+		err = errorutils.CheckErrorf("failed to parse %s: %s", lockFilePath, err.Error())
+	}
+	return
+}
+
+// Builds the dependencies map of the locked packages. The direct dependencies of the root are the requirements of composer.json that are locked.
+// Platform requirements, like 'php' and 'ext-json', aren't locked, so they aren't dependencies.
+func parseComposerLock(manifest composerManifest, lock composerLock, defaultRootName string) (treeMap map[string]coreXray.DepTreeNode, rootId string) {
+	typesById := map[string]string{}
+	for _, lockPackage := range lock.Packages {
+		typesById[getComponentId(lockPackage.Name, lockPackage.Version)] = prodDependencyType
+	}
+	for _, lockPackage := range lock.PackagesDev {
+		typesById[getComponentId(lockPackage.Name, lockPackage.Version)] = devDependencyType
+	}
+	lockPackages := append(slices.Clone(lock.Packages), lock.PackagesDev...)
+	idsByName := map[string]string{}
+	for _, lockPackage := range lockPackages {
+		for _, names := range []map[string]string{lockPackage.Replace, lockPackage.Provide} {
+			for name := range names {
+				if _, exists := idsByName[strings.ToLower(name)]; !exists {
+					idsByName[strings.ToLower(name)] = getComponentId(lockPackage.Name, lockPackage.Version)
+				}
+			}
+		}
+	}
+	// The names of the packages take precedence over the names that other packages replace or provide.
+	for _, lockPackage := range lockPackages {
+		idsByName[strings.ToLower(lockPackage.Name)] = getComponentId(lockPackage.Name, lockPackage.Version)
+	}
+
+	treeMap = map[string]coreXray.DepTreeNode{}
+	for _, lockPackage := range lockPackages {
+		componentId := getComponentId(lockPackage.Name, lockPackage.Version)
+		types := []string{typesById[componentId]}
+		treeMap[componentId] = coreXray.DepTreeNode{Types: &types, Children: getLockedDependencies(idsByName, lockPackage.Require, componentId)}
+	}
+	rootName := manifest.Name
+	if rootName == "" {
+		rootName = defaultRootName
+	}
+	rootId = composerPackageTypeIdentifier + rootName
+	if manifest.Version != "" {
+		rootId = getComponentId(rootName, manifest.Version)
+	}
+	requirements := maps.Clone(manifest.Require)
+	if requirements == nil {
+		requirements = map[string]string{}
+	}
+	maps.Copy(requirements, manifest.RequireDev)
+	treeMap[rootId] = coreXray.DepTreeNode{Children: getLockedDependencies(idsByName, requirements, rootId)}
+	return
+}
+
+func getLockedDependencies(idsByName map[string]string, requirements map[string]string, dependentId string) []string {
+	dependencies := datastructures.MakeSet[string]()
+	for name := range requirements {
+		if componentId, locked := idsByName[strings.ToLower(name)]; locked && componentId != dependentId {
+			dependencies.Add(componentId)
+		}
+	}
+	children := dependencies.ToSlice()
+	sort.Strings(children)
+	return children
+}
+
+// The versions of many packages are locked by their tags, like 'v5.4.0', while the component versions are without the 'v' prefix.
+func getComponentId(name, version string) string {
+	if trimmedVersion := strings.TrimPrefix(version, "v"); trimmedVersion != "" && unicode.IsDigit(rune(trimmedVersion[0])) {
+		version = trimmedVersion
+	}
+	return composerPackageTypeIdentifier + strings.ToLower(name) + ":" + version
+}
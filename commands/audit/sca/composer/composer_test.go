@@ -0,0 +1,76 @@
+package composer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestProjectDir(t *testing.T) string {
+	projectDir, err := filepath.Abs(filepath.Join("..", "..", "..", "..", "tests", "testdata", "projects", "package-managers", "composer", "composer-project"))
+	require.NoError(t, err)
+	return projectDir
+}
+
+func TestDetectProjects(t *testing.T) {
+	projectDir := getTestProjectDir(t)
+	// The packages that are installed in the vendor directory aren't detected as projects.
+	projects, err := DetectProjects(projectDir, true, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{projectDir: {filepath.Join(projectDir, "composer.json")}}, projects)
+}
+
+func TestBuildDependencyTree(t *testing.T) {
+	dependencyTrees, uniqueDeps, err := BuildDependencyTree(getTestProjectDir(t))
+	require.NoError(t, err)
+	require.Len(t, dependencyTrees, 1)
+	assert.Equal(t, "composer://jfrog/composer-project", dependencyTrees[0].Id)
+	// The platform requirements aren't dependencies.
+	var directDependencies []string
+	for _, node := range dependencyTrees[0].Nodes {
+		directDependencies = append(directDependencies, node.Id)
+	}
+	assert.Equal(t, []string{"composer://monolog/monolog:3.5.0", "composer://phpunit/phpunit:10.5.11", "composer://symfony/console:6.4.4"}, directDependencies)
+	assert.Equal(t, map[string][]string{
+		"composer://jfrog/composer-project":           nil,
+		"composer://monolog/monolog:3.5.0":            {"prod"},
+		"composer://psr/log:3.0.0":                    {"prod"},
+		"composer://symfony/console:6.4.4":            {"prod"},
+		"composer://symfony/polyfill-mbstring:1.29.0": {"prod"},
+		"composer://phpunit/phpunit:10.5.11":          {"dev"},
+	}, uniqueDeps)
+}
+
+func TestBuildDependencyTreeWithoutLockFile(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, manifestFileName), []byte(`{"require": {"psr/log": "^3.0"}}`), 0644))
+	_, _, err := BuildDependencyTree(projectDir)
+	assert.ErrorContains(t, err, "composer.lock wasn't found")
+}
+
+func TestParseComposerLock(t *testing.T) {
+	manifest := composerManifest{Version: "1.0.0", Require: map[string]string{"php": ">=8.1", "Psr/Log": "^3.0"}}
+	lock := composerLock{
+		Packages: []composerLockPackage{
+			{Name: "psr/log", Version: "3.0.0"},
+			{Name: "monolog/monolog", Version: "3.5.0", Require: map[string]string{"psr/log": "^3.0"}, Provide: map[string]string{"psr/log-implementation": "3.0.0"}},
+		},
+		PackagesDev: []composerLockPackage{
+			{Name: "phpunit/phpunit", Version: "v10.5.11", Require: map[string]string{"psr/log-implementation": "^3.0"}},
+		},
+	}
+	treeMap, rootId := parseComposerLock(manifest, lock, "project-dir")
+	// The root is named by its directory when composer.json has no name, and its requirements are resolved case-insensitively.
+	assert.Equal(t, "composer://project-dir:1.0.0", rootId)
+	prodTypes, devTypes := []string{prodDependencyType}, []string{devDependencyType}
+	assert.Equal(t, map[string]coreXray.DepTreeNode{
+		"composer://project-dir:1.0.0":       {Children: []string{"composer://psr/log:3.0.0"}},
+		"composer://psr/log:3.0.0":           {Types: &prodTypes},
+		"composer://monolog/monolog:3.5.0":   {Types: &prodTypes, Children: []string{"composer://psr/log:3.0.0"}},
+		"composer://phpunit/phpunit:10.5.11": {Types: &devTypes, Children: []string{"composer://monolog/monolog:3.5.0"}},
+	}, treeMap)
+}
@@ -19,7 +19,7 @@ import (
 
 func TestBuildGoDependencyList(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "go", "go-project"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "go", "go-project"))
 	defer cleanUp()
 
 	err := removeTxtSuffix("go.mod.txt")
@@ -47,7 +47,7 @@ func TestBuildGoDependencyList(t *testing.T) {
 	}
 
 	auditBasicParams := (&xrayutils.AuditBasicParams{}).SetServerDetails(server).SetDepsRepo("test-remote")
-	rootNode, uniqueDeps, err := BuildDependencyTree(auditBasicParams)
+	rootNode, uniqueDeps, err := BuildDependencyTree(auditBasicParams, tempDirPath)
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, uniqueDeps, expectedUniqueDeps, "First is actual, Second is Expected")
 	// jfrog-ignore: test case
@@ -5,7 +5,6 @@ import (
 	biutils "github.com/jfrog/build-info-go/utils"
 	"github.com/jfrog/gofrog/datastructures"
 	goartifactoryutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/golang"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
 	"github.com/jfrog/jfrog-cli-security/utils"
 	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
@@ -17,12 +16,7 @@ const (
 	goSourceCodePrefix      = "github.com/golang/go:v"
 )
 
-func BuildDependencyTree(params utils.AuditParams) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
-	currentDir, err := coreutils.GetWorkingDirectory()
-	if err != nil {
-		return
-	}
-
+func BuildDependencyTree(params utils.AuditParams, workingDir string) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
 	server, err := params.ServerDetails()
 	if err != nil {
 		err = fmt.Errorf("failed while getting server details: %s", err.Error())
@@ -36,17 +30,17 @@ func BuildDependencyTree(params utils.AuditParams) (dependencyTree []*xrayUtils.
 		}
 	}
 	// Calculate go dependencies graph
-	dependenciesGraph, err := goutils.GetDependenciesGraph(currentDir)
+	dependenciesGraph, err := goutils.GetDependenciesGraph(workingDir)
 	if err != nil || len(dependenciesGraph) == 0 {
 		return
 	}
 	// Calculate go dependencies list
-	dependenciesList, err := goutils.GetDependenciesList(currentDir)
+	dependenciesList, err := goutils.GetDependenciesList(workingDir)
 	if err != nil {
 		return
 	}
 	// Get root module name
-	rootModuleName, err := goutils.GetModuleName(currentDir)
+	rootModuleName, err := goutils.GetModuleName(workingDir)
 	if err != nil {
 		return
 	}
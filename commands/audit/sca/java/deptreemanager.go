@@ -1,6 +1,7 @@
 package java
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"strings"
@@ -16,14 +17,16 @@ const (
 	GavPackageTypeIdentifier = "gav://"
 )
 
-func BuildDependencyTree(depTreeParams DepTreeParams, tech coreutils.Technology) ([]*xrayUtils.GraphNode, map[string][]string, error) {
+// The Maven and Gradle processes are killed if the context is done before they complete.
+func BuildDependencyTree(ctx context.Context, depTreeParams DepTreeParams, tech coreutils.Technology) ([]*xrayUtils.GraphNode, map[string][]string, error) {
 	if tech == coreutils.Maven {
-		return buildMavenDependencyTree(&depTreeParams)
+		return buildMavenDependencyTree(ctx, &depTreeParams)
 	}
-	return buildGradleDependencyTree(&depTreeParams)
+	return buildGradleDependencyTree(ctx, &depTreeParams)
 }
 
 type DepTreeParams struct {
+	WorkingDir              string
 	UseWrapper              bool
 	Server                  *config.ServerDetails
 	DepsRepo                string
@@ -33,13 +36,23 @@ type DepTreeParams struct {
 }
 
 type DepTreeManager struct {
+	workingDir string
 	server     *config.ServerDetails
 	depsRepo   string
 	useWrapper bool
+	// Cancels the package manager commands, if set.
+	ctx context.Context
 }
 
 func NewDepTreeManager(params *DepTreeParams) DepTreeManager {
-	return DepTreeManager{useWrapper: params.UseWrapper, depsRepo: params.DepsRepo, server: params.Server}
+	return DepTreeManager{workingDir: params.WorkingDir, useWrapper: params.UseWrapper, depsRepo: params.DepsRepo, server: params.Server}
+}
+
+func (dtm *DepTreeManager) context() context.Context {
+	if dtm.ctx == nil {
+		return context.Background()
+	}
+	return dtm.ctx
 }
 
 // The structure of a dependency tree of a module in a Gradle/Maven project, as created by the gradle-dep-tree and maven-dep-tree plugins.
@@ -1,6 +1,7 @@
 package java
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -56,8 +57,9 @@ type gradleDepTreeManager struct {
 	DepTreeManager
 }
 
-func buildGradleDependencyTree(params *DepTreeParams) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps map[string][]string, err error) {
+func buildGradleDependencyTree(ctx context.Context, params *DepTreeParams) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps map[string][]string, err error) {
 	manager := &gradleDepTreeManager{DepTreeManager: NewDepTreeManager(params)}
+	manager.ctx = ctx
 	outputFileContent, err := manager.runGradleDepTree()
 	if err != nil {
 		return
@@ -77,7 +79,7 @@ func (gdt *gradleDepTreeManager) runGradleDepTree() (string, error) {
 	}()
 
 	if gdt.useWrapper {
-		gdt.useWrapper, err = isGradleWrapperExist()
+		gdt.useWrapper, err = isGradleWrapperExist(gdt.workingDir)
 		if err != nil {
 			return "", err
 		}
@@ -159,7 +161,9 @@ func (gdt *gradleDepTreeManager) execGradleDepTree(depTreeDir string) (outputFil
 		fmt.Sprintf("-Dcom.jfrog.depsTreeOutputFile=%s", outputFilePath),
 		"-Dcom.jfrog.includeAllBuildFiles=true"}
 	log.Info("Running gradle deps tree command:", gradleExecPath, strings.Join(tasks, " "))
-	if output, err := exec.Command(gradleExecPath, tasks...).CombinedOutput(); err != nil {
+	gradleCmd := exec.CommandContext(gdt.context(), gradleExecPath, tasks...)
+	gradleCmd.Dir = gdt.workingDir
+	if output, err := gradleCmd.CombinedOutput(); err != nil {
 		return nil, errorutils.CheckErrorf("error running gradle-dep-tree: %s\n%s", err.Error(), string(output))
 	}
 	defer func() {
@@ -188,12 +192,12 @@ func getDepTreeArtifactoryRepository(remoteRepo string, server *config.ServerDet
 		password), nil
 }
 
-// This function assumes that the Gradle wrapper is in the root directory.
+// This function assumes that the Gradle wrapper is in the root directory of the project.
 // The --project-dir option of Gradle won't work in this case.
-func isGradleWrapperExist() (bool, error) {
+func isGradleWrapperExist(workingDir string) (bool, error) {
 	wrapperName := gradlew
 	if coreutils.IsWindows() {
 		wrapperName += ".bat"
 	}
-	return fileutils.IsFileExists(wrapperName, false)
+	return fileutils.IsFileExists(filepath.Join(workingDir, wrapperName), false)
 }
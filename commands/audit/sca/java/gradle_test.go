@@ -1,6 +1,7 @@
 package java
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -49,7 +50,7 @@ func TestGradleTreesWithoutConfig(t *testing.T) {
 	assert.NoError(t, os.Chmod(filepath.Join(tempDirPath, "gradlew"), 0700))
 
 	// Run getModulesDependencyTrees
-	modulesDependencyTrees, uniqueDeps, err := buildGradleDependencyTree(&DepTreeParams{})
+	modulesDependencyTrees, uniqueDeps, err := buildGradleDependencyTree(context.Background(), &DepTreeParams{})
 	if assert.NoError(t, err) && assert.NotNil(t, modulesDependencyTrees) {
 		assert.Len(t, uniqueDeps, 12)
 		assert.Len(t, modulesDependencyTrees, 5)
@@ -73,7 +74,7 @@ func TestGradleTreesWithConfig(t *testing.T) {
 	assert.NoError(t, os.Chmod(filepath.Join(tempDirPath, "gradlew"), 0700))
 
 	// Run getModulesDependencyTrees
-	modulesDependencyTrees, uniqueDeps, err := buildGradleDependencyTree(&DepTreeParams{UseWrapper: true})
+	modulesDependencyTrees, uniqueDeps, err := buildGradleDependencyTree(context.Background(), &DepTreeParams{UseWrapper: true})
 	if assert.NoError(t, err) && assert.NotNil(t, modulesDependencyTrees) {
 		assert.Len(t, modulesDependencyTrees, 5)
 		assert.Len(t, uniqueDeps, 11)
@@ -92,14 +93,14 @@ func TestGradleTreesWithConfig(t *testing.T) {
 
 func TestIsGradleWrapperExist(t *testing.T) {
 	// Check Gradle wrapper doesn't exist
-	isWrapperExist, err := isGradleWrapperExist()
+	isWrapperExist, err := isGradleWrapperExist(t.TempDir())
 	assert.False(t, isWrapperExist)
 	assert.NoError(t, err)
 
 	// Check Gradle wrapper exist
-	_, cleanUp := tests.CreateTestWorkspace(t, filepath.Join("..", "..", "..", "..", "tests", "testdata", "projects", "package-managers", "gradle", "gradle"))
+	tempDirPath, cleanUp := tests.CreateTestWorkspace(t, filepath.Join("..", "..", "..", "..", "tests", "testdata", "projects", "package-managers", "gradle", "gradle"))
 	defer cleanUp()
-	isWrapperExist, err = isGradleWrapperExist()
+	isWrapperExist, err = isGradleWrapperExist(tempDirPath)
 	assert.NoError(t, err)
 	assert.True(t, isWrapperExist)
 }
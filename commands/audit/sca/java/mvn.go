@@ -1,6 +1,7 @@
 package java
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -56,8 +57,9 @@ type MavenDepTreeManager struct {
 
 func NewMavenDepTreeManager(params *DepTreeParams, cmdName MavenDepTreeCmd) *MavenDepTreeManager {
 	depTreeManager := NewDepTreeManager(&DepTreeParams{
-		Server:   params.Server,
-		DepsRepo: params.DepsRepo,
+		WorkingDir: params.WorkingDir,
+		Server:     params.Server,
+		DepsRepo:   params.DepsRepo,
 	})
 	return &MavenDepTreeManager{
 		DepTreeManager:      depTreeManager,
@@ -68,8 +70,9 @@ func NewMavenDepTreeManager(params *DepTreeParams, cmdName MavenDepTreeCmd) *Mav
 	}
 }
 
-func buildMavenDependencyTree(params *DepTreeParams) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps map[string][]string, err error) {
+func buildMavenDependencyTree(ctx context.Context, params *DepTreeParams) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps map[string][]string, err error) {
 	manager := NewMavenDepTreeManager(params, Tree)
+	manager.ctx = ctx
 	outputFilePaths, clearMavenDepTreeRun, err := manager.RunMavenDepTree()
 	if err != nil {
 		if clearMavenDepTreeRun != nil {
@@ -154,7 +157,7 @@ func (mdt *MavenDepTreeManager) runProjectsCmd() (string, error) {
 }
 
 func (mdt *MavenDepTreeManager) RunMvnCmd(goals []string) (cmdOutput []byte, err error) {
-	restoreMavenConfig, err := removeMavenConfig()
+	restoreMavenConfig, err := removeMavenConfig(mdt.workingDir)
 	if err != nil {
 		return
 	}
@@ -170,7 +173,9 @@ func (mdt *MavenDepTreeManager) RunMvnCmd(goals []string) (cmdOutput []byte, err
 	}
 
 	//#nosec G204
-	cmdOutput, err = exec.Command("mvn", goals...).CombinedOutput()
+	mvnCmd := exec.CommandContext(mdt.context(), "mvn", goals...)
+	mvnCmd.Dir = mdt.workingDir
+	cmdOutput, err = mvnCmd.CombinedOutput()
 	if err != nil {
 		stringOutput := string(cmdOutput)
 		if len(cmdOutput) > 0 {
@@ -193,7 +198,8 @@ func (mdt *MavenDepTreeManager) SetSettingsXmlPath(settingsXmlPath string) {
 	mdt.settingsXmlPath = settingsXmlPath
 }
 
-func removeMavenConfig() (func() error, error) {
+func removeMavenConfig(workingDir string) (func() error, error) {
+	mavenConfigPath := filepath.Join(workingDir, mavenConfigPath)
 	mavenConfigExists, err := fileutils.IsFileExists(mavenConfigPath, false)
 	if err != nil {
 		return nil, err
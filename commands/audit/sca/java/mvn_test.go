@@ -1,6 +1,7 @@
 package java
 
 import (
+	"context"
 	"github.com/jfrog/build-info-go/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
@@ -118,7 +119,7 @@ func TestMavenTreesMultiModule(t *testing.T) {
 		GavPackageTypeIdentifier + "hsqldb:hsqldb:1.8.0.10",
 	}
 	// Run getModulesDependencyTrees
-	modulesDependencyTrees, uniqueDeps, err := buildMavenDependencyTree(&DepTreeParams{})
+	modulesDependencyTrees, uniqueDeps, err := buildMavenDependencyTree(context.Background(), &DepTreeParams{})
 	if assert.NoError(t, err) && assert.NotEmpty(t, modulesDependencyTrees) {
 		assert.ElementsMatch(t, maps.Keys(uniqueDeps), expectedUniqueDeps, "First is actual, Second is Expected")
 		// Check root module
@@ -168,7 +169,7 @@ func TestMavenWrapperTrees(t *testing.T) {
 		GavPackageTypeIdentifier + "javax.servlet:servlet-api:2.5",
 	}
 
-	modulesDependencyTrees, uniqueDeps, err := buildMavenDependencyTree(&DepTreeParams{})
+	modulesDependencyTrees, uniqueDeps, err := buildMavenDependencyTree(context.Background(), &DepTreeParams{})
 	if assert.NoError(t, err) && assert.NotEmpty(t, modulesDependencyTrees) {
 		assert.ElementsMatch(t, maps.Keys(uniqueDeps), expectedUniqueDeps, "First is actual, Second is Expected")
 		// Check root module
@@ -193,7 +194,7 @@ func TestMavenWrapperTreesTypes(t *testing.T) {
 	// Create and change directory to test workspace
 	_, cleanUp := coreTests.CreateTestWorkspace(t, filepath.Join("..", "..", "..", "..", "tests", "testdata", "projects", "package-managers", "maven", "maven-example-with-many-types"))
 	defer cleanUp()
-	tree, uniqueDeps, err := buildMavenDependencyTree(&DepTreeParams{})
+	tree, uniqueDeps, err := buildMavenDependencyTree(context.Background(), &DepTreeParams{})
 	require.NoError(t, err)
 	// dependency of pom type
 	depWithPomType := uniqueDeps["gav://org.webjars:lodash:4.17.21"]
@@ -326,7 +327,7 @@ func TestRemoveMavenConfig(t *testing.T) {
 	defer restoreDir()
 
 	// No maven.config exists
-	restoreFunc, err := removeMavenConfig()
+	restoreFunc, err := removeMavenConfig(tmpDir)
 	assert.Nil(t, restoreFunc)
 	assert.Nil(t, err)
 
@@ -337,7 +338,7 @@ func TestRemoveMavenConfig(t *testing.T) {
 	assert.NoError(t, err)
 	err = file.Close()
 	assert.NoError(t, err)
-	restoreFunc, err = removeMavenConfig()
+	restoreFunc, err = removeMavenConfig(tmpDir)
 	assert.NoError(t, err)
 	assert.NoFileExists(t, mavenConfigPath)
 	err = restoreFunc()
@@ -6,36 +6,37 @@ import (
 	biutils "github.com/jfrog/build-info-go/build/utils"
 	buildinfo "github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/npm"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
 	"github.com/jfrog/jfrog-cli-security/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	"os"
 )
 
 const (
 	IgnoreScriptsFlag = "--ignore-scripts"
 )
 
-func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
-	currentDir, err := coreutils.GetWorkingDirectory()
+func BuildDependencyTree(params utils.AuditParams, workingDir string) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	npmVersion, npmExecutablePath, err := biutils.GetNpmVersionAndExecPath(log.Logger)
 	if err != nil {
 		return
 	}
-	npmVersion, npmExecutablePath, err := biutils.GetNpmVersionAndExecPath(log.Logger)
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(workingDir, npmVersion)
 	if err != nil {
 		return
 	}
-	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(currentDir, npmVersion)
+	workspaceMembers, err := GetWorkspaceMembers(workingDir)
 	if err != nil {
 		return
 	}
 
 	treeDepsParam := createTreeDepsParam(params)
 
-	clearResolutionServerFunc, err := configNpmResolutionServerIfNeeded(params)
+	clearResolutionServerFunc, err := configNpmResolutionServerIfNeeded(params, workingDir)
 	if err != nil {
 		err = fmt.Errorf("failed while configuring a resolution server: %s", err.Error())
 		return
@@ -47,7 +48,7 @@ func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils
 	}()
 
 	// Calculate npm dependencies
-	dependenciesMap, err := biutils.CalculateDependenciesMap(npmExecutablePath, currentDir, packageInfo.BuildInfoModuleId(), treeDepsParam, log.Logger)
+	dependenciesMap, err := biutils.CalculateDependenciesMap(npmExecutablePath, workingDir, packageInfo.BuildInfoModuleId(), treeDepsParam, log.Logger)
 	if err != nil {
 		log.Info("Used npm version:", npmVersion.GetVersion())
 		return
@@ -56,6 +57,11 @@ func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils
 	for _, dependency := range dependenciesMap {
 		dependenciesList = append(dependenciesList, dependency.Dependency)
 	}
+	if len(workspaceMembers) > 0 {
+		// The workspace is resolved once, and each of its packages gets its own tree.
+		dependencyTrees, uniqueDeps = BuildWorkspaceDependencyTrees(createNpmDependenciesTreeMap(dependenciesList), utils.NpmPackageTypeIdentifier+packageInfo.BuildInfoModuleId(), workspaceMembers)
+		return
+	}
 	// Parse the dependencies into Xray dependency tree format
 	dependencyTree, uniqueDeps := parseNpmDependenciesList(dependenciesList, packageInfo)
 	dependencyTrees = []*xrayUtils.GraphNode{dependencyTree}
@@ -63,7 +69,8 @@ func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils
 }
 
 // Generates a .npmrc file to configure an Artifactory server as the resolver server.
-func configNpmResolutionServerIfNeeded(params utils.AuditParams) (clearResolutionServerFunc func() error, err error) {
+// The .npmrc file is generated in the process working directory, so the working directory is changed to the project directory while it's generated.
+func configNpmResolutionServerIfNeeded(params utils.AuditParams, workingDir string) (clearResolutionServerFunc func() error, err error) {
 	// If we don't have an artifactory repo's name we don't need to configure any Artifactory server as resolution server
 	if params.DepsRepo() == "" {
 		return
@@ -74,6 +81,16 @@ func configNpmResolutionServerIfNeeded(params utils.AuditParams) (clearResolutio
 		return
 	}
 
+	currentWorkingDir, err := os.Getwd()
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	if err = os.Chdir(workingDir); errorutils.CheckError(err) != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Chdir(currentWorkingDir)))
+	}()
 	clearResolutionServerFunc, err = npm.SetArtifactoryAsResolutionServer(serverDetails, params.DepsRepo())
 	return
 }
@@ -105,6 +122,12 @@ func addIgnoreScriptsFlag(npmArgs []string) []string {
 
 // Parse the dependencies into an Xray dependency tree format
 func parseNpmDependenciesList(dependencies []buildinfo.Dependency, packageInfo *biutils.PackageInfo) (*xrayUtils.GraphNode, []string) {
+	graph, nodeMapTypes := coreXray.BuildXrayDependencyTree(createNpmDependenciesTreeMap(dependencies), utils.NpmPackageTypeIdentifier+packageInfo.BuildInfoModuleId())
+	return graph, maps.Keys(nodeMapTypes)
+}
+
+// Maps each dependency to the dependencies it requests.
+func createNpmDependenciesTreeMap(dependencies []buildinfo.Dependency) map[string]coreXray.DepTreeNode {
 	treeMap := make(map[string]coreXray.DepTreeNode)
 	for _, dependency := range dependencies {
 		dependencyId := utils.NpmPackageTypeIdentifier + dependency.Id
@@ -119,8 +142,7 @@ func parseNpmDependenciesList(dependencies []buildinfo.Dependency, packageInfo *
 			treeMap[parent] = depTreeNode
 		}
 	}
-	graph, nodeMapTypes := coreXray.BuildXrayDependencyTree(treeMap, utils.NpmPackageTypeIdentifier+packageInfo.BuildInfoModuleId())
-	return graph, maps.Keys(nodeMapTypes)
+	return treeMap
 }
 
 func appendUniqueChild(children []string, candidateDependency string) []string {
@@ -113,12 +113,12 @@ func TestParseNpmDependenciesList(t *testing.T) {
 
 func TestIgnoreScripts(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "npm", "npm-scripts"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "npm", "npm-scripts"))
 	defer cleanUp()
 
 	// The package.json file contain a postinstall script running an "exit 1" command.
 	// Without the "--ignore-scripts" flag, the test will fail.
 	params := &utils.AuditBasicParams{}
-	_, _, err := BuildDependencyTree(params)
+	_, _, err := BuildDependencyTree(params, tempDirPath)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,230 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/datastructures"
+	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-cli-security/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	packageJsonFileName   = "package.json"
+	pnpmWorkspaceFileName = "pnpm-workspace.yaml"
+	nodeModulesDirName    = "node_modules"
+)
+
+// A package of an npm, Yarn or pnpm workspace.
+type WorkspaceMember struct {
+	Name string
+	// The absolute path of the directory of the package.
+	Dir string
+}
+
+type packageJsonWorkspaces struct {
+	Name       string          `json:"name"`
+	Workspaces json.RawMessage `json:"workspaces,omitempty"`
+}
+
+type pnpmWorkspace struct {
+	Packages []string `yaml:"packages"`
+}
+
+// Returns the members of the workspace that the given directory is the root of, or nil if it isn't a workspace root.
+// The members are listed by the 'packages' of a pnpm-workspace.yaml file, or by the 'workspaces' of the package.json file (npm and Yarn).
+func GetWorkspaceMembers(workingDir string) ([]WorkspaceMember, error) {
+	patterns, err := getWorkspacePatterns(workingDir)
+	if err != nil || len(patterns) == 0 {
+		return nil, err
+	}
+	var includePatterns, excludePatterns []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(pattern)), "./")
+		if excludePattern, isExclude := strings.CutPrefix(pattern, "!"); isExclude {
+			excludePatterns = append(excludePatterns, strings.TrimPrefix(excludePattern, "./"))
+		} else if pattern != "" {
+			includePatterns = append(includePatterns, pattern)
+		}
+	}
+	var members []WorkspaceMember
+	err = filepath.WalkDir(workingDir, func(currentPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.IsDir() || currentPath == workingDir {
+			return nil
+		}
+		if entry.Name() == nodeModulesDirName || strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		relativePath, err := filepath.Rel(workingDir, currentPath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if !matchAnyWorkspacePattern(includePatterns, relativePath) || matchAnyWorkspacePattern(excludePatterns, relativePath) {
+			return nil
+		}
+		member, isPackage, err := readWorkspaceMember(currentPath)
+		if err != nil || !isPackage {
+			return err
+		}
+		members = append(members, member)
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Dir < members[j].Dir })
+	return members, nil
+}
+
+func getWorkspacePatterns(workingDir string) ([]string, error) {
+	pnpmWorkspacePath := filepath.Join(workingDir, pnpmWorkspaceFileName)
+	if exists, err := fileutils.IsFileExists(pnpmWorkspacePath, false); err != nil || exists {
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(pnpmWorkspacePath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		var workspace pnpmWorkspace
+		if err = yaml.Unmarshal(content, &workspace); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse %s: %s", pnpmWorkspacePath, err.Error())
+		}
+		return workspace.Packages, nil
+	}
+	packageJson, exists, err := readPackageJson(workingDir)
+	if err != nil || !exists || len(packageJson.Workspaces) == 0 {
+		return nil, err
+	}
+	// The workspaces are either a list of patterns, or an object with the list of patterns in its 'packages' (Yarn).
+	var patterns []string
+	if err = json.Unmarshal(packageJson.Workspaces, &patterns); err == nil {
+		return patterns, nil
+	}
+	var workspacesObject struct {
+		Packages []string `json:"packages"`
+	}
+	if err = json.Unmarshal(packageJson.Workspaces, &workspacesObject); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the workspaces of %s: %s", filepath.Join(workingDir, packageJsonFileName), err.Error())
+	}
+	return workspacesObject.Packages, nil
+}
+
+func readWorkspaceMember(dir string) (member WorkspaceMember, isPackage bool, err error) {
+	packageJson, isPackage, err := readPackageJson(dir)
+	if err != nil || !isPackage {
+		return
+	}
+	if packageJson.Name == "" {
+		log.Debug(fmt.Sprintf("Skipping the workspace package in %s, it has no name", dir))
+		return member, false, nil
+	}
+	return WorkspaceMember{Name: packageJson.Name, Dir: dir}, true, nil
+}
+
+func readPackageJson(dir string) (packageJson packageJsonWorkspaces, exists bool, err error) {
+	packageJsonPath := filepath.Join(dir, packageJsonFileName)
+	if exists, err = fileutils.IsFileExists(packageJsonPath, false); err != nil || !exists {
+		return
+	}
+	content, err := os.ReadFile(packageJsonPath)
+	if err != nil {
+		err = errorutils.CheckError(err)
+		return
+	}
+	if err = json.Unmarshal(content, &packageJson); err != nil {
+		err = errorutils.CheckErrorf("failed to parse %s: %s", packageJsonPath, err.Error())
+	}
+	return
+}
+
+func matchAnyWorkspacePattern(patterns []string, relativePath string) bool {
+	for _, pattern := range patterns {
+		if utils.MatchPathGlob(strings.TrimSuffix(pattern, "/"), relativePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// Builds a dependency tree for each of the workspace members, and one for the root of the workspace if it has dependencies of its own.
+// The members are removed from the dependencies of the root, so each dependency is attributed only to the workspace packages that depend on it.
+// The members are matched to the nodes of the dependencies map by their package names. Members that aren't found have no dependencies to scan.
+func BuildWorkspaceDependencyTrees(treeMap map[string]coreXray.DepTreeNode, rootId string, members []WorkspaceMember) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string) {
+	membersIds := getWorkspaceMembersIds(treeMap, rootId, members)
+	uniqueDepsSet := datastructures.MakeSet[string]()
+	// The root tree is built on a copy of the dependencies map, without the members.
+	rootTreeMap := maps.Clone(treeMap)
+	rootNode := rootTreeMap[rootId]
+	rootNode.Children = slices.DeleteFunc(slices.Clone(rootNode.Children), func(child string) bool {
+		return slices.Contains(membersIds, child)
+	})
+	rootTreeMap[rootId] = rootNode
+	if len(rootNode.Children) > 0 || len(membersIds) == 0 {
+		rootTree, rootUniqueDeps := coreXray.BuildXrayDependencyTree(rootTreeMap, rootId)
+		dependencyTrees = append(dependencyTrees, rootTree)
+		uniqueDepsSet.AddElements(maps.Keys(rootUniqueDeps)...)
+	}
+	for _, memberId := range membersIds {
+		memberTree, memberUniqueDeps := coreXray.BuildXrayDependencyTree(treeMap, memberId)
+		dependencyTrees = append(dependencyTrees, memberTree)
+		uniqueDepsSet.AddElements(maps.Keys(memberUniqueDeps)...)
+	}
+	uniqueDeps = uniqueDepsSet.ToSlice()
+	return
+}
+
+// The members are looked up in the direct dependencies of the root first, where the package managers list the workspace packages.
+// A package with the same name elsewhere in the tree, like a published version of a member that another package depends on, is used only for members that aren't direct dependencies of the root.
+func getWorkspaceMembersIds(treeMap map[string]coreXray.DepTreeNode, rootId string, members []WorkspaceMember) (membersIds []string) {
+	directIdsByName := getIdsByName(treeMap[rootId].Children, rootId)
+	var allIds []string
+	for id, node := range treeMap {
+		allIds = append(allIds, id)
+		allIds = append(allIds, node.Children...)
+	}
+	idsByName := getIdsByName(allIds, rootId)
+	for _, member := range members {
+		memberId, found := directIdsByName[member.Name]
+		if !found {
+			memberId, found = idsByName[member.Name]
+		}
+		if !found {
+			log.Debug(fmt.Sprintf("The workspace package %s in %s has no dependencies", member.Name, member.Dir))
+			continue
+		}
+		membersIds = append(membersIds, memberId)
+	}
+	return
+}
+
+// Maps the package names to the ids of the packages. The ids are sorted, so the same id is chosen for a name that has several versions, whatever the order of the given ids is.
+func getIdsByName(ids []string, rootId string) map[string]string {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	idsByName := map[string]string{}
+	for _, id := range ids {
+		if name, _, _ := utils.SplitComponentId(id); name != "" && id != rootId {
+			if _, exists := idsByName[name]; !exists {
+				idsByName[name] = id
+			}
+		}
+	}
+	return idsByName
+}
@@ -0,0 +1,138 @@
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createWorkspacePackages(t *testing.T, root string, packages map[string]string) {
+	for dir, content := range packages {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, dir, packageJsonFileName), []byte(content), 0644))
+	}
+}
+
+func TestGetWorkspaceMembers(t *testing.T) {
+	packages := map[string]string{
+		filepath.Join("packages", "a"):                           `{"name": "a"}`,
+		filepath.Join("packages", "b"):                           `{"name": "@scope/b"}`,
+		filepath.Join("packages", "private"):                     `{"name": "private"}`,
+		filepath.Join("packages", "no-name"):                     `{}`,
+		filepath.Join("apps", "web", "client"):                   `{"name": "client"}`,
+		filepath.Join("apps", "web", "node_modules", "lodash"):   `{"name": "lodash"}`,
+		filepath.Join("tools", "not-a-member"):                   `{"name": "not-a-member"}`,
+		filepath.Join("packages", "a", "node_modules", "lodash"): `{"name": "lodash"}`,
+	}
+	tests := []struct {
+		name            string
+		rootPackageJson string
+		pnpmWorkspace   string
+		expectedNames   []string
+	}{
+		{name: "not a workspace", rootPackageJson: `{"name": "root"}`, expectedNames: nil},
+		{name: "npm workspaces", rootPackageJson: `{"name": "root", "workspaces": ["packages/*", "!packages/private", "apps/**"]}`, expectedNames: []string{"client", "a", "@scope/b"}},
+		{name: "yarn workspaces", rootPackageJson: `{"name": "root", "workspaces": {"packages": ["./packages/a", "apps/*/client"]}}`, expectedNames: []string{"client", "a"}},
+		{name: "pnpm workspace", rootPackageJson: `{"name": "root"}`, pnpmWorkspace: "packages:\n  - 'packages/**'\n  - '!**/private'\n", expectedNames: []string{"a", "@scope/b"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			createWorkspacePackages(t, root, packages)
+			assert.NoError(t, os.WriteFile(filepath.Join(root, packageJsonFileName), []byte(test.rootPackageJson), 0644))
+			if test.pnpmWorkspace != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(root, pnpmWorkspaceFileName), []byte(test.pnpmWorkspace), 0644))
+			}
+			members, err := GetWorkspaceMembers(root)
+			assert.NoError(t, err)
+			var names []string
+			for _, member := range members {
+				names = append(names, member.Name)
+				assert.True(t, filepath.IsAbs(member.Dir))
+			}
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+
+	_, err := GetWorkspaceMembers(t.TempDir())
+	assert.NoError(t, err)
+	invalidRoot := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(invalidRoot, packageJsonFileName), []byte(`{"workspaces": 1}`), 0644))
+	_, err = GetWorkspaceMembers(invalidRoot)
+	assert.Error(t, err)
+}
+
+func TestMatchWorkspacePattern(t *testing.T) {
+	assert.True(t, matchAnyWorkspacePattern([]string{"packages/*"}, "packages/a"))
+	assert.False(t, matchAnyWorkspacePattern([]string{"packages/*"}, "packages/a/b"))
+	assert.True(t, matchAnyWorkspacePattern([]string{"packages/**"}, "packages/a/b"))
+	assert.True(t, matchAnyWorkspacePattern([]string{"**/b"}, "packages/a/b"))
+	assert.True(t, matchAnyWorkspacePattern([]string{"packages/a/"}, "packages/a"))
+	assert.False(t, matchAnyWorkspacePattern([]string{"apps/*", "packages/a"}, "packages/b"))
+}
+
+func TestBuildWorkspaceDependencyTrees(t *testing.T) {
+	members := []WorkspaceMember{{Name: "a", Dir: "packages/a"}, {Name: "@scope/b", Dir: "packages/b"}, {Name: "no-deps", Dir: "packages/no-deps"}}
+	treeMap := map[string]coreXray.DepTreeNode{
+		"npm://root:1.0.0":     {Children: []string{"npm://a:1.0.0", "npm://@scope/b:2.0.0", "npm://typescript:5.0.0"}},
+		"npm://a:1.0.0":        {Children: []string{"npm://lodash:4.17.0", "npm://@scope/b:2.0.0"}},
+		"npm://@scope/b:2.0.0": {Children: []string{"npm://axios:1.6.0"}},
+		"npm://axios:1.6.0":    {Children: []string{"npm://follow-redirects:1.15.0"}},
+	}
+	trees, uniqueDeps := BuildWorkspaceDependencyTrees(treeMap, "npm://root:1.0.0", members)
+	if assert.Len(t, trees, 3) {
+		assert.Equal(t, "npm://root:1.0.0", trees[0].Id)
+		assert.Equal(t, []string{"npm://typescript:5.0.0"}, getChildrenIds(trees[0]))
+		assert.Equal(t, "npm://a:1.0.0", trees[1].Id)
+		assert.Equal(t, []string{"npm://lodash:4.17.0", "npm://@scope/b:2.0.0"}, getChildrenIds(trees[1]))
+		assert.Equal(t, "npm://@scope/b:2.0.0", trees[2].Id)
+		assert.Equal(t, []string{"npm://axios:1.6.0"}, getChildrenIds(trees[2]))
+	}
+	assert.ElementsMatch(t, []string{"npm://root:1.0.0", "npm://a:1.0.0", "npm://@scope/b:2.0.0", "npm://typescript:5.0.0", "npm://lodash:4.17.0", "npm://axios:1.6.0", "npm://follow-redirects:1.15.0"}, uniqueDeps)
+	// The dependencies map of the root isn't changed.
+	assert.Len(t, treeMap["npm://root:1.0.0"].Children, 3)
+
+	// The root has no dependencies of its own.
+	trees, _ = BuildWorkspaceDependencyTrees(map[string]coreXray.DepTreeNode{
+		"npm://root:1.0.0": {Children: []string{"npm://a:1.0.0"}},
+		"npm://a:1.0.0":    {Children: []string{"npm://lodash:4.17.0"}},
+	}, "npm://root:1.0.0", members)
+	if assert.Len(t, trees, 1) {
+		assert.Equal(t, "npm://a:1.0.0", trees[0].Id)
+	}
+
+	// A registry package with the name of a member isn't mistaken for the member, which is a direct dependency of the root.
+	for i := 0; i < 10; i++ {
+		trees, _ = BuildWorkspaceDependencyTrees(map[string]coreXray.DepTreeNode{
+			"npm://root:1.0.0": {Children: []string{"npm://a:1.0.0", "npm://c:1.0.0"}},
+			"npm://a:1.0.0":    {Children: []string{"npm://lodash:4.17.0"}},
+			"npm://c:1.0.0":    {Children: []string{"npm://a:0.5.0"}},
+			"npm://a:0.5.0":    {Children: []string{"npm://axios:1.6.0"}},
+		}, "npm://root:1.0.0", members)
+		if assert.Len(t, trees, 2) {
+			assert.Equal(t, "npm://root:1.0.0", trees[0].Id)
+			assert.Equal(t, []string{"npm://c:1.0.0"}, getChildrenIds(trees[0]))
+			assert.Equal(t, "npm://a:1.0.0", trees[1].Id)
+		}
+	}
+
+	// Members that aren't direct dependencies of the root are looked up in the whole tree.
+	trees, _ = BuildWorkspaceDependencyTrees(map[string]coreXray.DepTreeNode{
+		"npm://root:1.0.0":     {},
+		"npm://@scope/b:2.0.0": {Children: []string{"npm://axios:1.6.0"}},
+	}, "npm://root:1.0.0", members)
+	if assert.Len(t, trees, 1) {
+		assert.Equal(t, "npm://@scope/b:2.0.0", trees[0].Id)
+	}
+}
+
+func getChildrenIds(node *xrayUtils.GraphNode) (ids []string) {
+	for _, child := range node.Nodes {
+		ids = append(ids, child.Id)
+	}
+	return
+}
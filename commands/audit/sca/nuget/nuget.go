@@ -1,6 +1,7 @@
 package nuget
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -37,13 +38,9 @@ const (
 	globalPackagesNotFoundErrorMessage = "could not find global packages path at:"
 )
 
-func BuildDependencyTree(params utils.AuditParams) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
-	wd, err := os.Getwd()
-	if err != nil {
-		return
-	}
+func BuildDependencyTree(ctx context.Context, params utils.AuditParams, workingDir string) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
 	exclusionPattern := sca.GetExcludePattern(params)
-	sol, err := solution.Load(wd, "", exclusionPattern, log.Logger)
+	sol, err := solution.Load(workingDir, "", exclusionPattern, log.Logger)
 	if err != nil && !strings.Contains(err.Error(), globalPackagesNotFoundErrorMessage) {
 		// In older NuGet projects that utilize NuGet Cli and package.config, if the project is not installed, the solution.Load function raises an error because it cannot find global package paths.
 		// This issue is resolved by executing the 'nuget restore' command followed by running solution.Load again. Therefore, in this scenario, we need to proceed with this process.
@@ -52,7 +49,7 @@ func BuildDependencyTree(params utils.AuditParams) (dependencyTree []*xrayUtils.
 
 	if isInstallRequired(params, sol) {
 		log.Info("Dependencies sources were not detected nor 'install' command provided. Running 'restore' command")
-		sol, err = runDotnetRestoreAndLoadSolution(params, wd, exclusionPattern)
+		sol, err = runDotnetRestoreAndLoadSolution(ctx, params, workingDir, exclusionPattern)
 		if err != nil {
 			return
 		}
@@ -77,7 +74,7 @@ func isInstallRequired(params utils.AuditParams, sol solution.Solution) bool {
 
 // Generates a temporary duplicate of the project to execute the 'install' command without impacting the original directory and establishing the JFrog configuration file for Artifactory resolution
 // Additionally, re-loads the project's Solution so the dependencies sources will be identified
-func runDotnetRestoreAndLoadSolution(params utils.AuditParams, originalWd, exclusionPattern string) (sol solution.Solution, err error) {
+func runDotnetRestoreAndLoadSolution(ctx context.Context, params utils.AuditParams, originalWd, exclusionPattern string) (sol solution.Solution, err error) {
 	// Creating a temporary copy of the project in order to run 'install' command without effecting the original directory + creating the jfrog config for artifactory resolution
 	tmpWd, err := fileutils.CreateTempDir()
 	if err != nil {
@@ -128,7 +125,7 @@ func runDotnetRestoreAndLoadSolution(params utils.AuditParams, originalWd, exclu
 		installCommandArgs = append(installCommandArgs, toolType.GetTypeFlagPrefix()+"configfile", configFile.Name())
 	}
 
-	err = runDotnetRestore(tmpWd, params, toolType, installCommandArgs)
+	err = runDotnetRestore(ctx, tmpWd, params, toolType, installCommandArgs)
 	if err != nil {
 		return
 	}
@@ -195,7 +192,7 @@ func getProjectConfigurationFilesPaths(wd string) (projectConfigFilesPaths []str
 	return
 }
 
-func runDotnetRestore(wd string, params utils.AuditParams, toolType bidotnet.ToolchainType, commandExtraArgs []string) (err error) {
+func runDotnetRestore(ctx context.Context, wd string, params utils.AuditParams, toolType bidotnet.ToolchainType, commandExtraArgs []string) (err error) {
 	var completeCommandArgs []string
 	if len(params.InstallCommandArgs()) > 0 {
 		// If the user has specified an 'install' command, we execute the command that has been provided.
@@ -207,7 +204,7 @@ func runDotnetRestore(wd string, params utils.AuditParams, toolType bidotnet.Too
 
 	// We include the flag that allows resolution from an Artifactory server, if it exists.
 	completeCommandArgs = append(completeCommandArgs, commandExtraArgs...)
-	command := exec.Command(completeCommandArgs[0], completeCommandArgs[1:]...)
+	command := exec.CommandContext(ctx, completeCommandArgs[0], completeCommandArgs[1:]...)
 	command.Dir = wd
 	output, err := command.CombinedOutput()
 	if err != nil {
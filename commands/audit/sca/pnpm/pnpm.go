@@ -9,7 +9,6 @@ import (
 
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/jfrog/gofrog/io"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
@@ -37,32 +36,32 @@ type pnpmLsProject struct {
 	DevDependencies map[string]pnpmLsDependency `json:"devDependencies,omitempty"`
 }
 
-func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+func BuildDependencyTree(params utils.AuditParams, workingDir string) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
 	// Prepare
-	currentDir, err := coreutils.GetWorkingDirectory()
-	if err != nil {
-		return
-	}
 	pnpmExecPath, err := getPnpmExecPath()
 	if err != nil {
 		return
 	}
 	// Build
 	var dirForDependenciesCalculation string
-	if dirForDependenciesCalculation, err = installProjectIfNeeded(pnpmExecPath, currentDir); errorutils.CheckError(err) != nil {
+	if dirForDependenciesCalculation, err = installProjectIfNeeded(pnpmExecPath, workingDir); errorutils.CheckError(err) != nil {
 		return
 	}
 
 	if dirForDependenciesCalculation == "" {
 		// If we didn't execute 'install' dirForDependenciesCalculation contains an empty value and the dependencies calculation should be performed on the original cloned dir
-		dirForDependenciesCalculation = currentDir
+		dirForDependenciesCalculation = workingDir
 	} else {
 		// If tempDirForDependenciesCalculation contains a non-empty value, it means we created a temporary directory during the execution of 'install' command, and it needs to removed at the end
 		defer func() {
 			err = errors.Join(err, biutils.RemoveTempDir(dirForDependenciesCalculation))
 		}()
 	}
-	return calculateDependencies(pnpmExecPath, dirForDependenciesCalculation, params)
+	workspaceMembers, err := npm.GetWorkspaceMembers(workingDir)
+	if err != nil {
+		return
+	}
+	return calculateDependencies(pnpmExecPath, dirForDependenciesCalculation, params, len(workspaceMembers) > 0)
 }
 
 func getPnpmExecPath() (pnpmExecPath string, err error) {
@@ -124,8 +123,12 @@ func installProjectIfNeeded(pnpmExecPath, workingDir string) (dirForDependencies
 }
 
 // Run 'pnpm ls ...' command (project must be installed) and parse the returned result to create a dependencies trees for the projects.
-func calculateDependencies(executablePath, workingDir string, params utils.AuditParams) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+// In a workspace, the packages of the workspace are listed recursively, and each of them gets its own tree.
+func calculateDependencies(executablePath, workingDir string, params utils.AuditParams, isWorkspace bool) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
 	lsArgs := append([]string{"--depth", "Infinity", "--json", "--long"}, params.Args()...)
+	if isWorkspace {
+		lsArgs = append(lsArgs, "--recursive")
+	}
 	npmLsCmdContent, err := getPnpmCmd(executablePath, workingDir, "ls", lsArgs...).RunWithOutput()
 	if err != nil {
 		return
@@ -142,6 +145,10 @@ func calculateDependencies(executablePath, workingDir string, params utils.Audit
 func parsePnpmLSContent(projectInfo []pnpmLsProject) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string) {
 	uniqueDepsSet := datastructures.MakeSet[string]()
 	for _, project := range projectInfo {
+		if len(projectInfo) > 1 && len(project.Dependencies) == 0 && len(project.DevDependencies) == 0 {
+			// A package of a workspace without dependencies, usually the root of the workspace.
+			continue
+		}
 		// Parse the dependencies into Xray dependency tree format
 		dependencyTree, uniqueProjectDeps := coreXray.BuildXrayDependencyTree(createProjectDependenciesTree(project), getDependencyId(project.Name, project.Version))
 		// Add results
@@ -18,7 +18,7 @@ import (
 
 func TestBuildDependencyTree(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "npm", "npm-no-lock"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "npm", "npm-no-lock"))
 	defer cleanUp()
 
 	testCases := []struct {
@@ -73,7 +73,7 @@ func TestBuildDependencyTree(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			// Build dependency tree
 			params := &utils.AuditBasicParams{}
-			rootNode, uniqueDeps, err := BuildDependencyTree(params.SetNpmScope(testCase.depType))
+			rootNode, uniqueDeps, err := BuildDependencyTree(params.SetNpmScope(testCase.depType), tempDirPath)
 			require.NoError(t, err)
 			// Validations
 			assert.ElementsMatch(t, uniqueDeps, testCase.expectedUniqueDeps, "First is actual, Second is Expected")
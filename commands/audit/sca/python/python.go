@@ -1,6 +1,7 @@
 package python
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,7 +30,11 @@ const (
 	pythonReportFile            = "report.json"
 )
 
+// Pipenv creates the virtual environment in the '.venv' directory of the project, where 'pipenv graph' finds it.
+var pipenvEnv = []string{"PIPENV_VENV_IN_PROJECT=1"}
+
 type AuditPython struct {
+	WorkingDir          string
 	Server              *config.ServerDetails
 	Tool                pythonutils.PythonTool
 	RemotePypiRepo      string
@@ -37,8 +42,8 @@ type AuditPython struct {
 	IsCurationCmd       bool
 }
 
-func BuildDependencyTree(auditPython *AuditPython) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps []string, downloadUrls map[string]string, err error) {
-	dependenciesGraph, directDependenciesList, pipUrls, errGetTree := getDependencies(auditPython)
+func BuildDependencyTree(ctx context.Context, auditPython *AuditPython) (dependencyTree []*xrayUtils.GraphNode, uniqueDeps []string, downloadUrls map[string]string, err error) {
+	dependenciesGraph, directDependenciesList, pipUrls, errGetTree := getDependencies(ctx, auditPython)
 	if errGetTree != nil {
 		err = errGetTree
 		return
@@ -63,37 +68,25 @@ func BuildDependencyTree(auditPython *AuditPython) (dependencyTree []*xrayUtils.
 	return
 }
 
-func getDependencies(auditPython *AuditPython) (dependenciesGraph map[string][]string, directDependencies []string, pipUrls map[string]string, err error) {
-	wd, err := os.Getwd()
-	if errorutils.CheckError(err) != nil {
-		return
-	}
-
+// The project is installed in a temporary copy of the working directory, and the package manager commands run in that copy.
+// Pip is configured through the process environment variables, so its installation should not run concurrently with other installations.
+func getDependencies(ctx context.Context, auditPython *AuditPython) (dependenciesGraph map[string][]string, directDependencies []string, pipUrls map[string]string, err error) {
 	// Create temp dir to run all work outside users working directory
 	tempDirPath, err := fileutils.CreateTempDir()
 	if err != nil {
 		return
 	}
 
-	err = os.Chdir(tempDirPath)
-	if errorutils.CheckError(err) != nil {
-		return
-	}
-
 	defer func() {
-		err = errors.Join(
-			err,
-			errorutils.CheckError(os.Chdir(wd)),
-			fileutils.RemoveTempDir(tempDirPath),
-		)
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
 	}()
 
-	err = biutils.CopyDir(wd, tempDirPath, true, nil)
+	err = biutils.CopyDir(auditPython.WorkingDir, tempDirPath, true, nil)
 	if err != nil {
 		return
 	}
 
-	restoreEnv, err := runPythonInstall(auditPython)
+	restoreEnv, err := runPythonInstall(ctx, auditPython, tempDirPath)
 	defer func() {
 		err = errors.Join(err, restoreEnv())
 	}()
@@ -113,7 +106,7 @@ func getDependencies(auditPython *AuditPython) (dependenciesGraph map[string][]s
 	if !auditPython.IsCurationCmd {
 		return
 	}
-	pipUrls, errProcessed := processPipDownloadsUrlsFromReportFile()
+	pipUrls, errProcessed := processPipDownloadsUrlsFromReportFile(filepath.Join(tempDirPath, pythonReportFile))
 	if errProcessed != nil {
 		err = errProcessed
 
@@ -121,8 +114,8 @@ func getDependencies(auditPython *AuditPython) (dependenciesGraph map[string][]s
 	return
 }
 
-func processPipDownloadsUrlsFromReportFile() (map[string]string, error) {
-	pipReport, err := readPipReportIfExists()
+func processPipDownloadsUrlsFromReportFile(reportFilePath string) (map[string]string, error) {
+	pipReport, err := readPipReportIfExists(reportFilePath)
 	if err != nil {
 		return nil, err
 	}
@@ -136,8 +129,8 @@ func processPipDownloadsUrlsFromReportFile() (map[string]string, error) {
 	return pipUrls, nil
 }
 
-func readPipReportIfExists() (pipReport *pypiReport, err error) {
-	if exist, existErr := fileutils.IsFileExists(pythonReportFile, false); existErr != nil {
+func readPipReportIfExists(reportFilePath string) (pipReport *pypiReport, err error) {
+	if exist, existErr := fileutils.IsFileExists(reportFilePath, false); existErr != nil {
 		err = existErr
 		return
 	} else if !exist {
@@ -146,7 +139,7 @@ func readPipReportIfExists() (pipReport *pypiReport, err error) {
 	}
 
 	var reportBytes []byte
-	if reportBytes, err = fileutils.ReadFile(pythonReportFile); err != nil {
+	if reportBytes, err = fileutils.ReadFile(reportFilePath); err != nil {
 		return
 	}
 	pipReport = &pypiReport{}
@@ -174,19 +167,19 @@ type pypiMetaData struct {
 	Version string `json:"version"`
 }
 
-func runPythonInstall(auditPython *AuditPython) (restoreEnv func() error, err error) {
+func runPythonInstall(ctx context.Context, auditPython *AuditPython, projectDir string) (restoreEnv func() error, err error) {
 	switch auditPython.Tool {
 	case pythonutils.Pip:
-		return installPipDeps(auditPython)
+		return installPipDeps(ctx, auditPython, projectDir)
 	case pythonutils.Pipenv:
-		return installPipenvDeps(auditPython)
+		return installPipenvDeps(ctx, auditPython, projectDir)
 	case pythonutils.Poetry:
-		return installPoetryDeps(auditPython)
+		return installPoetryDeps(ctx, auditPython, projectDir)
 	}
 	return
 }
 
-func installPoetryDeps(auditPython *AuditPython) (restoreEnv func() error, err error) {
+func installPoetryDeps(ctx context.Context, auditPython *AuditPython, projectDir string) (restoreEnv func() error, err error) {
 	restoreEnv = func() error {
 		return nil
 	}
@@ -196,34 +189,44 @@ func installPoetryDeps(auditPython *AuditPython) (restoreEnv func() error, err e
 			return restoreEnv, err
 		}
 		if password != "" {
-			err = utils.ConfigPoetryRepo(rtUrl.Scheme+"://"+rtUrl.Host+rtUrl.Path, username, password, auditPython.RemotePypiRepo)
+			err = configPoetryRepo(projectDir, rtUrl.Scheme+"://"+rtUrl.Host+rtUrl.Path, username, password, auditPython.RemotePypiRepo)
 			if err != nil {
 				return restoreEnv, err
 			}
 		}
 	}
 	// Run 'poetry install'
-	return restoreEnv, executeCommand("poetry", "install")
+	return restoreEnv, executeCommand(ctx, projectDir, nil, "poetry", "install")
 }
 
-func installPipenvDeps(auditPython *AuditPython) (restoreEnv func() error, err error) {
-	// Set virtualenv path to venv dir
-	err = os.Setenv("WORKON_HOME", ".jfrog")
-	if err != nil {
+// The Poetry repository is added to the pyproject.toml file in the process working directory, so the working directory is changed to the project directory while it's configured.
+func configPoetryRepo(projectDir, url, username, password, configRepoName string) (err error) {
+	wd, err := os.Getwd()
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	if err = os.Chdir(projectDir); errorutils.CheckError(err) != nil {
 		return
 	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Chdir(wd)))
+	}()
+	return utils.ConfigPoetryRepo(url, username, password, configRepoName)
+}
+
+func installPipenvDeps(ctx context.Context, auditPython *AuditPython, projectDir string) (restoreEnv func() error, err error) {
 	restoreEnv = func() error {
-		return os.Unsetenv("WORKON_HOME")
+		return nil
 	}
 	if auditPython.RemotePypiRepo != "" {
-		return restoreEnv, runPipenvInstallFromRemoteRegistry(auditPython.Server, auditPython.RemotePypiRepo)
+		return restoreEnv, runPipenvInstallFromRemoteRegistry(ctx, projectDir, auditPython.Server, auditPython.RemotePypiRepo)
 	}
 	// Run 'pipenv install -d'
-	return restoreEnv, executeCommand("pipenv", "install", "-d")
+	return restoreEnv, executeCommand(ctx, projectDir, pipenvEnv, "pipenv", "install", "-d")
 }
 
-func installPipDeps(auditPython *AuditPython) (restoreEnv func() error, err error) {
-	restoreEnv, err = SetPipVirtualEnvPath()
+func installPipDeps(ctx context.Context, auditPython *AuditPython, projectDir string) (restoreEnv func() error, err error) {
+	restoreEnv, err = setPipVirtualEnvPath(ctx, projectDir)
 	if err != nil {
 		return
 	}
@@ -247,10 +250,10 @@ func installPipDeps(auditPython *AuditPython) (restoreEnv func() error, err erro
 
 	pipInstallArgs := getPipInstallArgs(auditPython.PipRequirementsFile, remoteUrl, curationCachePip, reportFileName)
 	var reqErr error
-	err = executeCommand("python", pipInstallArgs...)
+	err = executeCommand(ctx, projectDir, nil, "python", pipInstallArgs...)
 	if err != nil && auditPython.PipRequirementsFile == "" {
 		pipInstallArgs = getPipInstallArgs("requirements.txt", remoteUrl, curationCachePip, reportFileName)
-		reqErr = executeCommand("python", pipInstallArgs...)
+		reqErr = executeCommand(ctx, projectDir, nil, "python", pipInstallArgs...)
 		if reqErr != nil {
 			// Return Pip install error and log the requirements fallback error.
 			log.Debug(reqErr.Error())
@@ -266,8 +269,14 @@ func installPipDeps(auditPython *AuditPython) (restoreEnv func() error, err erro
 	return
 }
 
-func executeCommand(executable string, args ...string) error {
-	installCmd := exec.Command(executable, args...)
+// The command runs in the given directory, with the given environment variables added to the process environment.
+// The command is killed if the context is done before it completes.
+func executeCommand(ctx context.Context, dir string, env []string, executable string, args ...string) error {
+	installCmd := exec.CommandContext(ctx, executable, args...)
+	installCmd.Dir = dir
+	if len(env) > 0 {
+		installCmd.Env = append(os.Environ(), env...)
+	}
 	maskedCmdString := coreutils.GetMaskedCommandString(installCmd)
 	log.Debug("Running", maskedCmdString)
 	output, err := installCmd.CombinedOutput()
@@ -302,17 +311,22 @@ func getPipInstallArgs(requirementsFile string, remoteUrl string, cacheFolder st
 	return args
 }
 
-func runPipenvInstallFromRemoteRegistry(server *config.ServerDetails, depsRepoName string) (err error) {
+func runPipenvInstallFromRemoteRegistry(ctx context.Context, projectDir string, server *config.ServerDetails, depsRepoName string) (err error) {
 	rtUrl, err := utils.GetPypiRepoUrl(server, depsRepoName, false)
 	if err != nil {
 		return err
 	}
 	args := []string{"install", "-d", utils.GetPypiRemoteRegistryFlag(pythonutils.Pipenv), rtUrl}
-	return executeCommand("pipenv", args...)
+	return executeCommand(ctx, projectDir, pipenvEnv, "pipenv", args...)
 }
 
 // Execute virtualenv command: "virtualenv venvdir" / "python3 -m venv venvdir" and set path
 func SetPipVirtualEnvPath() (restoreEnv func() error, err error) {
+	return setPipVirtualEnvPath(context.Background(), "")
+}
+
+// The virtual environment is created in the given directory, or in the process working directory if it's empty.
+func setPipVirtualEnvPath(ctx context.Context, dir string) (restoreEnv func() error, err error) {
 	restoreEnv = func() error {
 		return nil
 	}
@@ -324,11 +338,11 @@ func SetPipVirtualEnvPath() (restoreEnv func() error, err error) {
 		cmdArgs = append(cmdArgs, windowsPyArg)
 	}
 	cmdArgs = append(cmdArgs, "-m", "venv", venvdirName)
-	err = executeCommand(pythonPath, cmdArgs...)
+	err = executeCommand(ctx, dir, nil, pythonPath, cmdArgs...)
 	if err != nil {
 		// Failed running 'python -m venv', trying to run 'virtualenv'
 		log.Debug("Failed running python venv:", err.Error())
-		err = executeCommand("virtualenv", "-p", pythonPath, venvdirName)
+		err = executeCommand(ctx, dir, nil, "virtualenv", "-p", pythonPath, venvdirName)
 		if err != nil {
 			return
 		}
@@ -336,7 +350,7 @@ func SetPipVirtualEnvPath() (restoreEnv func() error, err error) {
 
 	// Keep original value of 'PATH'.
 	origPathValue := os.Getenv("PATH")
-	venvPath, err := filepath.Abs(venvdirName)
+	venvPath, err := filepath.Abs(filepath.Join(dir, venvdirName))
 	if err != nil {
 		return
 	}
@@ -1,6 +1,7 @@
 package python
 
 import (
+	"context"
 	"github.com/jfrog/build-info-go/utils/pythonutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"path/filepath"
@@ -14,12 +15,13 @@ import (
 
 func TestBuildPipDependencyListSetuppy(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "setuppyproject"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "setuppyproject"))
 	defer cleanUp()
 	// Run getModulesDependencyTrees
-	rootNode, uniqueDeps, _, err := BuildDependencyTree(&AuditPython{
-		Server: nil,
-		Tool:   pythonutils.PythonTool(coreutils.Pip),
+	rootNode, uniqueDeps, _, err := BuildDependencyTree(context.Background(), &AuditPython{
+		WorkingDir: tempDirPath,
+		Server:     nil,
+		Tool:       pythonutils.PythonTool(coreutils.Pip),
 	})
 	assert.NoError(t, err)
 	assert.Contains(t, uniqueDeps, PythonPackageTypeIdentifier+"pexpect:4.8.0")
@@ -41,10 +43,11 @@ func TestBuildPipDependencyListSetuppy(t *testing.T) {
 
 func TestBuildPipDependencyListSetuppyForCuration(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "setuppyproject"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "setuppyproject"))
 	defer cleanUp()
 	// Run getModulesDependencyTrees
-	rootNode, uniqueDeps, downloadUrls, err := BuildDependencyTree(&AuditPython{
+	rootNode, uniqueDeps, downloadUrls, err := BuildDependencyTree(context.Background(), &AuditPython{
+		WorkingDir:    tempDirPath,
 		Server:        nil,
 		Tool:          pythonutils.PythonTool(coreutils.Pip),
 		IsCurationCmd: true,
@@ -75,11 +78,12 @@ func TestBuildPipDependencyListSetuppyForCuration(t *testing.T) {
 
 func TestPipDependencyListRequirementsFallback(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "requirementsproject"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "requirementsproject"))
 	defer cleanUp()
 	// No requirements file field specified, expect the command to use the fallback 'pip install -r requirements.txt' command
-	rootNode, uniqueDeps, _, err := BuildDependencyTree(&AuditPython{
-		Tool: pythonutils.PythonTool(coreutils.Pip),
+	rootNode, uniqueDeps, _, err := BuildDependencyTree(context.Background(), &AuditPython{
+		WorkingDir: tempDirPath,
+		Tool:       pythonutils.PythonTool(coreutils.Pip),
 	})
 	assert.NoError(t, err)
 	assert.Contains(t, uniqueDeps, PythonPackageTypeIdentifier+"pexpect:4.7.0")
@@ -96,10 +100,11 @@ func TestPipDependencyListRequirementsFallback(t *testing.T) {
 
 func TestBuildPipDependencyListRequirements(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "requirementsproject"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pip", "pip", "requirementsproject"))
 	defer cleanUp()
 	// Run getModulesDependencyTrees
-	rootNode, uniqueDeps, _, err := BuildDependencyTree(&AuditPython{
+	rootNode, uniqueDeps, _, err := BuildDependencyTree(context.Background(), &AuditPython{
+		WorkingDir:          tempDirPath,
 		Server:              nil,
 		Tool:                pythonutils.PythonTool(coreutils.Pip),
 		PipRequirementsFile: "requirements.txt",
@@ -121,7 +126,7 @@ func TestBuildPipDependencyListRequirements(t *testing.T) {
 
 func TestBuildPipenvDependencyList(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pipenv", "pipenv", "pipenvproject"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "pipenv", "pipenv", "pipenvproject"))
 	defer cleanUp()
 	expectedPipenvUniqueDeps := []string{
 		PythonPackageTypeIdentifier + "toml:0.10.2",
@@ -129,9 +134,10 @@ func TestBuildPipenvDependencyList(t *testing.T) {
 		PythonPackageTypeIdentifier + "ptyprocess:0.7.0",
 	}
 	// Run getModulesDependencyTrees
-	rootNode, uniqueDeps, _, err := BuildDependencyTree(&AuditPython{
-		Server: nil,
-		Tool:   pythonutils.PythonTool(coreutils.Pipenv),
+	rootNode, uniqueDeps, _, err := BuildDependencyTree(context.Background(), &AuditPython{
+		WorkingDir: tempDirPath,
+		Server:     nil,
+		Tool:       pythonutils.PythonTool(coreutils.Pipenv),
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -151,7 +157,7 @@ func TestBuildPipenvDependencyList(t *testing.T) {
 
 func TestBuildPoetryDependencyList(t *testing.T) {
 	// Create and change directory to test workspace
-	_, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "poetry", "my-poetry-project"))
+	tempDirPath, cleanUp := sca.CreateTestWorkspace(t, filepath.Join("projects", "package-managers", "python", "poetry", "my-poetry-project"))
 	defer cleanUp()
 	expectedPoetryUniqueDeps := []string{
 		PythonPackageTypeIdentifier + "wcwidth:0.2.8",
@@ -167,8 +173,9 @@ func TestBuildPoetryDependencyList(t *testing.T) {
 		PythonPackageTypeIdentifier + "pytest:5.4.3",
 	}
 	// Run getModulesDependencyTrees
-	rootNode, uniqueDeps, _, err := BuildDependencyTree(&AuditPython{
-		Tool: pythonutils.PythonTool(coreutils.Poetry),
+	rootNode, uniqueDeps, _, err := BuildDependencyTree(context.Background(), &AuditPython{
+		WorkingDir: tempDirPath,
+		Tool:       pythonutils.PythonTool(coreutils.Poetry),
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"golang.org/x/exp/maps"
+	"os"
 	"path/filepath"
 
 	"github.com/jfrog/build-info-go/build"
@@ -11,9 +12,9 @@ import (
 	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/yarn"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	coreXray "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/npm"
 	"github.com/jfrog/jfrog-cli-security/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -40,28 +41,28 @@ const (
 	nodeModulesRepoName = "node_modules"
 )
 
-func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
-	currentDir, err := coreutils.GetWorkingDirectory()
-	if err != nil {
-		return
-	}
+func BuildDependencyTree(params utils.AuditParams, workingDir string) (dependencyTrees []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
 	executablePath, err := biutils.GetYarnExecutable()
 	if errorutils.CheckError(err) != nil {
 		return
 	}
 
-	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(currentDir, nil)
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(workingDir, nil)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
+	workspaceMembers, err := npm.GetWorkspaceMembers(workingDir)
+	if err != nil {
+		return
+	}
 
-	installRequired, err := isInstallRequired(currentDir, params.InstallCommandArgs())
+	installRequired, err := isInstallRequired(workingDir, params.InstallCommandArgs())
 	if err != nil {
 		return
 	}
 
 	if installRequired {
-		err = configureYarnResolutionServerAndRunInstall(params, currentDir, executablePath)
+		err = configureYarnResolutionServerAndRunInstall(params, workingDir, executablePath)
 		if err != nil {
 			err = fmt.Errorf("failed to configure an Artifactory resolution server or running and install command: %s", err.Error())
 			return
@@ -69,10 +70,15 @@ func BuildDependencyTree(params utils.AuditParams) (dependencyTrees []*xrayUtils
 	}
 
 	// Calculate Yarn dependencies
-	dependenciesMap, root, err := biutils.GetYarnDependencies(executablePath, currentDir, packageInfo, log.Logger)
+	dependenciesMap, root, err := biutils.GetYarnDependencies(executablePath, workingDir, packageInfo, log.Logger)
 	if err != nil {
 		return
 	}
+	if len(workspaceMembers) > 0 {
+		// The workspace is resolved once, and each of its packages gets its own tree.
+		dependencyTrees, uniqueDeps = npm.BuildWorkspaceDependencyTrees(createYarnDependenciesTreeMap(dependenciesMap), getXrayDependencyId(root), workspaceMembers)
+		return
+	}
 	// Parse the dependencies into Xray dependency tree format
 	dependencyTree, uniqueDeps := parseYarnDependenciesMap(dependenciesMap, getXrayDependencyId(root))
 	dependencyTrees = []*xrayUtils.GraphNode{dependencyTree}
@@ -106,15 +112,32 @@ func configureYarnResolutionServerAndRunInstall(params utils.AuditParams, curWd,
 		return
 	}
 
-	// If an Artifactory resolution repository was provided we first configure to resolve from it and only then run the 'install' command
-	restoreYarnrcFunc, err := ioutils.BackupFile(filepath.Join(curWd, yarn.YarnrcFileName), yarn.YarnrcBackupFileName)
+	registry, repoAuthIdent, err := yarn.GetYarnAuthDetails(serverDetails, depsRepo)
 	if err != nil {
 		return
 	}
 
-	registry, repoAuthIdent, err := yarn.GetYarnAuthDetails(serverDetails, depsRepo)
+	log.Info(fmt.Sprintf("Resolving dependencies from '%s' from repo '%s'", serverDetails.Url, depsRepo))
+	return runYarnInstallWithResolutionServer(curWd, yarnExecPath, registry, repoAuthIdent, params.InstallCommandArgs())
+}
+
+// Configures Yarn to resolve the dependencies from the given registry, executes the 'install' command and restores the configurations.
+// 'yarn config' reads and writes the scope registries of the process working directory, so the project directory is the working directory until the configurations are restored.
+func runYarnInstallWithResolutionServer(curWd, yarnExecPath, registry, repoAuthIdent string, installCommandArgs []string) (err error) {
+	currentWorkingDir, err := os.Getwd()
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	if err = os.Chdir(curWd); errorutils.CheckError(err) != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Chdir(currentWorkingDir)))
+	}()
+
+	// We first configure to resolve from the registry and only then run the 'install' command
+	restoreYarnrcFunc, err := ioutils.BackupFile(filepath.Join(curWd, yarn.YarnrcFileName), yarn.YarnrcBackupFileName)
 	if err != nil {
-		err = errors.Join(err, restoreYarnrcFunc())
 		return
 	}
 
@@ -131,8 +154,7 @@ func configureYarnResolutionServerAndRunInstall(params utils.AuditParams, curWd,
 		err = errors.Join(err, yarn.RestoreConfigurationsFromBackup(backupEnvMap, restoreYarnrcFunc))
 	}()
 
-	log.Info(fmt.Sprintf("Resolving dependencies from '%s' from repo '%s'", serverDetails.Url, depsRepo))
-	return runYarnInstallAccordingToVersion(curWd, yarnExecPath, params.InstallCommandArgs())
+	return runYarnInstallAccordingToVersion(curWd, yarnExecPath, installCommandArgs)
 }
 
 func isInstallRequired(currentDir string, installCommandArgs []string) (installRequired bool, err error) {
@@ -200,6 +222,12 @@ func runYarnInstallAccordingToVersion(curWd, yarnExecPath string, installCommand
 
 // Parse the dependencies into a Xray dependency tree format
 func parseYarnDependenciesMap(dependencies map[string]*biutils.YarnDependency, rootXrayId string) (*xrayUtils.GraphNode, []string) {
+	graph, uniqDeps := coreXray.BuildXrayDependencyTree(createYarnDependenciesTreeMap(dependencies), rootXrayId)
+	return graph, maps.Keys(uniqDeps)
+}
+
+// Maps each dependency to its own dependencies.
+func createYarnDependenciesTreeMap(dependencies map[string]*biutils.YarnDependency) map[string]coreXray.DepTreeNode {
 	treeMap := make(map[string]coreXray.DepTreeNode)
 	for _, dependency := range dependencies {
 		xrayDepId := getXrayDependencyId(dependency)
@@ -211,8 +239,7 @@ func parseYarnDependenciesMap(dependencies map[string]*biutils.YarnDependency, r
 			treeMap[xrayDepId] = coreXray.DepTreeNode{Children: subDeps}
 		}
 	}
-	graph, uniqDeps := coreXray.BuildXrayDependencyTree(treeMap, rootXrayId)
-	return graph, maps.Keys(uniqDeps)
+	return treeMap
 }
 
 func getXrayDependencyId(yarnDependency *biutils.YarnDependency) string {
@@ -4,11 +4,16 @@ import (
 	"github.com/jfrog/build-info-go/build"
 	biutils "github.com/jfrog/build-info-go/build/utils"
 	utils2 "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/yarn"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
 	"github.com/jfrog/jfrog-cli-security/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	clientTestUtils "github.com/jfrog/jfrog-client-go/utils/tests"
 	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -109,3 +114,40 @@ func executeRunYarnInstallAccordingToVersionAndVerifyInstallation(t *testing.T,
 	assert.NoError(t, err)
 	assert.False(t, installRequired)
 }
+
+func TestRunYarnInstallWithResolutionServerKeepsOtherYarnrc(t *testing.T) {
+	if coreutils.IsWindows() {
+		t.Skip("The fake yarn executable is a shell script")
+	}
+	// The fake yarn v3 executable replaces the scope registries in the .yarnrc.yml file of its working directory.
+	yarnExecPath := filepath.Join(t.TempDir(), "yarn")
+	require.NoError(t, os.WriteFile(yarnExecPath, []byte(`#!/bin/sh
+case "$1 $2" in
+--version*) echo 3.6.4 ;;
+"config get") echo '{"jfrog":{"npmRegistryServer":"https://registry.yarnpkg.com"}}' ;;
+"config set") echo "npmScopes: $4" > .yarnrc.yml ;;
+esac
+`), 0755))
+	scopedYarnrc := []byte("npmScopes:\n  jfrog:\n    npmRegistryServer: https://registry.yarnpkg.com\n")
+
+	// The scan runs from another directory, with its own scoped .yarnrc.yml file.
+	runDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, yarn.YarnrcFileName), scopedYarnrc, 0644))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallback := clientTestUtils.ChangeDirWithCallback(t, wd, runDir)
+	defer chdirCallback()
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, yarn.YarnrcFileName), scopedYarnrc, 0644))
+
+	assert.NoError(t, runYarnInstallWithResolutionServer(projectDir, yarnExecPath, "https://artifactory/api/npm/npm-remote", "auth", []string{}))
+	// The .yarnrc.yml file outside the project is left unchanged, and the project's file is restored.
+	for _, dir := range []string{runDir, projectDir} {
+		content, err := os.ReadFile(filepath.Join(dir, yarn.YarnrcFileName))
+		require.NoError(t, err)
+		assert.Equal(t, string(scopedYarnrc), string(content))
+	}
+	currentDir, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, runDir, currentDir)
+}
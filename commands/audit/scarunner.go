@@ -1,19 +1,25 @@
 package audit
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jfrog/build-info-go/utils/pythonutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
-	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/gofrog/parallel"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/sca"
+	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/cargo"
+	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/composer"
 	_go "github.com/jfrog/jfrog-cli-security/commands/audit/sca/go"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/java"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/npm"
@@ -28,21 +34,29 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/jfrog/jfrog-client-go/xray/services"
 	xrayCmdUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/slices"
 )
 
-func runScaScan(params *AuditParams, results *xrayutils.Results) (err error) {
+// The scans that didn't complete before the context is done fail with the context error, the results of the completed scans are kept.
+func runScaScan(ctx context.Context, params *AuditParams, results *xrayutils.Results) (err error) {
 	// Prepare
-	currentWorkingDir, err := os.Getwd()
-	if errorutils.CheckError(err) != nil {
-		return
-	}
 	serverDetails, err := params.ServerDetails()
 	if err != nil {
 		return
 	}
 
-	scans := getScaScansToPreform(params)
+	var scans []*xrayutils.ScaScanResult
+	var importedTrees []*DependencyTreeResult
+	if params.dependencyTrees != nil {
+		scans, importedTrees = getImportedScaScans(params.dependencyTrees)
+	} else {
+		scans = getScaScansToPreform(params)
+	}
 	if len(scans) == 0 {
+		if params.changedFiles != nil {
+			log.Info("The dependencies of the projects didn't change. Skipping the SCA scan...")
+			return
+		}
 		log.Info("Couldn't determine a package manager or build tool used by this project. Skipping the SCA scan...")
 		return
 	}
@@ -52,16 +66,37 @@ func runScaScan(params *AuditParams, results *xrayutils.Results) (err error) {
 	}
 	log.Info(fmt.Sprintf("Preforming %d SCA scans:\n%s", len(scans), scanInfo))
 
-	defer func() {
-		// Make sure to return to the original working directory, executeScaScan may change it
-		err = errors.Join(err, os.Chdir(currentWorkingDir))
-	}()
-	for _, scan := range scans {
-		// Run the scan
-		log.Info("Running SCA scan for", scan.Technology, "vulnerable dependencies in", scan.WorkingDirectory, "directory...")
-		if wdScanErr := executeScaScan(serverDetails, params, scan); wdScanErr != nil {
-			err = errors.Join(err, fmt.Errorf("audit command in '%s' failed:\n%s", scan.WorkingDirectory, wdScanErr.Error()))
-			continue
+	// The scans run concurrently. Their outputs are kept by the scan index, so the results are collected in the order of the scans.
+	scansDirectDependencies := make([][]string, len(scans))
+	scansErrors := runConcurrently(params.Threads(), len(scans), func(index int) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		log.Info("Running SCA scan for", scans[index].Technology, "vulnerable dependencies in", scans[index].WorkingDirectory, "directory...")
+		var importedTree *DependencyTreeResult
+		if importedTrees != nil {
+			importedTree = importedTrees[index]
+		}
+		directDependencies, scanErr := executeScaScan(ctx, serverDetails, params, scans[index], importedTree)
+		if scanErr != nil {
+			return scanErr
+		}
+		scansDirectDependencies[index] = directDependencies
+		return nil
+	})
+
+	for i, scan := range scans {
+		if scansErrors[i] != nil {
+			// The failed scan is kept in the results, so the error is reported with the project it belongs to.
+			var scanError *xrayutils.ScaScanError
+			if !errors.As(scansErrors[i], &scanError) {
+				// The scan didn't start.
+				scanError = xrayutils.NewScaScanError(xrayutils.DependencyTreePhase, scansErrors[i])
+			}
+			scan.ScanError = scanError
+			err = errors.Join(err, fmt.Errorf("audit command in '%s' failed:\n%s", scan.WorkingDirectory, scansErrors[i].Error()))
+		} else {
+			params.AppendDependenciesForApplicabilityScan(scansDirectDependencies[i])
 		}
 		// Add the scan to the results
 		results.ScaResults = append(results.ScaResults, *scan)
@@ -69,11 +104,43 @@ func runScaScan(params *AuditParams, results *xrayutils.Results) (err error) {
 	return
 }
 
+// Runs the task for each index up to tasksCount, with up to threads tasks running concurrently.
+// Returns the errors of the tasks by their index.
+func runConcurrently(threads, tasksCount int, task func(index int) error) []error {
+	tasksErrors := make([]error, tasksCount)
+	producerConsumer := parallel.NewBounedRunner(max(threads, 1), false)
+	go func() {
+		defer producerConsumer.Done()
+		for i := 0; i < tasksCount; i++ {
+			getTask := func(index int) func(threadId int) error {
+				return func(threadId int) error {
+					tasksErrors[index] = task(index)
+					return nil
+				}
+			}
+			if _, addTaskErr := producerConsumer.AddTask(getTask(i)); addTaskErr != nil {
+				tasksErrors[i] = addTaskErr
+			}
+		}
+	}()
+	producerConsumer.Run()
+	return tasksErrors
+}
+
+// Returns the scans of the projects of the imported dependency trees, and their trees by the scan index.
+func getImportedScaScans(trees []ProjectDependencyTree) (scans []*xrayutils.ScaScanResult, scansTrees []*DependencyTreeResult) {
+	for i := range trees {
+		scans = append(scans, &xrayutils.ScaScanResult{Technology: trees[i].Technology, WorkingDirectory: trees[i].WorkingDirectory, Descriptors: trees[i].Descriptors})
+		scansTrees = append(scansTrees, &trees[i].DependencyTreeResult)
+	}
+	return
+}
+
 // Calculate the scans to preform
 func getScaScansToPreform(params *AuditParams) (scansToPreform []*xrayutils.ScaScanResult) {
 	for _, requestedDirectory := range params.workingDirs {
 		// Detect descriptors and technologies in the requested directory.
-		techToWorkingDirs, err := coreutils.DetectTechnologiesDescriptors(requestedDirectory, params.IsRecursiveScan(), params.Technologies(), getRequestedDescriptors(params), sca.GetExcludePattern(params.AuditBasicParams))
+		techToWorkingDirs, err := detectTechnologiesDescriptors(params, requestedDirectory)
 		if err != nil {
 			log.Warn("Couldn't detect technologies in", requestedDirectory, "directory.", err.Error())
 			continue
@@ -95,9 +162,132 @@ func getScaScansToPreform(params *AuditParams) (scansToPreform []*xrayutils.ScaS
 			}
 		}
 	}
+	// The technologies and working directories are detected in maps, sort the scans to keep the results order stable.
+	sort.SliceStable(scansToPreform, func(i, j int) bool {
+		if scansToPreform[i].WorkingDirectory != scansToPreform[j].WorkingDirectory {
+			return scansToPreform[i].WorkingDirectory < scansToPreform[j].WorkingDirectory
+		}
+		return scansToPreform[i].Technology < scansToPreform[j].Technology
+	})
+	scansToPreform = mergeWorkspacesScans(scansToPreform)
+	if params.changedFiles != nil {
+		scansToPreform = getScansAffectedByChanges(scansToPreform, params.changedFiles)
+	}
+	return
+}
+
+// The technologies that jfrog-cli-core doesn't detect, mapped to the functions that detect their projects in a directory.
+var auditDetectedTechnologies = map[coreutils.Technology]func(path string, recursive bool, excludePattern string) (map[string][]string, error){
+	xrayutils.Cargo:    cargo.DetectProjects,
+	xrayutils.Composer: composer.DetectProjects,
+}
+
+// Detects the technologies of jfrog-cli-core and the technologies that are detected by the audit, like Cargo and Composer, in the requested directory.
+func detectTechnologiesDescriptors(params *AuditParams, requestedDirectory string) (techToWorkingDirs map[coreutils.Technology]map[string][]string, err error) {
+	excludePattern := sca.GetExcludePattern(params.AuditBasicParams)
+	requestedTechs := params.Technologies()
+	var coreRequestedTechs []string
+	for _, tech := range requestedTechs {
+		if _, isAuditDetected := auditDetectedTechnologies[coreutils.Technology(tech)]; !isAuditDetected {
+			coreRequestedTechs = append(coreRequestedTechs, tech)
+		}
+	}
+	techToWorkingDirs = map[coreutils.Technology]map[string][]string{}
+	// No requested technologies means all the technologies, so the detection of jfrog-cli-core is skipped only when all the requested technologies are detected by the audit.
+	if len(requestedTechs) == 0 || len(coreRequestedTechs) > 0 {
+		if techToWorkingDirs, err = coreutils.DetectTechnologiesDescriptors(requestedDirectory, params.IsRecursiveScan(), coreRequestedTechs, getRequestedDescriptors(params), excludePattern); err != nil {
+			return
+		}
+	}
+	for tech, detectProjects := range auditDetectedTechnologies {
+		isRequested := slices.Contains(requestedTechs, tech.String())
+		if len(requestedTechs) > 0 && !isRequested {
+			continue
+		}
+		var projects map[string][]string
+		if projects, err = detectProjects(requestedDirectory, params.IsRecursiveScan(), excludePattern); err != nil {
+			return
+		}
+		if len(projects) == 0 && isRequested {
+			log.Warn(fmt.Sprintf("Requested technology %s but not found any indicators/descriptors in detection.", tech))
+		}
+		if len(projects) > 0 || isRequested {
+			techToWorkingDirs[tech] = projects
+		}
+	}
+	return
+}
+
+// Each package of an npm, Yarn or pnpm workspace is detected as a project, while the dependencies of the whole workspace are resolved from the workspace root.
+// The scans of the workspace packages are merged into the scan of the workspace root, which gets their descriptors.
+func mergeWorkspacesScans(scans []*xrayutils.ScaScanResult) []*xrayutils.ScaScanResult {
+	workspaceRootByMemberDir := map[string]*xrayutils.ScaScanResult{}
+	for _, scan := range scans {
+		if !isJavaScriptTechnology(scan.Technology) {
+			continue
+		}
+		members, err := npm.GetWorkspaceMembers(scan.WorkingDirectory)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Couldn't detect the workspace packages in %s: %s", scan.WorkingDirectory, err.Error()))
+			continue
+		}
+		for _, member := range members {
+			workspaceRootByMemberDir[member.Dir] = scan
+			if descriptor := filepath.Join(member.Dir, "package.json"); !slices.Contains(scan.Descriptors, descriptor) {
+				scan.Descriptors = append(scan.Descriptors, descriptor)
+			}
+		}
+	}
+	if len(workspaceRootByMemberDir) == 0 {
+		return scans
+	}
+	var mergedScans []*xrayutils.ScaScanResult
+	for _, scan := range scans {
+		if workspaceRoot, isMember := workspaceRootByMemberDir[scan.WorkingDirectory]; isMember && isJavaScriptTechnology(scan.Technology) {
+			log.Debug(fmt.Sprintf("The %s project in %s is a package of the workspace in %s, it is scanned as part of the workspace", scan.Technology, scan.WorkingDirectory, workspaceRoot.WorkingDirectory))
+			continue
+		}
+		mergedScans = append(mergedScans, scan)
+	}
+	return mergedScans
+}
+
+func isJavaScriptTechnology(tech coreutils.Technology) bool {
+	return tech == coreutils.Npm || tech == coreutils.Yarn || tech == coreutils.Pnpm
+}
+
+// The files that affect the resolved dependencies of a project in addition to its descriptors, when they are in the working directory of the project.
+var dependencyResolutionFiles = []string{
+	"package-lock.json", "npm-shrinkwrap.json", ".npmrc", "yarn.lock", ".yarnrc", ".yarnrc.yml", "pnpm-lock.yaml", "pnpm-workspace.yaml",
+	"go.sum", "Pipfile.lock", "poetry.lock", "Cargo.lock", "composer.lock", "settings.gradle", "settings.gradle.kts", "gradle.properties",
+	"packages.config", "packages.lock.json", "Directory.Packages.props", "Directory.Build.props", "nuget.config", "NuGet.Config",
+}
+
+// Returns the scans of the projects whose dependencies may be affected by the changed files: a descriptor of the project or another dependency resolution file in its working directory changed.
+// Changes to the source files don't change the dependencies, so they don't require an SCA scan.
+func getScansAffectedByChanges(scans []*xrayutils.ScaScanResult, changedFiles []string) (affectedScans []*xrayutils.ScaScanResult) {
+	for _, scan := range scans {
+		if isScanAffectedByChanges(scan, changedFiles) {
+			affectedScans = append(affectedScans, scan)
+			continue
+		}
+		log.Info(fmt.Sprintf("Skipping the %s SCA scan in %s, its dependencies didn't change", scan.Technology, scan.WorkingDirectory))
+	}
 	return
 }
 
+func isScanAffectedByChanges(scan *xrayutils.ScaScanResult, changedFiles []string) bool {
+	for _, changedFile := range changedFiles {
+		if slices.Contains(scan.Descriptors, changedFile) {
+			return true
+		}
+		if filepath.Dir(changedFile) == filepath.Clean(scan.WorkingDirectory) && slices.Contains(dependencyResolutionFiles, filepath.Base(changedFile)) {
+			return true
+		}
+	}
+	return false
+}
+
 func getRequestedDescriptors(params *AuditParams) map[coreutils.Technology][]string {
 	requestedDescriptors := map[coreutils.Technology][]string{}
 	if params.PipRequirementsFile() != "" {
@@ -106,39 +296,49 @@ func getRequestedDescriptors(params *AuditParams) map[coreutils.Technology][]str
 	return requestedDescriptors
 }
 
-// Preform the SCA scan for the given scan information.
-// This method will change the working directory to the scan's working directory.
-func executeScaScan(serverDetails *config.ServerDetails, params *AuditParams, scan *xrayutils.ScaScanResult) (err error) {
-	// Get the dependency tree for the technology in the working directory.
-	if err = os.Chdir(scan.WorkingDirectory); err != nil {
-		return errorutils.CheckError(err)
-	}
-	treeResult, techErr := GetTechDependencyTree(params.AuditBasicParams, scan.Technology)
-	if techErr != nil {
-		return fmt.Errorf("failed while building '%s' dependency tree:\n%s", scan.Technology, techErr.Error())
+// Preform the SCA scan for the given scan information. The dependency tree is built, unless an imported tree is given.
+// Returns the direct dependencies of the scanned project, to be used in the applicability scan, or the error with the phase the scan failed in.
+func executeScaScan(ctx context.Context, serverDetails *config.ServerDetails, params *AuditParams, scan *xrayutils.ScaScanResult, importedTree *DependencyTreeResult) (dependenciesForApplicabilityScan []string, err error) {
+	var treeResult DependencyTreeResult
+	if importedTree != nil {
+		treeResult = *importedTree
+	} else {
+		// The resolution repository is detected for each working directory, so each scan uses its own copy of the params.
+		scanParams := *params.AuditBasicParams
+		// Get the dependency tree for the technology in the working directory.
+		var techErr error
+		if treeResult, techErr = GetTechDependencyTree(ctx, &scanParams, scan.WorkingDirectory, scan.Technology); techErr != nil {
+			return nil, xrayutils.NewScaScanError(xrayutils.DependencyTreePhase, fmt.Errorf("failed while building '%s' dependency tree:\n%s", scan.Technology, techErr.Error()))
+		}
 	}
 	if treeResult.FlatTree == nil || len(treeResult.FlatTree.Nodes) == 0 {
-		return errorutils.CheckErrorf("no dependencies were found. Please try to build your project and re-run the audit command")
+		return nil, xrayutils.NewScaScanError(xrayutils.DependencyTreePhase, errorutils.CheckErrorf("no dependencies were found. Please try to build your project and re-run the audit command"))
 	}
 	// Scan the dependency tree.
-	scanResults, xrayErr := runScaWithTech(scan.Technology, params, serverDetails, treeResult.FlatTree, treeResult.FullDepTrees)
+	scanResults, xrayErr := runScaWithTech(ctx, scan.Technology, params, serverDetails, treeResult.FlatTree, treeResult.FullDepTrees)
 	if xrayErr != nil {
-		return fmt.Errorf("'%s' Xray dependency tree scan request failed:\n%s", scan.Technology, xrayErr.Error())
+		return nil, xrayutils.NewScaScanError(xrayutils.XrayScanPhase, fmt.Errorf("'%s' Xray dependency tree scan request failed:\n%s", scan.Technology, xrayErr.Error()))
 	}
 	scan.IsMultipleRootProject = clientutils.Pointer(len(treeResult.FullDepTrees) > 1)
-	addThirdPartyDependenciesToParams(params, scan.Technology, treeResult.FlatTree, treeResult.FullDepTrees)
+	scan.DependencyTrees = treeResult.FullDepTrees
 	scan.XrayResults = append(scan.XrayResults, scanResults...)
-	return
+	return getDependenciesForApplicabilityScan(params, scan.Technology, treeResult.FlatTree, treeResult.FullDepTrees), nil
 }
 
-func runScaWithTech(tech coreutils.Technology, params *AuditParams, serverDetails *config.ServerDetails, flatTree *xrayCmdUtils.GraphNode, fullDependencyTrees []*xrayCmdUtils.GraphNode) (techResults []services.ScanResponse, err error) {
+func runScaWithTech(ctx context.Context, tech coreutils.Technology, params *AuditParams, serverDetails *config.ServerDetails, flatTree *xrayCmdUtils.GraphNode, fullDependencyTrees []*xrayCmdUtils.GraphNode) (techResults []services.ScanResponse, err error) {
+	// The graph scan params are updated with the scanned graph and technology, so each scan uses its own copy.
+	xrayGraphScanParams := *params.xrayGraphScanParams
+	if xrayGraphScanParams.XscGitInfoContext != nil {
+		xscGitInfoContext := *xrayGraphScanParams.XscGitInfoContext
+		xrayGraphScanParams.XscGitInfoContext = &xscGitInfoContext
+	}
 	scanGraphParams := scangraph.NewScanGraphParams().
 		SetServerDetails(serverDetails).
-		SetXrayGraphScanParams(params.xrayGraphScanParams).
+		SetXrayGraphScanParams(&xrayGraphScanParams).
 		SetXrayVersion(params.xrayVersion).
 		SetFixableOnly(params.fixableOnly).
 		SetSeverityLevel(params.minSeverityFilter)
-	techResults, err = sca.RunXrayDependenciesTreeScanGraph(flatTree, params.Progress(), tech, scanGraphParams)
+	techResults, err = sca.RunXrayDependenciesTreeScanGraph(ctx, flatTree, params.Progress(), tech, scanGraphParams)
 	if err != nil {
 		return
 	}
@@ -146,14 +346,11 @@ func runScaWithTech(tech coreutils.Technology, params *AuditParams, serverDetail
 	return
 }
 
-func addThirdPartyDependenciesToParams(params *AuditParams, tech coreutils.Technology, flatTree *xrayCmdUtils.GraphNode, fullDependencyTrees []*xrayCmdUtils.GraphNode) {
-	var dependenciesForApplicabilityScan []string
+func getDependenciesForApplicabilityScan(params *AuditParams, tech coreutils.Technology, flatTree *xrayCmdUtils.GraphNode, fullDependencyTrees []*xrayCmdUtils.GraphNode) []string {
 	if shouldUseAllDependencies(params.thirdPartyApplicabilityScan, tech) {
-		dependenciesForApplicabilityScan = getDirectDependenciesFromTree([]*xrayCmdUtils.GraphNode{flatTree})
-	} else {
-		dependenciesForApplicabilityScan = getDirectDependenciesFromTree(fullDependencyTrees)
+		return getDirectDependenciesFromTree([]*xrayCmdUtils.GraphNode{flatTree})
 	}
-	params.AppendDependenciesForApplicabilityScan(dependenciesForApplicabilityScan)
+	return getDirectDependenciesFromTree(fullDependencyTrees)
 }
 
 // When building pip dependency tree using pipdeptree, some of the direct dependencies are recognized as transitive and missed by the CA scanner.
@@ -183,12 +380,22 @@ func getCurationCacheByTech(tech coreutils.Technology) (string, error) {
 }
 
 type DependencyTreeResult struct {
-	FlatTree     *xrayCmdUtils.GraphNode
-	FullDepTrees []*xrayCmdUtils.GraphNode
-	DownloadUrls map[string]string
+	FlatTree     *xrayCmdUtils.GraphNode   `json:"flatTree"`
+	FullDepTrees []*xrayCmdUtils.GraphNode `json:"fullDependencyTrees"`
+	DownloadUrls map[string]string         `json:"downloadUrls,omitempty"`
 }
 
-func GetTechDependencyTree(params xrayutils.AuditParams, tech coreutils.Technology) (depTreeResult DependencyTreeResult, err error) {
+// The dependency trees are built in the working directories of the projects, but some package managers are configured through the process environment variables,
+// and the helpers that configure Poetry and npm to resolve the dependencies from Artifactory work in the process working directory, which they change while they run.
+// These trees are built while holding the write lock, and all other trees are built while holding the read lock.
+var processStateLock sync.RWMutex
+
+// The package manager processes that are started by the audit are killed when the context is done.
+// Processes that are started by the jfrog-cli-core and build-info-go helpers can't be canceled, the context is checked once they complete.
+func GetTechDependencyTree(ctx context.Context, params xrayutils.AuditParams, workingDir string, tech coreutils.Technology) (depTreeResult DependencyTreeResult, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
 	logMessage := fmt.Sprintf("Calculating %s dependencies", tech.ToFormal())
 	curationLogMsg, curationCacheFolder, err := getCurationCacheFolderAndLogMsg(params, tech)
 	if err != nil {
@@ -201,7 +408,7 @@ func GetTechDependencyTree(params xrayutils.AuditParams, tech coreutils.Technolo
 		params.Progress().SetHeadlineMsg(logMessage)
 	}
 
-	err = SetResolutionRepoIfExists(params, tech)
+	err = SetResolutionRepoIfExists(params, workingDir, tech)
 	if err != nil {
 		return
 	}
@@ -209,13 +416,15 @@ func GetTechDependencyTree(params xrayutils.AuditParams, tech coreutils.Technolo
 	if err != nil {
 		return
 	}
+	defer lockProcessState(params, tech)()
 	var uniqueDeps []string
 	var uniqDepsWithTypes map[string][]string
 	startTime := time.Now()
 
 	switch tech {
 	case coreutils.Maven, coreutils.Gradle:
-		depTreeResult.FullDepTrees, uniqDepsWithTypes, err = java.BuildDependencyTree(java.DepTreeParams{
+		depTreeResult.FullDepTrees, uniqDepsWithTypes, err = java.BuildDependencyTree(ctx, java.DepTreeParams{
+			WorkingDir:              workingDir,
 			Server:                  serverDetails,
 			DepsRepo:                params.DepsRepo(),
 			IsMavenDepTreeInstalled: params.IsMavenDepTreeInstalled(),
@@ -224,16 +433,17 @@ func GetTechDependencyTree(params xrayutils.AuditParams, tech coreutils.Technolo
 			CurationCacheFolder:     curationCacheFolder,
 		}, tech)
 	case coreutils.Npm:
-		depTreeResult.FullDepTrees, uniqueDeps, err = npm.BuildDependencyTree(params)
+		depTreeResult.FullDepTrees, uniqueDeps, err = npm.BuildDependencyTree(params, workingDir)
 	case coreutils.Pnpm:
-		depTreeResult.FullDepTrees, uniqueDeps, err = pnpm.BuildDependencyTree(params)
+		depTreeResult.FullDepTrees, uniqueDeps, err = pnpm.BuildDependencyTree(params, workingDir)
 	case coreutils.Yarn:
-		depTreeResult.FullDepTrees, uniqueDeps, err = yarn.BuildDependencyTree(params)
+		depTreeResult.FullDepTrees, uniqueDeps, err = yarn.BuildDependencyTree(params, workingDir)
 	case coreutils.Go:
-		depTreeResult.FullDepTrees, uniqueDeps, err = _go.BuildDependencyTree(params)
+		depTreeResult.FullDepTrees, uniqueDeps, err = _go.BuildDependencyTree(params, workingDir)
 	case coreutils.Pipenv, coreutils.Pip, coreutils.Poetry:
 		depTreeResult.FullDepTrees, uniqueDeps,
-			depTreeResult.DownloadUrls, err = python.BuildDependencyTree(&python.AuditPython{
+			depTreeResult.DownloadUrls, err = python.BuildDependencyTree(ctx, &python.AuditPython{
+			WorkingDir:          workingDir,
 			Server:              serverDetails,
 			Tool:                pythonutils.PythonTool(tech),
 			RemotePypiRepo:      params.DepsRepo(),
@@ -241,10 +451,18 @@ func GetTechDependencyTree(params xrayutils.AuditParams, tech coreutils.Technolo
 			IsCurationCmd:       params.IsCurationCmd(),
 		})
 	case coreutils.Nuget:
-		depTreeResult.FullDepTrees, uniqueDeps, err = nuget.BuildDependencyTree(params)
+		depTreeResult.FullDepTrees, uniqueDeps, err = nuget.BuildDependencyTree(ctx, params, workingDir)
+	case xrayutils.Cargo:
+		depTreeResult.FullDepTrees, uniqueDeps, err = cargo.BuildDependencyTree(ctx, workingDir)
+	case xrayutils.Composer:
+		depTreeResult.FullDepTrees, uniqDepsWithTypes, err = composer.BuildDependencyTree(workingDir)
 	default:
 		err = errorutils.CheckErrorf("%s is currently not supported", string(tech))
 	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = errors.Join(ctxErr, err)
+		return
+	}
 	if err != nil || (len(uniqueDeps) == 0 && len(uniqDepsWithTypes) == 0) {
 		return
 	}
@@ -257,6 +475,28 @@ func GetTechDependencyTree(params xrayutils.AuditParams, tech coreutils.Technolo
 	return
 }
 
+// Locks the process state for building the dependency tree of the given technology. Returns a function that releases the lock.
+func lockProcessState(params xrayutils.AuditParams, tech coreutils.Technology) (unlock func()) {
+	if !isProcessStateModified(params, tech) {
+		processStateLock.RLock()
+		return processStateLock.RUnlock
+	}
+	processStateLock.Lock()
+	return processStateLock.Unlock
+}
+
+func isProcessStateModified(params xrayutils.AuditParams, tech coreutils.Technology) bool {
+	switch tech {
+	case coreutils.Pip:
+		// The virtual environment is added to the process PATH, where the dependencies are listed from.
+		return true
+	case coreutils.Poetry, coreutils.Npm, coreutils.Yarn, coreutils.Go:
+		// The Artifactory resolution server is configured through the process environment variables, or in the process working directory.
+		return params.DepsRepo() != ""
+	}
+	return false
+}
+
 func getCurationCacheFolderAndLogMsg(params xrayutils.AuditParams, tech coreutils.Technology) (logMessage string, curationCacheFolder string, err error) {
 	if !params.IsCurationCmd() {
 		return
@@ -290,11 +530,16 @@ var TechType = map[coreutils.Technology]project.ProjectType{
 }
 
 // Verifies the existence of depsRepo. If it doesn't exist, it searches for a configuration file based on the technology type. If found, it assigns depsRepo in the AuditParams.
-func SetResolutionRepoIfExists(params xrayutils.AuditParams, tech coreutils.Technology) (err error) {
+func SetResolutionRepoIfExists(params xrayutils.AuditParams, workingDir string, tech coreutils.Technology) (err error) {
 	if params.DepsRepo() != "" || params.IgnoreConfigFile() {
 		return
 	}
-	configFilePath, exists, err := project.GetProjectConfFilePath(TechType[tech])
+	projectType, exists := TechType[tech]
+	if !exists {
+		// The technology has no project configuration file to resolve its dependencies from Artifactory.
+		return
+	}
+	configFilePath, exists, err := GetProjectConfFilePath(workingDir, projectType.String())
 	if err != nil {
 		err = fmt.Errorf("failed while searching for %s.yaml config file: %s", tech.String(), err.Error())
 		return
@@ -303,7 +548,7 @@ func SetResolutionRepoIfExists(params xrayutils.AuditParams, tech coreutils.Tech
 		// Nuget and Dotnet are identified similarly in the detection process. To prevent redundancy, Dotnet is filtered out earlier in the process, focusing solely on detecting Nuget.
 		// Consequently, it becomes necessary to verify the presence of dotnet.yaml when Nuget detection occurs.
 		if tech == coreutils.Nuget {
-			configFilePath, exists, err = project.GetProjectConfFilePath(TechType[coreutils.Dotnet])
+			configFilePath, exists, err = GetProjectConfFilePath(workingDir, TechType[coreutils.Dotnet].String())
 			if err != nil {
 				err = fmt.Errorf("failed while searching for %s.yaml config file: %s", tech.String(), err.Error())
 				return
@@ -345,6 +590,39 @@ func SetResolutionRepoIfExists(params xrayutils.AuditParams, tech coreutils.Tech
 	return
 }
 
+// Returns the path of the configuration file with the given name (usually the project type), from the '.jfrog' directory of the working directory or one of its parents.
+// If the configuration file doesn't exist there, the global configuration file path is returned.
+// Unlike project.GetProjectConfFilePath, it doesn't depend on the process working directory.
+func GetProjectConfFilePath(workingDir, configName string) (confFilePath string, exists bool, err error) {
+	confFileName := filepath.Join("projects", configName+".yaml")
+	for dir := workingDir; ; dir = filepath.Dir(dir) {
+		var jfrogDirExists bool
+		if jfrogDirExists, err = fileutils.IsDirExists(filepath.Join(dir, ".jfrog"), false); err != nil {
+			return
+		}
+		if jfrogDirExists {
+			confFilePath = filepath.Join(dir, ".jfrog", confFileName)
+			if exists, err = fileutils.IsFileExists(confFilePath, false); err != nil || exists {
+				return
+			}
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	// If missing in the root project, check in the home dir
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return
+	}
+	confFilePath = filepath.Join(jfrogHomeDir, confFileName)
+	if exists, err = fileutils.IsFileExists(confFilePath, false); err != nil || !exists {
+		confFilePath = ""
+	}
+	return
+}
+
 func createFlatTreeWithTypes(uniqueDeps map[string][]string) (*xrayCmdUtils.GraphNode, error) {
 	if err := logDeps(uniqueDeps); err != nil {
 		return nil, err
@@ -66,8 +66,10 @@ func createTestDir(t *testing.T) (directory string, cleanUp func()) {
 	// ├── yarn
 	// │   ├── Pip
 	// │   └── Pipenv
-	// └── Nuget
-	//	   ├── Nuget-sub
+	// ├── Nuget
+	// │   ├── Nuget-sub
+	// ├── Cargo
+	// └── Composer
 
 	dir := createEmptyDir(t, filepath.Join(tmpDir, "dir"))
 	// Maven
@@ -100,6 +102,14 @@ func createTestDir(t *testing.T) (directory string, cleanUp func()) {
 	createEmptyFile(t, filepath.Join(nuget, "project.sln"))
 	nugetSub := createEmptyDir(t, filepath.Join(nuget, "Nuget-sub"))
 	createEmptyFile(t, filepath.Join(nugetSub, "project.csproj"))
+	// Cargo
+	cargo := createEmptyDir(t, filepath.Join(tmpDir, "Cargo"))
+	createEmptyFile(t, filepath.Join(cargo, "Cargo.toml"))
+	createEmptyFile(t, filepath.Join(cargo, "Cargo.lock"))
+	// Composer
+	composer := createEmptyDir(t, filepath.Join(tmpDir, "Composer"))
+	createEmptyFile(t, filepath.Join(composer, "composer.json"))
+	createEmptyFile(t, filepath.Join(composer, "composer.lock"))
 
 	return tmpDir, func() {
 		assert.NoError(t, fileutils.RemoveTempDir(tmpDir), "Couldn't removeAll: "+tmpDir)
@@ -205,6 +215,32 @@ func TestGetScaScansToPreform(t *testing.T) {
 					WorkingDirectory: filepath.Join(dir, "Nuget"),
 					Descriptors:      []string{filepath.Join(dir, "Nuget", "project.sln"), filepath.Join(dir, "Nuget", "Nuget-sub", "project.csproj")},
 				},
+				{
+					Technology:       xrayutils.Cargo,
+					WorkingDirectory: filepath.Join(dir, "Cargo"),
+					Descriptors:      []string{filepath.Join(dir, "Cargo", "Cargo.toml")},
+				},
+				{
+					Technology:       xrayutils.Composer,
+					WorkingDirectory: filepath.Join(dir, "Composer"),
+					Descriptors:      []string{filepath.Join(dir, "Composer", "composer.json")},
+				},
+			},
+		},
+		{
+			name: "Test Cargo only",
+			wd:   dir,
+			params: func() *AuditParams {
+				param := NewAuditParams().SetWorkingDirs([]string{dir})
+				param.SetTechnologies([]string{"cargo"}).SetIsRecursiveScan(true)
+				return param
+			},
+			expected: []*xrayutils.ScaScanResult{
+				{
+					Technology:       xrayutils.Cargo,
+					WorkingDirectory: filepath.Join(dir, "Cargo"),
+					Descriptors:      []string{filepath.Join(dir, "Cargo", "Cargo.toml")},
+				},
 			},
 		},
 	}
@@ -217,8 +253,118 @@ func TestGetScaScansToPreform(t *testing.T) {
 				sort.Strings(test.expected[i].Descriptors)
 			}
 			assert.ElementsMatch(t, test.expected, result)
+			// The scans are sorted to keep the results order stable.
+			assert.True(t, sort.SliceIsSorted(result, func(i, j int) bool {
+				if result[i].WorkingDirectory != result[j].WorkingDirectory {
+					return result[i].WorkingDirectory < result[j].WorkingDirectory
+				}
+				return result[i].Technology < result[j].Technology
+			}))
 		})
 	}
 
 	cleanUp()
 }
+
+func TestGetProjectConfFilePath(t *testing.T) {
+	projectDir := t.TempDir()
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	workingDir := filepath.Join(projectDir, "module", "sub-module")
+	assert.NoError(t, os.MkdirAll(workingDir, 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".jfrog", "projects"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", "projects", "npm.yaml"), []byte{}, 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(homeDir, "projects"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(homeDir, "projects", "go.yaml"), []byte{}, 0644))
+
+	// The configuration file is found in the project '.jfrog' directory.
+	confFilePath, exists, err := GetProjectConfFilePath(workingDir, TechType[coreutils.Npm].String())
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, filepath.Join(projectDir, ".jfrog", "projects", "npm.yaml"), confFilePath)
+
+	// The global configuration file is used if it doesn't exist in the project.
+	confFilePath, exists, err = GetProjectConfFilePath(workingDir, TechType[coreutils.Go].String())
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, filepath.Join(homeDir, "projects", "go.yaml"), confFilePath)
+
+	_, exists, err = GetProjectConfFilePath(workingDir, TechType[coreutils.Maven].String())
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestIsProcessStateModified(t *testing.T) {
+	tests := []struct {
+		tech     coreutils.Technology
+		depsRepo string
+		expected bool
+	}{
+		{tech: coreutils.Pip, expected: true},
+		{tech: coreutils.Pipenv},
+		{tech: coreutils.Pipenv, depsRepo: "pypi-remote"},
+		{tech: coreutils.Poetry},
+		{tech: coreutils.Poetry, depsRepo: "pypi-remote", expected: true},
+		{tech: coreutils.Npm},
+		{tech: coreutils.Npm, depsRepo: "npm-remote", expected: true},
+		{tech: coreutils.Yarn, depsRepo: "npm-remote", expected: true},
+		{tech: coreutils.Go, depsRepo: "go-remote", expected: true},
+		{tech: coreutils.Maven, depsRepo: "maven-remote"},
+	}
+	for _, test := range tests {
+		t.Run(test.tech.String()+"/"+test.depsRepo, func(t *testing.T) {
+			assert.Equal(t, test.expected, isProcessStateModified((&xrayutils.AuditBasicParams{}).SetDepsRepo(test.depsRepo), test.tech))
+		})
+	}
+}
+
+func TestGetScansAffectedByChanges(t *testing.T) {
+	root := filepath.Join("root", "project")
+	webDir, apiDir, docsDir := filepath.Join(root, "web"), filepath.Join(root, "api"), filepath.Join(root, "docs")
+	scans := []*xrayutils.ScaScanResult{
+		{Technology: coreutils.Npm, WorkingDirectory: webDir, Descriptors: []string{filepath.Join(webDir, "package.json")}},
+		{Technology: coreutils.Go, WorkingDirectory: apiDir, Descriptors: []string{filepath.Join(apiDir, "go.mod")}},
+		{Technology: coreutils.Pip, WorkingDirectory: docsDir, Descriptors: []string{filepath.Join(docsDir, "requirements.txt")}},
+	}
+	tests := []struct {
+		name          string
+		changedFiles  []string
+		expectedScans []*xrayutils.ScaScanResult
+	}{
+		{name: "no changes", changedFiles: []string{}, expectedScans: nil},
+		{name: "source files changed", changedFiles: []string{filepath.Join(root, "README.md"), filepath.Join(webDir, "index.js"), filepath.Join(apiDir, "main.go")}, expectedScans: nil},
+		{name: "descriptor changed", changedFiles: []string{filepath.Join(webDir, "package.json")}, expectedScans: scans[:1]},
+		{name: "lock file changed", changedFiles: []string{filepath.Join(apiDir, "go.sum"), filepath.Join(webDir, "nested", "go.sum")}, expectedScans: scans[1:2]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedScans, getScansAffectedByChanges(scans, test.changedFiles))
+		})
+	}
+}
+
+func TestMergeWorkspacesScans(t *testing.T) {
+	root := t.TempDir()
+	for dir, content := range map[string]string{
+		"":                                `{"name": "root", "workspaces": ["packages/*"]}`,
+		filepath.Join("packages", "a"):    `{"name": "a"}`,
+		filepath.Join("packages", "b"):    `{"name": "b"}`,
+		filepath.Join("other", "project"): `{"name": "project"}`,
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, dir, "package.json"), []byte(content), 0644))
+	}
+	aDir, bDir, otherDir := filepath.Join(root, "packages", "a"), filepath.Join(root, "packages", "b"), filepath.Join(root, "other", "project")
+	scans := []*xrayutils.ScaScanResult{
+		{Technology: coreutils.Yarn, WorkingDirectory: root, Descriptors: []string{filepath.Join(root, "package.json")}},
+		{Technology: coreutils.Npm, WorkingDirectory: aDir, Descriptors: []string{filepath.Join(aDir, "package.json")}},
+		{Technology: coreutils.Npm, WorkingDirectory: bDir, Descriptors: []string{filepath.Join(bDir, "package.json")}},
+		{Technology: coreutils.Npm, WorkingDirectory: otherDir, Descriptors: []string{filepath.Join(otherDir, "package.json")}},
+		{Technology: coreutils.Go, WorkingDirectory: aDir, Descriptors: []string{filepath.Join(aDir, "go.mod")}},
+	}
+	assert.Equal(t, []*xrayutils.ScaScanResult{
+		{Technology: coreutils.Yarn, WorkingDirectory: root, Descriptors: []string{filepath.Join(root, "package.json"), filepath.Join(aDir, "package.json"), filepath.Join(bDir, "package.json")}},
+		{Technology: coreutils.Npm, WorkingDirectory: otherDir, Descriptors: []string{filepath.Join(otherDir, "package.json")}},
+		{Technology: coreutils.Go, WorkingDirectory: aDir, Descriptors: []string{filepath.Join(aDir, "go.mod")}},
+	}, mergeWorkspacesScans(scans))
+}
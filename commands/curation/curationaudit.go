@@ -1,6 +1,7 @@
 package curation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	config "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-security/commands/audit"
+	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/cargo"
 	"github.com/jfrog/jfrog-cli-security/commands/audit/sca/python"
 	"github.com/jfrog/jfrog-cli-security/utils"
 	"github.com/jfrog/jfrog-client-go/artifactory"
@@ -65,6 +67,9 @@ var supportedTech = map[coreutils.Technology]func(ca *CurationAuditCommand) (boo
 	coreutils.Maven: func(ca *CurationAuditCommand) (bool, error) {
 		return ca.checkSupportByVersionOrEnv(coreutils.Maven, utils.CurationMavenSupport)
 	},
+	utils.Cargo: func(ca *CurationAuditCommand) (bool, error) {
+		return ca.checkSupportByVersionOrEnv(utils.Cargo, utils.CurationCargoSupport)
+	},
 }
 
 func (ca *CurationAuditCommand) checkSupportByVersionOrEnv(tech coreutils.Technology, envName string) (bool, error) {
@@ -231,7 +236,10 @@ func (ca *CurationAuditCommand) Run() (err error) {
 }
 
 func (ca *CurationAuditCommand) doCurateAudit(results map[string][]*PackageStatus) error {
-	techs := coreutils.DetectedTechnologiesList()
+	techs, err := detectTechnologiesList()
+	if err != nil {
+		return err
+	}
 	for _, tech := range techs {
 		supportedFunc, ok := supportedTech[coreutils.Technology(tech)]
 		if !ok {
@@ -254,6 +262,23 @@ func (ca *CurationAuditCommand) doCurateAudit(results map[string][]*PackageStatu
 	return nil
 }
 
+// Detects the technologies of the project in the working directory, including Cargo, which isn't detected by jfrog-cli-core.
+func detectTechnologiesList() ([]string, error) {
+	techs := coreutils.DetectedTechnologiesList()
+	workPath, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	cargoProjects, err := cargo.DetectProjects(workPath, false, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(cargoProjects) > 0 {
+		techs = append(techs, utils.Cargo.String())
+	}
+	return techs, nil
+}
+
 func (ca *CurationAuditCommand) getRtManagerAndAuth(tech coreutils.Technology) (rtManager artifactory.ArtifactoryServicesManager, serverDetails *config.ServerDetails, err error) {
 	if ca.PackageManagerConfig == nil {
 		if err = ca.SetRepo(tech); err != nil {
@@ -285,7 +310,11 @@ func (ca *CurationAuditCommand) getAuditParamsByTech(tech coreutils.Technology)
 }
 
 func (ca *CurationAuditCommand) auditTree(tech coreutils.Technology, results map[string][]*PackageStatus) error {
-	depTreeResult, err := audit.GetTechDependencyTree(ca.getAuditParamsByTech(tech), tech)
+	workPath, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	depTreeResult, err := audit.GetTechDependencyTree(context.Background(), ca.getAuditParamsByTech(tech), workPath, tech)
 	if err != nil {
 		return err
 	}
@@ -304,10 +333,6 @@ func (ca *CurationAuditCommand) auditTree(tech coreutils.Technology, results map
 	rootNode := depTreeResult.FullDepTrees[0]
 	_, projectName, projectScope, projectVersion := getUrlNameAndVersionByTech(tech, rootNode, nil, "", "")
 	if projectName == "" {
-		workPath, err := os.Getwd()
-		if err != nil {
-			return err
-		}
 		projectName = filepath.Base(workPath)
 	}
 
@@ -412,7 +437,7 @@ func (ca *CurationAuditCommand) CommandName() string {
 }
 
 func (ca *CurationAuditCommand) SetRepo(tech coreutils.Technology) error {
-	resolverParams, err := ca.getRepoParams(audit.TechType[tech])
+	resolverParams, err := ca.getRepoParams(tech)
 	if err != nil {
 		return err
 	}
@@ -420,14 +445,28 @@ func (ca *CurationAuditCommand) SetRepo(tech coreutils.Technology) error {
 	return nil
 }
 
-func (ca *CurationAuditCommand) getRepoParams(projectType project.ProjectType) (*project.RepositoryConfig, error) {
-	configFilePath, exists, err := project.GetProjectConfFilePath(projectType)
+func (ca *CurationAuditCommand) getRepoParams(tech coreutils.Technology) (*project.RepositoryConfig, error) {
+	workPath, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	projectType, hasProjectType := audit.TechType[tech]
+	configName := tech.String()
+	if hasProjectType {
+		configName = projectType.String()
+	}
+	configFilePath, exists, err := audit.GetProjectConfFilePath(workPath, configName)
 	if err != nil {
 		return nil, err
 	}
 	if !exists {
-		return nil, errorutils.CheckErrorf("no config file was found! Before running the " + projectType.String() + " command on a " +
-			"project for the first time, the project should be configured using the 'jf " + projectType.String() + "c' command")
+		if !hasProjectType {
+			// There is no config command for Cargo, so its resolver is read from a cargo.yaml file of the same format as the other package managers.
+			return nil, errorutils.CheckErrorf("no config file was found! Before running the curation audit on a " + configName + " project, " +
+				"the resolver repository and server ID should be configured in the '.jfrog/projects/" + configName + ".yaml' file")
+		}
+		return nil, errorutils.CheckErrorf("no config file was found! Before running the " + configName + " command on a " +
+			"project for the first time, the project should be configured using the 'jf " + configName + "c' command")
 	}
 	vConfig, err := project.ReadConfigFile(configFilePath, project.YAML)
 	if err != nil {
@@ -624,7 +663,8 @@ func getUrlNameAndVersionByTech(tech coreutils.Technology, node *xrayUtils.Graph
 	case coreutils.Pip:
 		downloadUrls, name, version = getPythonNameVersion(node.Id, downloadUrlsMap)
 		return
-
+	case utils.Cargo:
+		return getCargoNameAndVersion(node.Id, artiUrl, repo)
 	}
 	return
 }
@@ -698,6 +738,20 @@ func buildNpmDownloadUrl(url, repo, name, scope, version string) []string {
 	return []string{packageUrl}
 }
 
+// input - id: cargo://serde:1.0.197
+// input - repo: cargo-remote
+// output - downloadUrl: <arti-url>/api/cargo/cargo-remote/v1/crates/serde/1.0.197/download
+func getCargoNameAndVersion(id, artiUrl, repo string) (downloadUrls []string, name, scope, version string) {
+	id = strings.TrimPrefix(id, utils.Cargo.String()+"://")
+	nameVersion := strings.Split(id, ":")
+	name = nameVersion[0]
+	if len(nameVersion) > 1 {
+		version = nameVersion[1]
+	}
+	downloadUrls = []string{fmt.Sprintf("%s/api/cargo/%s/v1/crates/%s/%s/download", strings.TrimSuffix(artiUrl, "/"), repo, name, version)}
+	return
+}
+
 func DetectNumOfThreads(threadsCount int) (int, error) {
 	if threadsCount > TotalConcurrentRequests {
 		return 0, errorutils.CheckErrorf("number of threads crossed the maximum, the maximum threads allowed is %v", TotalConcurrentRequests)
@@ -172,6 +172,14 @@ func TestGetNameScopeAndVersion(t *testing.T) {
 	}
 }
 
+func TestGetCargoNameAndVersion(t *testing.T) {
+	downloadUrls, name, scope, version := getCargoNameAndVersion("cargo://serde:1.0.197", "http://localhost:8000/artifactory/", "cargo-remote")
+	assert.Equal(t, []string{"http://localhost:8000/artifactory/api/cargo/cargo-remote/v1/crates/serde/1.0.197/download"}, downloadUrls)
+	assert.Equal(t, "serde", name)
+	assert.Empty(t, scope)
+	assert.Equal(t, "1.0.197", version)
+}
+
 func TestTreeAnalyzerFillGraphRelations(t *testing.T) {
 	tests := getTestCasesForFillGraphRelations()
 	for _, tt := range tests {
@@ -415,6 +423,8 @@ func TestDoCurationAudit(t *testing.T) {
 			defer callbackMaven()
 			callbackPip := clienttestutils.SetEnvWithCallbackAndAssert(t, utils.CurationPipSupport, "true")
 			defer callbackPip()
+			callbackCargo := clienttestutils.SetEnvWithCallbackAndAssert(t, utils.CurationCargoSupport, "true")
+			defer callbackCargo()
 			mockServer, config := curationServer(t, tt.expectedBuildRequest, tt.expectedRequest, tt.requestToFail, tt.requestToError, tt.serveResources)
 			defer mockServer.Close()
 			configFilePath := WriteServerDetailsConfigFileBytes(t, config.ArtifactoryUrl, configurationDir)
@@ -579,6 +589,38 @@ func getTestCasesForDoCurationAudit() []testCase {
 			requestToError: nil,
 			expectedError:  "",
 		},
+		{
+			name:       "cargo tree - one blocked package",
+			pathToTest: filepath.Join(TestDataDir, "projects", "package-managers", "cargo", "cargo-curation", ".jfrog"),
+			expectedRequest: map[string]bool{
+				"/api/cargo/cargo-remote/v1/crates/cfg-if/1.0.3/download": false,
+				"/api/cargo/cargo-remote/v1/crates/itoa/1.0.15/download":  false,
+			},
+			requestToFail: map[string]bool{
+				"/api/cargo/cargo-remote/v1/crates/itoa/1.0.15/download": false,
+			},
+			expectedResp: map[string][]*PackageStatus{
+				"cargo-curation:0.1.0": {
+					{
+						Action:            "blocked",
+						ParentVersion:     "1.0.15",
+						ParentName:        "itoa",
+						BlockedPackageUrl: "/api/cargo/cargo-remote/v1/crates/itoa/1.0.15/download",
+						PackageName:       "itoa",
+						PackageVersion:    "1.0.15",
+						BlockingReason:    "Policy violations",
+						PkgType:           "cargo",
+						DepRelation:       "direct",
+						Policy: []Policy{
+							{
+								Policy:    "pol1",
+								Condition: "cond1",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:                   "npm tree - two blocked package ",
 			pathToTest:             filepath.Join(TestDataDir, "projects", "package-managers", "npm", "npm-project", ".jfrog"),
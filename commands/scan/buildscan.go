@@ -22,6 +22,7 @@ const (
 type BuildScanCommand struct {
 	serverDetails          *config.ServerDetails
 	outputFormat           outputFormat.OutputFormat
+	outputFiles            []xrutils.OutputFile
 	buildConfiguration     *build.BuildConfiguration
 	includeVulnerabilities bool
 	failBuild              bool
@@ -43,6 +44,11 @@ func (bsc *BuildScanCommand) SetOutputFormat(format outputFormat.OutputFormat) *
 	return bsc
 }
 
+func (bsc *BuildScanCommand) SetOutputFiles(outputFiles []xrutils.OutputFile) *BuildScanCommand {
+	bsc.outputFiles = outputFiles
+	return bsc
+}
+
 func (bsc *BuildScanCommand) ServerDetails() (*config.ServerDetails, error) {
 	return bsc.serverDetails, nil
 }
@@ -139,11 +145,14 @@ func (bsc *BuildScanCommand) runBuildScanAndPrintResults(xrayManager *xray.XrayS
 		SetIsMultipleRootProject(true).
 		SetPrintExtendedTable(bsc.printExtendedTable).
 		SetScanType(services.Binary).
-		SetExtraMessages(nil)
+		SetExtraMessages(nil).
+		SetOutputFiles(bsc.outputFiles)
 
 	if bsc.outputFormat != outputFormat.Table {
 		// Print the violations and/or vulnerabilities as part of one JSON.
-		err = resultsPrinter.PrintScanResults()
+		if err = resultsPrinter.PrintScanResults(); err != nil {
+			return false, err
+		}
 	} else {
 		// Print two different tables for violations and vulnerabilities (if needed)
 
@@ -160,6 +169,8 @@ func (bsc *BuildScanCommand) runBuildScanAndPrintResults(xrayManager *xray.XrayS
 			}
 		}
 	}
+	// The files are written once, after both tables are printed.
+	err = resultsPrinter.WriteScanResultsToFiles()
 	return
 }
 
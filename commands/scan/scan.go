@@ -2,6 +2,7 @@ package scan
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -48,12 +49,15 @@ type ScanCommand struct {
 	indexerPath            string
 	indexerTempDir         string
 	outputFormat           outputFormat.OutputFormat
+	outputFiles            []xrutils.OutputFile
+	baselineFile           string
 	projectKey             string
 	minSeverityFilter      string
 	watches                []string
 	includeVulnerabilities bool
 	includeLicenses        bool
 	fail                   bool
+	failConditions         []*xrutils.FailCondition
 	printExtendedTable     bool
 	bypassArchiveLimits    bool
 	fixableOnly            bool
@@ -84,6 +88,16 @@ func (scanCmd *ScanCommand) SetOutputFormat(format outputFormat.OutputFormat) *S
 	return scanCmd
 }
 
+func (scanCmd *ScanCommand) SetBaselineFile(baselineFile string) *ScanCommand {
+	scanCmd.baselineFile = baselineFile
+	return scanCmd
+}
+
+func (scanCmd *ScanCommand) SetOutputFiles(outputFiles []xrutils.OutputFile) *ScanCommand {
+	scanCmd.outputFiles = outputFiles
+	return scanCmd
+}
+
 func (scanCmd *ScanCommand) SetServerDetails(server *config.ServerDetails) *ScanCommand {
 	scanCmd.serverDetails = server
 	return scanCmd
@@ -123,6 +137,12 @@ func (scanCmd *ScanCommand) SetFail(fail bool) *ScanCommand {
 	return scanCmd
 }
 
+// The local conditions that fail the build if any finding matches them, regardless of the Xray watches.
+func (scanCmd *ScanCommand) SetFailConditions(failConditions []*xrutils.FailCondition) *ScanCommand {
+	scanCmd.failConditions = failConditions
+	return scanCmd
+}
+
 func (scanCmd *ScanCommand) SetPrintExtendedTable(printExtendedTable bool) *ScanCommand {
 	scanCmd.printExtendedTable = printExtendedTable
 	return scanCmd
@@ -172,6 +192,16 @@ func (scanCmd *ScanCommand) Run() (err error) {
 			}
 		}
 	}()
+	var baseline *xrutils.Baseline
+	if scanCmd.baselineFile != "" {
+		if baseline, err = xrutils.LoadBaseline(scanCmd.baselineFile); err != nil {
+			return err
+		}
+	}
+	suppressions, err := xrutils.LoadSuppressions(xrutils.SuppressionsFileName)
+	if err != nil {
+		return err
+	}
 	xrayManager, xrayVersion, err := xrutils.CreateXrayServiceManagerAndGetVersion(scanCmd.serverDetails)
 	if err != nil {
 		return err
@@ -249,15 +279,25 @@ func (scanCmd *ScanCommand) Run() (err error) {
 	scanResults := xrutils.NewAuditResults()
 	scanResults.XrayVersion = xrayVersion
 	scanResults.ScaResults = []xrutils.ScaScanResult{{XrayResults: flatResults}}
+	if suppressions != nil {
+		suppressions.SuppressFindings(scanResults)
+	}
+	if baseline != nil {
+		baseline.ExcludeExistingFindings(scanResults)
+	}
 
-	if err = xrutils.NewResultsWriter(scanResults).
+	resultsWriter := xrutils.NewResultsWriter(scanResults).
 		SetOutputFormat(scanCmd.outputFormat).
 		SetIncludeVulnerabilities(scanCmd.includeVulnerabilities).
 		SetIncludeLicenses(scanCmd.includeLicenses).
 		SetPrintExtendedTable(scanCmd.printExtendedTable).
 		SetIsMultipleRootProject(true).
 		SetScanType(services.Binary).
-		PrintScanResults(); err != nil {
+		SetOutputFiles(scanCmd.outputFiles)
+	if err = resultsWriter.PrintScanResults(); err != nil {
+		return
+	}
+	if err = resultsWriter.WriteScanResultsToFiles(); err != nil {
 		return
 	}
 
@@ -267,10 +307,13 @@ func (scanCmd *ScanCommand) Run() (err error) {
 	// If includeVulnerabilities is false it means that context was provided, so we need to check for build violations.
 	// If user provided --fail=false, don't fail the build.
 	if scanCmd.fail && !scanCmd.includeVulnerabilities {
-		if xrutils.CheckIfFailBuild(flatResults) {
+		if xrutils.CheckIfFailBuild(scanResults.GetScaScansXrayResults()) {
 			return xrutils.NewFailBuildError()
 		}
 	}
+	if err = xrutils.CheckFailConditions(scanResults, scanCmd.failConditions); err != nil {
+		return err
+	}
 	if len(scanErrors) > 0 {
 		return errorutils.CheckErrorf(scanErrors[0].ErrorMessage)
 	}
@@ -349,7 +392,7 @@ func (scanCmd *ScanCommand) createIndexerHandlerFunc(file *spec.File, indexedFil
 				if err != nil {
 					return err
 				}
-				scanResults, err := scangraph.RunScanGraphAndGetResults(scanGraphParams, xrayManager)
+				scanResults, err := scangraph.RunScanGraphAndGetResults(context.Background(), scanGraphParams, xrayManager)
 				if err != nil {
 					log.Error(fmt.Sprintf("scanning '%s' failed with error: %s", graph.Id, err.Error()))
 					indexedFileErrors[threadId] = append(indexedFileErrors[threadId], formats.SimpleJsonError{FilePath: filePath, ErrorMessage: err.Error()})
@@ -140,6 +140,18 @@ func ConvertToOperationalRiskViolationScanTableRow(rows []OperationalRiskViolati
 	return
 }
 
+func ConvertToScaScanErrorTableRow(rows []SimpleJsonError) (tableRows []scaScanErrorTableRow) {
+	for i := range rows {
+		tableRows = append(tableRows, scaScanErrorTableRow{
+			technology:       rows[i].Technology,
+			workingDirectory: rows[i].FilePath,
+			phase:            rows[i].Phase,
+			errorMessage:     rows[i].ErrorMessage,
+		})
+	}
+	return
+}
+
 func ConvertToSecretsTableRow(rows []SourceCodeRow) (tableRows []secretsTableRow) {
 	for i := range rows {
 		tableRows = append(tableRows, secretsTableRow{
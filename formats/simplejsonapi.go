@@ -17,6 +17,10 @@ type SimpleJsonResults struct {
 	Sast                      []SourceCodeRow               `json:"sastViolations"`
 	Errors                    []SimpleJsonError             `json:"errors"`
 	MultiScanId               string                        `json:"multiScanId,omitempty"`
+	// The findings that were suppressed by the rules of the suppressions file, and excluded from the rest of the results.
+	Suppressed []SuppressedFindingRow `json:"suppressed,omitempty"`
+	// The findings that exist in the baseline of the scan, and are excluded from the rest of the results.
+	Existing []ExistingFindingRow `json:"existing,omitempty"`
 }
 
 type SeverityDetails struct {
@@ -70,6 +74,8 @@ type SourceCodeRow struct {
 	Finding            string       `json:"finding,omitempty"`
 	ScannerDescription string       `json:"scannerDescription,omitempty"`
 	CodeFlow           [][]Location `json:"codeFlow,omitempty"`
+	// Identifies the finding across scans, even if its line changes. Equals to the SARIF result's partial fingerprint.
+	Fingerprint string `json:"fingerprint,omitempty"`
 }
 
 type Location struct {
@@ -84,6 +90,9 @@ type Location struct {
 type ComponentRow struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
+	// Set only for the direct dependencies of an issue. Identifies the issue that is introduced by this direct dependency across scans,
+	// and equals to the SARIF result's partial fingerprint.
+	Fingerprint string `json:"fingerprint,omitempty"`
 }
 
 type CveRow struct {
@@ -107,6 +116,38 @@ type Evidence struct {
 type SimpleJsonError struct {
 	FilePath     string `json:"filePath"`
 	ErrorMessage string `json:"errorMessage"`
+	// Set for the errors of the SCA scans of the audit command.
+	Technology string `json:"technology,omitempty"`
+	Phase      string `json:"phase,omitempty"`
+}
+
+type SuppressedFindingRow struct {
+	// One of: vulnerability, violation, secret, iac or sast.
+	Type string `json:"type"`
+	// Set for the SCA findings.
+	IssueId                   string `json:"issueId,omitempty"`
+	ImpactedDependencyName    string `json:"impactedPackageName,omitempty"`
+	ImpactedDependencyVersion string `json:"impactedPackageVersion,omitempty"`
+	// Set for the secrets, IaC and SAST findings.
+	File   string `json:"file,omitempty"`
+	RuleId string `json:"ruleId,omitempty"`
+	// The reason and the expiry date of the suppression rule.
+	Reason  string `json:"reason"`
+	Expires string `json:"expires,omitempty"`
+}
+
+type ExistingFindingRow struct {
+	// One of: vulnerability, violation, secret, iac or sast.
+	Type string `json:"type"`
+	// Set for the SCA findings.
+	IssueId                   string `json:"issueId,omitempty"`
+	ImpactedDependencyName    string `json:"impactedPackageName,omitempty"`
+	ImpactedDependencyVersion string `json:"impactedPackageVersion,omitempty"`
+	// Set for the secrets, IaC and SAST findings.
+	File   string `json:"file,omitempty"`
+	RuleId string `json:"ruleId,omitempty"`
+	// The fingerprint that matched the baseline, so the results can be used as the baseline of the next scans.
+	Fingerprint string `json:"fingerprint"`
 }
 
 type JfrogResearchInformation struct {
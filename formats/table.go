@@ -122,6 +122,13 @@ type cveTableRow struct {
 	cvssV3 string `col-name:"CVSS\nv3" extended:"true"`
 }
 
+type scaScanErrorTableRow struct {
+	technology       string `col-name:"Technology"`
+	workingDirectory string `col-name:"Working\nDirectory"`
+	phase            string `col-name:"Failed\nPhase"`
+	errorMessage     string `col-name:"Error"`
+}
+
 type secretsTableRow struct {
 	severity   string `col-name:"Severity"`
 	file       string `col-name:"File"`
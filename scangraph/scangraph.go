@@ -1,8 +1,12 @@
 package scangraph
 
 import (
+	"context"
+	"errors"
+
 	"github.com/jfrog/jfrog-cli-security/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/xray"
 	"github.com/jfrog/jfrog-client-go/xray/services"
 	"golang.org/x/text/cases"
@@ -14,22 +18,27 @@ const (
 	ScanTypeMinXrayVersion  = "3.37.2"
 )
 
-func RunScanGraphAndGetResults(params *ScanGraphParams, xrayManager *xray.XrayServicesManager) (*services.ScanResponse, error) {
+// Stops waiting for the scan results when the context is done.
+// The requests themselves are canceled only if the Xray manager was created with the same context.
+func RunScanGraphAndGetResults(ctx context.Context, params *ScanGraphParams, xrayManager *xray.XrayServicesManager) (*services.ScanResponse, error) {
 	err := clientutils.ValidateMinimumVersion(clientutils.Xray, params.xrayVersion, ScanTypeMinXrayVersion)
 	if err != nil {
 		// Remove scan type param if Xray version is under the minimum supported version
 		params.xrayGraphScanParams.ScanType = ""
 	}
+	if err = ctx.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
 
 	scanId, err := xrayManager.ScanGraph(*params.xrayGraphScanParams)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(ctx.Err(), err)
 	}
 
 	xscEnabled := params.xrayGraphScanParams.XscVersion != ""
 	scanResult, err := xrayManager.GetScanGraphResults(scanId, params.XrayGraphScanParams().IncludeVulnerabilities, params.XrayGraphScanParams().IncludeLicenses, xscEnabled)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(ctx.Err(), err)
 	}
 	return filterResultIfNeeded(scanResult, params), nil
 }
@@ -0,0 +1,3 @@
+pub fn is_match(text: &str) -> bool {
+    regex::Regex::new("^[a-z]+$").unwrap().is_match(text)
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -86,11 +87,12 @@ type AnalyzerManager struct {
 	MultiScanId             string
 }
 
-func (am *AnalyzerManager) Exec(configFile, scanCommand, workingDir string, serverDetails *config.ServerDetails) (err error) {
-	return am.ExecWithOutputFile(configFile, scanCommand, workingDir, "", serverDetails)
+func (am *AnalyzerManager) Exec(ctx context.Context, configFile, scanCommand, workingDir string, serverDetails *config.ServerDetails) (err error) {
+	return am.ExecWithOutputFile(ctx, configFile, scanCommand, workingDir, "", serverDetails)
 }
 
-func (am *AnalyzerManager) ExecWithOutputFile(configFile, scanCommand, workingDir, outputFile string, serverDetails *config.ServerDetails) (err error) {
+// Runs the analyzer manager. The process is killed if the context is done before it completes.
+func (am *AnalyzerManager) ExecWithOutputFile(ctx context.Context, configFile, scanCommand, workingDir, outputFile string, serverDetails *config.ServerDetails) (err error) {
 	if err = SetAnalyzerManagerEnvVariables(serverDetails); err != nil {
 		return
 	}
@@ -98,13 +100,14 @@ func (am *AnalyzerManager) ExecWithOutputFile(configFile, scanCommand, workingDi
 	multiScanId := os.Getenv(JfMsiEnvVariable)
 	if len(outputFile) > 0 {
 		log.Debug("Executing", am.AnalyzerManagerFullPath, scanCommand, configFile, outputFile, multiScanId)
-		cmd = exec.Command(am.AnalyzerManagerFullPath, scanCommand, configFile, outputFile)
+		cmd = exec.CommandContext(ctx, am.AnalyzerManagerFullPath, scanCommand, configFile, outputFile)
 	} else {
 		log.Debug("Executing", am.AnalyzerManagerFullPath, scanCommand, configFile, multiScanId)
-		cmd = exec.Command(am.AnalyzerManagerFullPath, scanCommand, configFile)
+		cmd = exec.CommandContext(ctx, am.AnalyzerManagerFullPath, scanCommand, configFile)
 	}
 	defer func() {
-		if cmd.ProcessState != nil && !cmd.ProcessState.Exited() {
+		// A process that was stopped by the context was already killed.
+		if cmd.ProcessState != nil && !cmd.ProcessState.Exited() && ctx.Err() == nil {
 			if killProcessError := cmd.Process.Kill(); errorutils.CheckError(killProcessError) != nil {
 				err = errors.Join(err, killProcessError)
 			}
@@ -112,6 +115,9 @@ func (am *AnalyzerManager) ExecWithOutputFile(configFile, scanCommand, workingDi
 	}()
 	cmd.Dir = workingDir
 	output, err := cmd.CombinedOutput()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return errorutils.CheckError(ctxErr)
+	}
 	if isCI() || err != nil {
 		if len(output) > 0 {
 			log.Debug(fmt.Sprintf("%s %q output: %s", workingDir, strings.Join(cmd.Args, " "), string(output)))
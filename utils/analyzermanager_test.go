@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,3 +61,22 @@ func TestScanTypeErrorMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestExecWithOutputFileKilledOnTimeout(t *testing.T) {
+	if coreutils.IsWindows() {
+		t.Skip("The test uses the 'sleep' command as the analyzer manager executable")
+	}
+	// Restore the environment variables that are set for the analyzer manager.
+	for _, envVar := range []string{jfUserEnvVariable, jfPasswordEnvVariable, jfPlatformUrlEnvVariable, jfTokenEnvVariable} {
+		t.Setenv(envVar, "")
+	}
+	// Skip the creation of the analyzer manager logs directory.
+	t.Setenv(coreutils.CI, "true")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	analyzerManager := &AnalyzerManager{AnalyzerManagerFullPath: "sleep"}
+	start := time.Now()
+	err := analyzerManager.ExecWithOutputFile(ctx, "10", "10", t.TempDir(), "", &config.ServerDetails{Url: "https://jfrog.io/"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
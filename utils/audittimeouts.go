@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	totalTimeoutKey = "total"
+	scaTimeoutKey   = "sca"
+	jasTimeoutKey   = "jas"
+)
+
+// AuditTimeouts limits the duration of the audit and of its phases. A zero duration means no limit.
+type AuditTimeouts struct {
+	Total time.Duration
+	// Building the dependency trees and scanning them with Xray.
+	Sca time.Duration
+	// Running the JFrog Advanced Security scanners.
+	Jas time.Duration
+}
+
+// ParseAuditTimeouts parses a duration that limits the whole audit, for example: '30m',
+// or a comma-separated list of <phase>=<duration> pairs, for example: 'total=30m,sca=10m,jas=20m'.
+func ParseAuditTimeouts(timeoutFlagVal string) (timeouts AuditTimeouts, err error) {
+	if strings.TrimSpace(timeoutFlagVal) == "" {
+		return
+	}
+	if !strings.Contains(timeoutFlagVal, "=") {
+		timeouts.Total, err = parseTimeout(timeoutFlagVal)
+		return
+	}
+	for _, timeoutVal := range strings.Split(timeoutFlagVal, ",") {
+		phase, durationVal, _ := strings.Cut(strings.TrimSpace(timeoutVal), "=")
+		var duration time.Duration
+		if duration, err = parseTimeout(durationVal); err != nil {
+			return
+		}
+		switch strings.ToLower(strings.TrimSpace(phase)) {
+		case totalTimeoutKey:
+			timeouts.Total = duration
+		case scaTimeoutKey:
+			timeouts.Sca = duration
+		case jasTimeoutKey:
+			timeouts.Jas = duration
+		default:
+			return AuditTimeouts{}, errorutils.CheckErrorf("invalid timeout '%s', the supported phases are: %s, %s and %s", timeoutVal, totalTimeoutKey, scaTimeoutKey, jasTimeoutKey)
+		}
+	}
+	return
+}
+
+func parseTimeout(durationVal string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(durationVal))
+	if err != nil || duration < 0 {
+		return 0, errorutils.CheckErrorf("invalid timeout duration '%s', expected a duration such as '90s' or '10m', or 0 for no timeout", durationVal)
+	}
+	return duration, nil
+}
+
+// WithTimeout returns a context that is done when the timeout of the phase expires, or when the parent context is done.
+// The cause of the returned context describes the expired timeout. A zero timeout means no limit.
+func WithTimeout(ctx context.Context, timeout time.Duration, phase string) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, timeout, fmt.Errorf("the %s didn't complete within %s: %w", phase, timeout, context.DeadlineExceeded))
+}
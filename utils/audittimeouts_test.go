@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAuditTimeouts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		flagValue        string
+		expectedTimeouts AuditTimeouts
+		expectedError    bool
+	}{
+		{name: "Empty value", flagValue: ""},
+		{name: "Total timeout", flagValue: "30m", expectedTimeouts: AuditTimeouts{Total: 30 * time.Minute}},
+		{name: "Phases timeouts", flagValue: " total=1h, SCA=10m,jas=90s", expectedTimeouts: AuditTimeouts{Total: time.Hour, Sca: 10 * time.Minute, Jas: 90 * time.Second}},
+		{name: "Unknown phase", flagValue: "sca=10m,sast=5m", expectedError: true},
+		{name: "Invalid duration", flagValue: "sca=10", expectedError: true},
+		{name: "Negative duration", flagValue: "-5m", expectedError: true},
+		{name: "Zero duration means no timeout", flagValue: "total=0,sca=10m", expectedTimeouts: AuditTimeouts{Sca: 10 * time.Minute}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeouts, err := ParseAuditTimeouts(tc.flagValue)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTimeouts, timeouts)
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), 0, "SCA scan")
+	assert.NoError(t, ctx.Err())
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	ctx, cancel = WithTimeout(context.Background(), time.Millisecond, "SCA scan")
+	defer cancel()
+	<-ctx.Done()
+	assert.True(t, errors.Is(context.Cause(ctx), context.DeadlineExceeded))
+	assert.ErrorContains(t, context.Cause(ctx), "the SCA scan didn't complete within 1ms")
+}
@@ -0,0 +1,263 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"golang.org/x/exp/maps"
+)
+
+// Baseline holds the fingerprints of the findings of a previous scan, so that only new findings are reported.
+// The findings are compared by the fingerprints that are written to the simple-json and sarif formats.
+type Baseline struct {
+	fingerprints *datastructures.Set[string]
+}
+
+// LoadBaseline reads the results of a previous scan, in the simple-json or sarif format.
+func LoadBaseline(filePath string) (*Baseline, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(content, &fields); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the baseline file '%s', expected the simple-json or sarif format: %s", filePath, err.Error())
+	}
+	baseline := &Baseline{fingerprints: datastructures.MakeSet[string]()}
+	if _, isSarif := fields["runs"]; isSarif {
+		err = baseline.addSarifFingerprints(content)
+	} else {
+		err = baseline.addSimpleJsonFingerprints(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if baseline.fingerprints.Size() == 0 {
+		log.Warn(fmt.Sprintf("No fingerprints were found in the baseline file '%s'. All the findings will be reported as new.", filePath))
+	}
+	return baseline, nil
+}
+
+func (b *Baseline) addSarifFingerprints(content []byte) error {
+	report, err := sarif.FromBytes(content)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, run := range report.Runs {
+		for _, result := range run.Results {
+			if fingerprint, ok := result.PartialFingerprints[JfrogFingerprintKey].(string); ok {
+				b.fingerprints.Add(fingerprint)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Baseline) addSimpleJsonFingerprints(content []byte) error {
+	var jsonTable formats.SimpleJsonResults
+	if err := json.Unmarshal(content, &jsonTable); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, issue := range append(jsonTable.Vulnerabilities, jsonTable.SecurityViolations...) {
+		b.addComponentsFingerprints(issue.Components)
+	}
+	for _, license := range jsonTable.LicensesViolations {
+		b.addComponentsFingerprints(license.Components)
+	}
+	for _, finding := range append(append(jsonTable.Secrets, jsonTable.Iacs...), jsonTable.Sast...) {
+		if finding.Fingerprint != "" {
+			b.fingerprints.Add(finding.Fingerprint)
+		}
+	}
+	// The findings that were already existing in the scan of the baseline are existing in the next scans as well.
+	for _, finding := range jsonTable.Existing {
+		if finding.Fingerprint != "" {
+			b.fingerprints.Add(finding.Fingerprint)
+		}
+	}
+	return nil
+}
+
+func (b *Baseline) addComponentsFingerprints(components []formats.ComponentRow) {
+	for _, component := range components {
+		if component.Fingerprint != "" {
+			b.fingerprints.Add(component.Fingerprint)
+		}
+	}
+}
+
+// The findings that exist in the baseline, and were excluded from the rest of the results.
+type ExistingFindings struct {
+	// The existing findings, in the structure of the scan results.
+	Results *Results
+	Rows    []formats.ExistingFindingRow
+}
+
+// ExcludeExistingFindings moves the findings that exist in the baseline from the results to results.Existing, and returns their count.
+// SCA issues are compared per direct dependency, so an issue that is introduced by a new direct dependency is kept, with the new impact paths only.
+// The Contextual Analysis results are kept, since they describe the SCA issues rather than new findings.
+func (b *Baseline) ExcludeExistingFindings(results *Results) (excluded int) {
+	existing := &ExistingFindings{Results: &Results{XrayVersion: results.XrayVersion, MultiScanId: results.MultiScanId}}
+	for i := range results.ScaResults {
+		scaResult := &results.ScaResults[i]
+		existingScaResult := *scaResult
+		existingScaResult.XrayResults = nil
+		for j := range scaResult.XrayResults {
+			xrayResult := &scaResult.XrayResults[j]
+			existingXrayResult := services.ScanResponse{ScanId: xrayResult.ScanId, XrayDataUrl: xrayResult.XrayDataUrl}
+			xrayResult.Vulnerabilities, existingXrayResult.Vulnerabilities = b.excludeExistingVulnerabilities(xrayResult.Vulnerabilities, existing)
+			xrayResult.Violations, existingXrayResult.Violations = b.excludeExistingViolations(xrayResult.Violations, existing)
+			if len(existingXrayResult.Vulnerabilities) > 0 || len(existingXrayResult.Violations) > 0 {
+				existingScaResult.XrayResults = append(existingScaResult.XrayResults, existingXrayResult)
+			}
+		}
+		if len(existingScaResult.XrayResults) > 0 {
+			existing.Results.ScaResults = append(existing.Results.ScaResults, existingScaResult)
+		}
+	}
+	extended := results.ExtendedScanResults
+	existing.Results.ExtendedScanResults = &ExtendedScanResults{ApplicabilityScanResults: extended.ApplicabilityScanResults, EntitledForJas: extended.EntitledForJas}
+	existingExtended := existing.Results.ExtendedScanResults
+	existingExtended.SecretsScanResults = b.excludeExistingSourceCodeFindings(extended.SecretsScanResults, "secret", existing)
+	existingExtended.IacScanResults = b.excludeExistingSourceCodeFindings(extended.IacScanResults, "iac", existing)
+	existingExtended.SastScanResults = b.excludeExistingSourceCodeFindings(extended.SastScanResults, "sast", existing)
+	// The results are marked as compared to the baseline even if no existing findings were found, so all their findings are reported as new.
+	results.Existing = existing
+	excluded = len(existing.Rows)
+	log.Info(fmt.Sprintf("%d existing findings that were found in the baseline are excluded from the results.", excluded))
+	return
+}
+
+func (b *Baseline) excludeExistingVulnerabilities(vulnerabilities []services.Vulnerability, existing *ExistingFindings) (kept, excluded []services.Vulnerability) {
+	for _, vulnerability := range vulnerabilities {
+		keptComponents, existingComponents := b.excludeExistingImpactPaths(vulnerability.Components, "vulnerability", GetIssueIdentifier(convertCves(vulnerability.Cves), vulnerability.IssueId), existing)
+		if len(existingComponents) > 0 {
+			existingVulnerability := vulnerability
+			existingVulnerability.Components = existingComponents
+			excluded = append(excluded, existingVulnerability)
+		}
+		if len(keptComponents) > 0 {
+			vulnerability.Components = keptComponents
+			kept = append(kept, vulnerability)
+		}
+	}
+	return
+}
+
+// Operational risk violations have no fingerprints, so they are always kept.
+func (b *Baseline) excludeExistingViolations(violations []services.Violation, existing *ExistingFindings) (kept, excluded []services.Violation) {
+	for _, violation := range violations {
+		issueId := ""
+		switch violation.ViolationType {
+		case "security":
+			issueId = GetIssueIdentifier(convertCves(violation.Cves), violation.IssueId)
+		case "license":
+			issueId = violation.LicenseKey
+		}
+		if issueId == "" {
+			kept = append(kept, violation)
+			continue
+		}
+		keptComponents, existingComponents := b.excludeExistingImpactPaths(violation.Components, "violation", issueId, existing)
+		if len(existingComponents) > 0 {
+			existingViolation := violation
+			existingViolation.Components = existingComponents
+			excluded = append(excluded, existingViolation)
+		}
+		if len(keptComponents) > 0 {
+			violation.Components = keptComponents
+			kept = append(kept, violation)
+		}
+	}
+	return
+}
+
+// Splits the impact paths of the components by whether their direct dependencies exist in the baseline.
+// Returns the components with the new impact paths, and the components with the existing impact paths.
+func (b *Baseline) excludeExistingImpactPaths(components map[string]services.Component, findingType, issueId string, existing *ExistingFindings) (kept, excluded map[string]services.Component) {
+	// The components are sorted to keep the order of the existing rows stable.
+	componentsIds := maps.Keys(components)
+	sort.Strings(componentsIds)
+	for _, componentId := range componentsIds {
+		component := components[componentId]
+		impactedDependencyName, impactedDependencyVersion, _ := SplitComponentId(componentId)
+		var newImpactPaths, existingImpactPaths [][]services.ImpactPathNode
+		existingFingerprints := datastructures.MakeSet[string]()
+		for _, impactPath := range component.ImpactPaths {
+			directDependencyName, _, _ := SplitComponentId(getDirectDependencyId(impactPath))
+			fingerprint := GetScaFingerprint(issueId, impactedDependencyName, directDependencyName)
+			if !b.fingerprints.Exists(fingerprint) {
+				newImpactPaths = append(newImpactPaths, impactPath)
+				continue
+			}
+			existingImpactPaths = append(existingImpactPaths, impactPath)
+			if existingFingerprints.Exists(fingerprint) {
+				continue
+			}
+			existingFingerprints.Add(fingerprint)
+			existing.Rows = append(existing.Rows, formats.ExistingFindingRow{
+				Type:                      findingType,
+				IssueId:                   issueId,
+				ImpactedDependencyName:    impactedDependencyName,
+				ImpactedDependencyVersion: impactedDependencyVersion,
+				Fingerprint:               fingerprint,
+			})
+		}
+		if len(existingImpactPaths) > 0 {
+			if excluded == nil {
+				excluded = map[string]services.Component{}
+			}
+			existingComponent := component
+			existingComponent.ImpactPaths = existingImpactPaths
+			excluded[componentId] = existingComponent
+		}
+		// Components without impact paths have no fingerprints, and can't be compared.
+		if len(newImpactPaths) > 0 || len(component.ImpactPaths) == 0 {
+			if kept == nil {
+				kept = map[string]services.Component{}
+			}
+			component.ImpactPaths = newImpactPaths
+			kept[componentId] = component
+		}
+	}
+	return
+}
+
+// Removes the existing results from the runs, and returns runs with the existing results, in the same order.
+func (b *Baseline) excludeExistingSourceCodeFindings(runs []*sarif.Run, findingType string, existing *ExistingFindings) (existingRuns []*sarif.Run) {
+	for _, run := range runs {
+		existingRun := *run
+		existingRun.Results = nil
+		var newResults []*sarif.Result
+		for _, result := range run.Results {
+			if len(result.Locations) == 0 {
+				newResults = append(newResults, result)
+				continue
+			}
+			file := GetRelativeLocationFileName(result.Locations[0], run.Invocations)
+			fingerprint := GetSourceCodeFingerprint(GetResultRuleId(result), file, GetLocationSnippet(result.Locations[0]))
+			if !b.fingerprints.Exists(fingerprint) {
+				newResults = append(newResults, result)
+				continue
+			}
+			existingRun.Results = append(existingRun.Results, result)
+			existing.Rows = append(existing.Rows, formats.ExistingFindingRow{
+				Type:        findingType,
+				File:        file,
+				RuleId:      GetResultRuleId(result),
+				Fingerprint: fingerprint,
+			})
+		}
+		run.Results = newResults
+		existingRuns = append(existingRuns, &existingRun)
+	}
+	return
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBaselineTestResults(directDependencies ...string) *Results {
+	var impactPaths [][]services.ImpactPathNode
+	for _, directDependency := range directDependencies {
+		impactPaths = append(impactPaths, []services.ImpactPathNode{{ComponentId: "npm://root:1.0.0"}, {ComponentId: directDependency}, {ComponentId: "npm://lodash:4.17.0"}})
+	}
+	results := NewAuditResults()
+	results.ExtendedScanResults.EntitledForJas = true
+	results.ScaResults = []ScaScanResult{{
+		Technology: coreutils.Npm,
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{{
+				IssueId:    "XRAY-1",
+				Severity:   "High",
+				Cves:       []services.Cve{{Id: "CVE-2024-1234"}},
+				Components: map[string]services.Component{"npm://lodash:4.17.0": {ImpactPaths: impactPaths}},
+			}},
+			Violations: []services.Violation{{
+				IssueId:       "XRAY-2",
+				ViolationType: "license",
+				LicenseKey:    "GPL-3.0",
+				Severity:      "Medium",
+				Components:    map[string]services.Component{"npm://lodash:4.17.0": {ImpactPaths: impactPaths}},
+			}},
+		}},
+	}}
+	invocation := sarif.NewInvocation().WithWorkingDirectory(sarif.NewSimpleArtifactLocation("wd"))
+	results.ExtendedScanResults.SecretsScanResults = []*sarif.Run{
+		CreateRunWithDummyResults(CreateResultWithOneLocation("file://wd/file", 1, 2, 3, 4, "secret-snippet", "secret-rule", "note")).WithInvocations([]*sarif.Invocation{invocation}),
+	}
+	return results
+}
+
+func TestBaselineExcludeExistingFindings(t *testing.T) {
+	testCases := []struct {
+		name         string
+		outputFormat format.OutputFormat
+	}{
+		{name: "simple-json baseline", outputFormat: format.SimpleJson},
+		{name: "sarif baseline", outputFormat: format.Sarif},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+			require.NoError(t, NewResultsWriter(createBaselineTestResults("npm://express:4.0.0")).
+				SetIncludeVulnerabilities(true).
+				SetIsMultipleRootProject(true).
+				SetOutputFiles([]OutputFile{{Format: tc.outputFormat, Path: baselinePath}}).
+				WriteScanResultsToFiles())
+			baseline, err := LoadBaseline(baselinePath)
+			require.NoError(t, err)
+
+			// The vulnerability is now also introduced by a new direct dependency, and a new secret is found.
+			results := createBaselineTestResults("npm://express:4.0.0", "npm://react:18.0.0")
+			secretsRun := results.ExtendedScanResults.SecretsScanResults[0]
+			secretsRun.Results = append(secretsRun.Results, CreateResultWithOneLocation("file://wd/file", 10, 2, 10, 4, "new-secret-snippet", "secret-rule", "note"))
+
+			// The existing vulnerability, license violation and secret are excluded.
+			assert.Equal(t, 3, baseline.ExcludeExistingFindings(results))
+			xrayResult := results.ScaResults[0].XrayResults[0]
+			require.Len(t, xrayResult.Vulnerabilities, 1)
+			impactPaths := xrayResult.Vulnerabilities[0].Components["npm://lodash:4.17.0"].ImpactPaths
+			require.Len(t, impactPaths, 1)
+			assert.Equal(t, "npm://react:18.0.0", impactPaths[0][1].ComponentId)
+			require.Len(t, xrayResult.Violations, 1)
+			assert.Len(t, xrayResult.Violations[0].Components["npm://lodash:4.17.0"].ImpactPaths, 1)
+			require.Len(t, secretsRun.Results, 1)
+			assert.Equal(t, "new-secret-snippet", GetLocationSnippet(secretsRun.Results[0].Locations[0]))
+
+			// The existing findings are kept aside, to be reported as existing.
+			require.NotNil(t, results.Existing)
+			require.Len(t, results.Existing.Rows, 3)
+			assert.Equal(t, []string{"vulnerability", "violation", "secret"}, []string{results.Existing.Rows[0].Type, results.Existing.Rows[1].Type, results.Existing.Rows[2].Type})
+			assert.Equal(t, GetScaFingerprint("CVE-2024-1234", "lodash", "express"), results.Existing.Rows[0].Fingerprint)
+			existingImpactPaths := results.Existing.Results.ScaResults[0].XrayResults[0].Vulnerabilities[0].Components["npm://lodash:4.17.0"].ImpactPaths
+			require.Len(t, existingImpactPaths, 1)
+			assert.Equal(t, "npm://express:4.0.0", existingImpactPaths[0][1].ComponentId)
+			require.Len(t, results.Existing.Results.ExtendedScanResults.SecretsScanResults[0].Results, 1)
+
+			// Scanning again with the same findings reports nothing new.
+			results = createBaselineTestResults("npm://express:4.0.0")
+			baseline.ExcludeExistingFindings(results)
+			assert.Empty(t, results.ScaResults[0].XrayResults[0].Vulnerabilities)
+			assert.Empty(t, results.ScaResults[0].XrayResults[0].Violations)
+			assert.Empty(t, results.ExtendedScanResults.SecretsScanResults[0].Results)
+		})
+	}
+}
+
+func TestWriteExistingFindings(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, NewResultsWriter(createBaselineTestResults("npm://express:4.0.0")).
+		SetIncludeVulnerabilities(true).
+		SetIsMultipleRootProject(true).
+		SetOutputFiles([]OutputFile{{Format: format.SimpleJson, Path: baselinePath}}).
+		WriteScanResultsToFiles())
+	baseline, err := LoadBaseline(baselinePath)
+	require.NoError(t, err)
+	results := createBaselineTestResults("npm://express:4.0.0", "npm://react:18.0.0")
+	assert.Equal(t, 3, baseline.ExcludeExistingFindings(results))
+	writer := NewResultsWriter(results).SetIncludeVulnerabilities(true).SetIsMultipleRootProject(true)
+
+	// The new findings are marked as new, and the existing findings are reported alongside them as unchanged.
+	report, err := GenereateSarifReportFromResults(results, true, false, nil)
+	require.NoError(t, err)
+	baselineStates := map[string]int{}
+	for _, run := range report.Runs {
+		for _, result := range run.Results {
+			require.NotNil(t, result.BaselineState)
+			baselineStates[*result.BaselineState]++
+		}
+	}
+	assert.Equal(t, map[string]int{newSarifBaselineState: 2, unchangedSarifBaselineState: 3}, baselineStates)
+
+	// The existing findings are listed in the simple-json format, out of the rest of the results.
+	jsonTable, err := writer.convertScanToSimpleJson()
+	require.NoError(t, err)
+	assert.Len(t, jsonTable.Vulnerabilities, 1)
+	assert.Empty(t, jsonTable.Secrets)
+	assert.Len(t, jsonTable.Existing, 3)
+
+	// Results that were compared to a baseline can be used as the baseline of the next scans, with their existing findings.
+	for _, outputFormat := range []format.OutputFormat{format.SimpleJson, format.Sarif} {
+		nextBaselinePath := filepath.Join(t.TempDir(), "next-baseline.json")
+		require.NoError(t, writer.SetOutputFiles([]OutputFile{{Format: outputFormat, Path: nextBaselinePath}}).WriteScanResultsToFiles())
+		nextBaseline, err := LoadBaseline(nextBaselinePath)
+		require.NoError(t, err)
+		nextResults := createBaselineTestResults("npm://express:4.0.0", "npm://react:18.0.0")
+		assert.Equal(t, 5, nextBaseline.ExcludeExistingFindings(nextResults), outputFormat)
+		assert.Empty(t, nextResults.ScaResults[0].XrayResults[0].Vulnerabilities, outputFormat)
+	}
+}
+
+func TestLoadBaselineInvalidFile(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.txt")
+	require.NoError(t, os.WriteFile(baselinePath, []byte("not a json"), 0644))
+	_, err := LoadBaseline(baselinePath)
+	assert.Error(t, err)
+	_, err = LoadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
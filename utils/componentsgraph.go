@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Holds the unique components of the scanned projects and the direct relationships between them.
+// Used by the SBOM output formats.
+type componentsGraph struct {
+	roots      *datastructures.Set[string]
+	components map[string]*datastructures.Set[string]
+}
+
+// Builds the components graph from the dependency trees of each scan.
+// Components that are not part of any tree (as in binary scans, where no trees are available) are added from the impact paths of the Xray issues.
+func buildComponentsGraph(results *Results) *componentsGraph {
+	graph := &componentsGraph{roots: datastructures.MakeSet[string](), components: map[string]*datastructures.Set[string]{}}
+	for _, scaResult := range results.ScaResults {
+		for _, tree := range scaResult.DependencyTrees {
+			if tree == nil {
+				continue
+			}
+			graph.roots.Add(tree.Id)
+			graph.addTree(tree)
+		}
+		// Without dependency trees, the scanned roots are the first components of the impact paths.
+		addRoots := len(scaResult.DependencyTrees) == 0
+		for _, xrayResult := range scaResult.XrayResults {
+			for _, vulnerability := range xrayResult.Vulnerabilities {
+				graph.addImpactPaths(vulnerability.Components, addRoots)
+			}
+			for _, violation := range xrayResult.Violations {
+				graph.addImpactPaths(violation.Components, addRoots)
+			}
+			for _, license := range xrayResult.Licenses {
+				graph.addImpactPaths(license.Components, addRoots)
+			}
+		}
+	}
+	return graph
+}
+
+func (g *componentsGraph) addComponent(componentId string) *datastructures.Set[string] {
+	if _, exists := g.components[componentId]; !exists {
+		g.components[componentId] = datastructures.MakeSet[string]()
+	}
+	return g.components[componentId]
+}
+
+func (g *componentsGraph) addTree(node *xrayUtils.GraphNode) {
+	dependsOn := g.addComponent(node.Id)
+	for _, child := range node.Nodes {
+		dependsOn.Add(child.Id)
+		g.addTree(child)
+	}
+}
+
+func (g *componentsGraph) addImpactPaths(components map[string]services.Component, addRoots bool) {
+	for componentId, component := range components {
+		g.addComponent(componentId)
+		for _, impactPath := range component.ImpactPaths {
+			for i, pathNode := range impactPath {
+				dependsOn := g.addComponent(pathNode.ComponentId)
+				if i == 0 && addRoots {
+					g.roots.Add(pathNode.ComponentId)
+				}
+				if i+1 < len(impactPath) {
+					dependsOn.Add(impactPath[i+1].ComponentId)
+				}
+			}
+		}
+	}
+}
+
+// Returns the sorted ids of all the components in the graph.
+func (g *componentsGraph) componentIds() []string {
+	componentIds := maps.Keys(g.components)
+	slices.Sort(componentIds)
+	return componentIds
+}
+
+// Returns the sorted ids of the direct dependencies of the given component.
+func (g *componentsGraph) dependencies(componentId string) []string {
+	dependencies := g.components[componentId].ToSlice()
+	slices.Sort(dependencies)
+	return dependencies
+}
+
+// Returns the sorted ids of the graph roots (the scanned projects or files).
+func (g *componentsGraph) rootIds() []string {
+	roots := g.roots.ToSlice()
+	slices.Sort(roots)
+	return roots
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildComponentsGraphFromImpactPaths(t *testing.T) {
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{{
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{{
+				Components: map[string]services.Component{
+					"gav://org.example:lib:1.0.0": {ImpactPaths: [][]services.ImpactPathNode{
+						{{ComponentId: "docker://image:latest"}, {ComponentId: "gav://org.example:app:1.0.0"}, {ComponentId: "gav://org.example:lib:1.0.0"}},
+					}},
+				},
+			}},
+		}},
+	}}
+	graph := buildComponentsGraph(results)
+	assert.Equal(t, []string{"docker://image:latest"}, graph.rootIds())
+	assert.Equal(t, []string{"docker://image:latest", "gav://org.example:app:1.0.0", "gav://org.example:lib:1.0.0"}, graph.componentIds())
+	assert.Equal(t, []string{"gav://org.example:app:1.0.0"}, graph.dependencies("docker://image:latest"))
+	assert.Equal(t, []string{"gav://org.example:lib:1.0.0"}, graph.dependencies("gav://org.example:app:1.0.0"))
+	assert.Empty(t, graph.dependencies("gav://org.example:lib:1.0.0"))
+}
@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The CSV export writes one file per section of the simple-json results, named after the section's json key (for example 'vulnerabilities.csv').
+// Nested fields are flattened as follows:
+//   - Each issue is written in one row per direct dependency (ComponentRow), so the direct dependency relationship is kept.
+//     Issues without direct dependencies are written in a single row with empty direct dependency columns.
+//   - List fields (CVEs, CVSS scores, fixed versions, references) are joined with csvListSeparator, keeping the order of the list.
+//   - Impact paths and code flows are written as their nodes joined with ' > ', and the different paths are joined with csvListSeparator.
+const (
+	csvListSeparator = "; "
+	csvFileExtension = ".csv"
+)
+
+var (
+	csvSecurityIssuesHeader  = []string{"Severity", "Impacted Package Name", "Impacted Package Version", "Impacted Package Type", "Direct Dependency Name", "Direct Dependency Version", "Fixed Versions", "CVEs", "CVSS v2", "CVSS v3", "Applicable", "Issue ID", "Summary", "References", "Impact Paths"}
+	csvLicensesHeader        = []string{"Severity", "License", "Impacted Package Name", "Impacted Package Version", "Impacted Package Type", "Direct Dependency Name", "Direct Dependency Version", "Impact Paths"}
+	csvOperationalRiskHeader = []string{"Severity", "Impacted Package Name", "Impacted Package Version", "Impacted Package Type", "Direct Dependency Name", "Direct Dependency Version", "Risk Reason", "Is End Of Life", "End Of Life Message", "Cadence", "Commits", "Committers", "Newer Versions", "Latest Version"}
+	csvSourceCodeHeader      = []string{"Severity", "File", "Start Line", "Start Column", "End Line", "End Column", "Snippet", "Finding", "Scanner Description", "Code Flows"}
+)
+
+// WriteCsvFiles writes the scan results to the given directory, one CSV file for each section of the simple-json results.
+func (rw *ResultsWriter) WriteCsvFiles(dirPath string) error {
+	jsonTable, err := rw.convertScanToSimpleJson()
+	if err != nil {
+		return err
+	}
+	return WriteSimpleJsonToCsvFiles(jsonTable, dirPath)
+}
+
+func WriteSimpleJsonToCsvFiles(jsonTable formats.SimpleJsonResults, dirPath string) error {
+	if err := errorutils.CheckError(os.MkdirAll(dirPath, 0755)); err != nil {
+		return err
+	}
+	sections := map[string][][]string{
+		"vulnerabilities":           getSecurityIssuesCsvRecords(jsonTable.Vulnerabilities),
+		"securityViolations":        getSecurityIssuesCsvRecords(jsonTable.SecurityViolations),
+		"licensesViolations":        getLicensesCsvRecords(jsonTable.LicensesViolations),
+		"licenses":                  getLicensesCsvRecords(jsonTable.Licenses),
+		"operationalRiskViolations": getOperationalRiskCsvRecords(jsonTable.OperationalRiskViolations),
+		"secrets":                   getSourceCodeCsvRecords(jsonTable.Secrets),
+		"iacViolations":             getSourceCodeCsvRecords(jsonTable.Iacs),
+		"sastViolations":            getSourceCodeCsvRecords(jsonTable.Sast),
+	}
+	for section, records := range sections {
+		if err := writeCsvFile(filepath.Join(dirPath, section+csvFileExtension), records); err != nil {
+			return err
+		}
+	}
+	log.Info("The CSV files were written to", dirPath)
+	return nil
+}
+
+func writeCsvFile(filePath string, records [][]string) (err error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errorutils.CheckError(errors.Join(err, file.Close()))
+	}()
+	writer := csv.NewWriter(file)
+	if err = writer.WriteAll(records); err != nil {
+		return
+	}
+	return writer.Error()
+}
+
+func getSecurityIssuesCsvRecords(issues []formats.VulnerabilityOrViolationRow) [][]string {
+	records := [][]string{csvSecurityIssuesHeader}
+	for _, issue := range issues {
+		var cveIds, cvssV2, cvssV3 []string
+		for _, cve := range issue.Cves {
+			cveIds = append(cveIds, cve.Id)
+			cvssV2 = append(cvssV2, cve.CvssV2)
+			cvssV3 = append(cvssV3, cve.CvssV3)
+		}
+		for _, directDependency := range getCsvDirectDependencies(issue.Components) {
+			records = append(records, []string{
+				issue.Severity,
+				issue.ImpactedDependencyName,
+				issue.ImpactedDependencyVersion,
+				issue.ImpactedDependencyType,
+				directDependency.Name,
+				directDependency.Version,
+				strings.Join(issue.FixedVersions, csvListSeparator),
+				strings.Join(cveIds, csvListSeparator),
+				strings.Join(cvssV2, csvListSeparator),
+				strings.Join(cvssV3, csvListSeparator),
+				issue.Applicable,
+				issue.IssueId,
+				issue.Summary,
+				strings.Join(issue.References, csvListSeparator),
+				getCsvImpactPaths(issue.ImpactPaths),
+			})
+		}
+	}
+	return records
+}
+
+func getLicensesCsvRecords(licenses []formats.LicenseRow) [][]string {
+	records := [][]string{csvLicensesHeader}
+	for _, license := range licenses {
+		for _, directDependency := range getCsvDirectDependencies(license.Components) {
+			records = append(records, []string{
+				license.Severity,
+				license.LicenseKey,
+				license.ImpactedDependencyName,
+				license.ImpactedDependencyVersion,
+				license.ImpactedDependencyType,
+				directDependency.Name,
+				directDependency.Version,
+				getCsvImpactPaths(license.ImpactPaths),
+			})
+		}
+	}
+	return records
+}
+
+func getOperationalRiskCsvRecords(violations []formats.OperationalRiskViolationRow) [][]string {
+	records := [][]string{csvOperationalRiskHeader}
+	for _, violation := range violations {
+		for _, directDependency := range getCsvDirectDependencies(violation.Components) {
+			records = append(records, []string{
+				violation.Severity,
+				violation.ImpactedDependencyName,
+				violation.ImpactedDependencyVersion,
+				violation.ImpactedDependencyType,
+				directDependency.Name,
+				directDependency.Version,
+				violation.RiskReason,
+				violation.IsEol,
+				violation.EolMessage,
+				violation.Cadence,
+				violation.Commits,
+				violation.Committers,
+				violation.NewerVersions,
+				violation.LatestVersion,
+			})
+		}
+	}
+	return records
+}
+
+func getSourceCodeCsvRecords(findings []formats.SourceCodeRow) [][]string {
+	records := [][]string{csvSourceCodeHeader}
+	for _, finding := range findings {
+		var codeFlows []string
+		for _, codeFlow := range finding.CodeFlow {
+			var locations []string
+			for _, location := range codeFlow {
+				locations = append(locations, fmt.Sprintf("%s:%d:%d", location.File, location.StartLine, location.StartColumn))
+			}
+			codeFlows = append(codeFlows, strings.Join(locations, " > "))
+		}
+		records = append(records, []string{
+			finding.Severity,
+			finding.File,
+			strconv.Itoa(finding.StartLine),
+			strconv.Itoa(finding.StartColumn),
+			strconv.Itoa(finding.EndLine),
+			strconv.Itoa(finding.EndColumn),
+			finding.Snippet,
+			finding.Finding,
+			finding.ScannerDescription,
+			strings.Join(codeFlows, csvListSeparator),
+		})
+	}
+	return records
+}
+
+// Returns at least one (possibly empty) component, so that issues without direct dependencies are also written.
+func getCsvDirectDependencies(components []formats.ComponentRow) []formats.ComponentRow {
+	if len(components) == 0 {
+		return []formats.ComponentRow{{}}
+	}
+	return components
+}
+
+func getCsvImpactPaths(impactPaths [][]formats.ComponentRow) string {
+	var paths []string
+	for _, impactPath := range impactPaths {
+		paths = append(paths, getImpactPathFormatted(impactPath))
+	}
+	return strings.Join(paths, csvListSeparator)
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSecurityIssuesCsvRecords(t *testing.T) {
+	records := getSecurityIssuesCsvRecords([]formats.VulnerabilityOrViolationRow{
+		{
+			ImpactedDependencyDetails: formats.ImpactedDependencyDetails{
+				SeverityDetails:           formats.SeverityDetails{Severity: "High"},
+				ImpactedDependencyName:    "lodash",
+				ImpactedDependencyVersion: "4.17.20",
+				ImpactedDependencyType:    "npm",
+				Components:                []formats.ComponentRow{{Name: "express", Version: "4.18.0"}, {Name: "lodash", Version: "4.17.20"}},
+			},
+			FixedVersions: []string{"[4.17.21]", "[5.0.0]"},
+			Cves:          []formats.CveRow{{Id: "CVE-2024-0001", CvssV3: "7.5"}, {Id: "CVE-2024-0002", CvssV3: "5.0"}},
+			IssueId:       "XRAY-1",
+			ImpactPaths:   [][]formats.ComponentRow{{{Name: "express", Version: "4.18.0"}, {Name: "lodash", Version: "4.17.20"}}, {{Name: "lodash", Version: "4.17.20"}}},
+		},
+		{IssueId: "XRAY-2"},
+	})
+	require.Len(t, records, 4)
+	assert.Equal(t, csvSecurityIssuesHeader, records[0])
+	assert.Equal(t, []string{"High", "lodash", "4.17.20", "npm", "express", "4.18.0", "[4.17.21]; [5.0.0]", "CVE-2024-0001; CVE-2024-0002", "; ", "7.5; 5.0", "", "XRAY-1", "", "", "express:4.18.0 > lodash:4.17.20; lodash:4.17.20"}, records[1])
+	assert.Equal(t, "lodash", records[2][4])
+	assert.Equal(t, "XRAY-2", records[3][11])
+	assert.Empty(t, records[3][4])
+}
+
+func TestGetSourceCodeCsvRecords(t *testing.T) {
+	records := getSourceCodeCsvRecords([]formats.SourceCodeRow{{
+		SeverityDetails: formats.SeverityDetails{Severity: "Medium"},
+		Location:        formats.Location{File: "app.js", StartLine: 10, StartColumn: 2, EndLine: 10, EndColumn: 8},
+		Finding:         "Stored XSS",
+		CodeFlow:        [][]formats.Location{{{File: "input.js", StartLine: 1, StartColumn: 1}, {File: "app.js", StartLine: 10, StartColumn: 2}}},
+	}})
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"Medium", "app.js", "10", "2", "10", "8", "", "Stored XSS", "", "input.js:1:1 > app.js:10:2"}, records[1])
+}
+
+func TestWriteSimpleJsonToCsvFiles(t *testing.T) {
+	csvDir := filepath.Join(t.TempDir(), "csv")
+	jsonTable := formats.SimpleJsonResults{Secrets: []formats.SourceCodeRow{{Location: formats.Location{File: "config.yaml"}, Finding: "Secret, with comma"}}}
+	require.NoError(t, WriteSimpleJsonToCsvFiles(jsonTable, csvDir))
+	for _, section := range []string{"vulnerabilities", "securityViolations", "licensesViolations", "licenses", "operationalRiskViolations", "secrets", "iacViolations", "sastViolations"} {
+		assert.FileExists(t, filepath.Join(csvDir, section+csvFileExtension))
+	}
+	file, err := os.Open(filepath.Join(csvDir, "secrets.csv"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Secret, with comma", records[1][7])
+}
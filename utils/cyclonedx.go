@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	nvdVulnerabilityUrl = "https://nvd.nist.gov/vuln/detail/"
+	xrayToolName        = "JFrog Xray"
+)
+
+// GenerateCycloneDxBom converts the SCA scan results to a CycloneDX 1.5 BOM.
+// The components and their relationships are taken from the components graph of the scans (see buildComponentsGraph).
+// The vulnerabilities section holds all the vulnerabilities and security violations that were found by Xray.
+func GenerateCycloneDxBom(results *Results) *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Tools: &cdx.ToolsChoice{Components: &[]cdx.Component{{
+			Type:    cdx.ComponentTypeApplication,
+			Name:    xrayToolName,
+			Version: results.XrayVersion,
+		}}},
+	}
+	vulnerabilities := map[string]*cdx.Vulnerability{}
+	for _, scaResult := range results.ScaResults {
+		for _, xrayResult := range scaResult.XrayResults {
+			for _, vulnerability := range xrayResult.Vulnerabilities {
+				addCycloneDxVulnerabilities(vulnerabilities, vulnerability.IssueId, vulnerability.Severity, vulnerability.Summary, vulnerability.Cves, vulnerability.Components, results)
+			}
+			for _, violation := range xrayResult.Violations {
+				if violation.ViolationType == "security" {
+					addCycloneDxVulnerabilities(vulnerabilities, violation.IssueId, violation.Severity, violation.Summary, violation.Cves, violation.Components, results)
+				}
+			}
+		}
+	}
+	components, dependencies := toCycloneDxComponentsAndDependencies(buildComponentsGraph(results))
+	bom.Components = &components
+	bom.Dependencies = &dependencies
+	vulnerabilitiesIds := maps.Keys(vulnerabilities)
+	slices.Sort(vulnerabilitiesIds)
+	bomVulnerabilities := []cdx.Vulnerability{}
+	for _, id := range vulnerabilitiesIds {
+		bomVulnerabilities = append(bomVulnerabilities, *vulnerabilities[id])
+	}
+	bom.Vulnerabilities = &bomVulnerabilities
+	return bom
+}
+
+func ConvertCycloneDxBomToString(bom *cdx.BOM) (string, error) {
+	var content bytes.Buffer
+	if err := cdx.NewBOMEncoder(&content, cdx.BOMFileFormatJSON).SetPretty(true).EncodeVersion(bom, cdx.SpecVersion1_5); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return content.String(), nil
+}
+
+func toCycloneDxComponentsAndDependencies(graph *componentsGraph) (components []cdx.Component, dependencies []cdx.Dependency) {
+	components = []cdx.Component{}
+	dependencies = []cdx.Dependency{}
+	for _, componentId := range graph.componentIds() {
+		components = append(components, toCycloneDxComponent(componentId))
+		dependsOn := graph.dependencies(componentId)
+		dependencies = append(dependencies, cdx.Dependency{Ref: componentId, Dependencies: &dependsOn})
+	}
+	return
+}
+
+func toCycloneDxComponent(componentId string) cdx.Component {
+	name, version, _ := SplitComponentId(componentId)
+	component := cdx.Component{
+		BOMRef:     componentId,
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       name,
+		Version:    version,
+		PackageURL: XrayComponentIdToPurl(componentId),
+	}
+	if strings.HasPrefix(componentId, "gav://") {
+		if groupEnd := strings.LastIndex(name, ":"); groupEnd != -1 {
+			component.Group, component.Name = name[:groupEnd], name[groupEnd+1:]
+		}
+	}
+	return component
+}
+
+// Adds a CycloneDX vulnerability for each of the issue CVEs (or for the Xray issue itself, if it has no CVEs).
+// Vulnerabilities that were already reported (by other scans) are updated with the new affected components.
+func addCycloneDxVulnerabilities(vulnerabilities map[string]*cdx.Vulnerability, issueId, severity, summary string, cves []services.Cve, components map[string]services.Component, results *Results) {
+	var identifiedCves []*services.Cve
+	for i := range cves {
+		if cves[i].Id != "" {
+			identifiedCves = append(identifiedCves, &cves[i])
+		}
+	}
+	if len(identifiedCves) == 0 {
+		// Issues without CVEs are reported by their Xray issue id
+		identifiedCves = append(identifiedCves, nil)
+	}
+	for _, cve := range identifiedCves {
+		vulnerabilityId := issueId
+		if cve != nil {
+			vulnerabilityId = cve.Id
+		}
+		vulnerability, exists := vulnerabilities[vulnerabilityId]
+		if !exists {
+			vulnerability = newCycloneDxVulnerability(issueId, severity, summary, cve)
+			vulnerabilities[vulnerabilityId] = vulnerability
+		}
+		for componentId, component := range components {
+			addCycloneDxAffectedComponent(vulnerability, componentId, component.FixedVersions)
+		}
+		if cve != nil && vulnerability.Analysis == nil && results.ExtendedScanResults.EntitledForJas {
+			vulnerability.Analysis = getCycloneDxVulnerabilityAnalysis(getCveApplicabilityField(formats.CveRow{Id: cve.Id}, results.ExtendedScanResults.ApplicabilityScanResults, components))
+		}
+	}
+}
+
+func newCycloneDxVulnerability(issueId, severity, summary string, cve *services.Cve) *cdx.Vulnerability {
+	vulnerability := &cdx.Vulnerability{
+		BOMRef:      issueId,
+		ID:          issueId,
+		Source:      &cdx.Source{Name: xrayToolName},
+		Description: summary,
+	}
+	ratings := []cdx.VulnerabilityRating{{
+		Source:   &cdx.Source{Name: xrayToolName},
+		Severity: toCycloneDxSeverity(severity),
+		Method:   cdx.ScoringMethodOther,
+	}}
+	if cve != nil {
+		vulnerability.BOMRef = cve.Id
+		vulnerability.ID = cve.Id
+		vulnerability.Source = &cdx.Source{Name: "NVD", URL: nvdVulnerabilityUrl + cve.Id}
+		vulnerability.References = &[]cdx.VulnerabilityReference{{ID: issueId, Source: &cdx.Source{Name: xrayToolName}}}
+		if rating := toCycloneDxCvssRating(cve.CvssV3Score, cve.CvssV3Vector, cdx.ScoringMethodCVSSv3); rating != nil {
+			ratings = append(ratings, *rating)
+		}
+		if rating := toCycloneDxCvssRating(cve.CvssV2Score, cve.CvssV2Vector, cdx.ScoringMethodCVSSv2); rating != nil {
+			ratings = append(ratings, *rating)
+		}
+	}
+	vulnerability.Ratings = &ratings
+	return vulnerability
+}
+
+func toCycloneDxCvssRating(score, vector string, method cdx.ScoringMethod) *cdx.VulnerabilityRating {
+	if score == "" {
+		return nil
+	}
+	floatScore, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		log.Debug("Couldn't parse CVSS score '" + score + "': " + err.Error())
+		return nil
+	}
+	return &cdx.VulnerabilityRating{Score: &floatScore, Method: method, Vector: vector}
+}
+
+func toCycloneDxSeverity(severity string) cdx.Severity {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return cdx.SeverityCritical
+	case "high":
+		return cdx.SeverityHigh
+	case "medium":
+		return cdx.SeverityMedium
+	case "low":
+		return cdx.SeverityLow
+	}
+	return cdx.SeverityUnknown
+}
+
+func addCycloneDxAffectedComponent(vulnerability *cdx.Vulnerability, componentId string, fixedVersions []string) {
+	if vulnerability.Affects == nil {
+		vulnerability.Affects = &[]cdx.Affects{}
+	}
+	if !slices.ContainsFunc(*vulnerability.Affects, func(affects cdx.Affects) bool { return affects.Ref == componentId }) {
+		*vulnerability.Affects = append(*vulnerability.Affects, cdx.Affects{Ref: componentId})
+	}
+	if len(fixedVersions) == 0 {
+		return
+	}
+	name, _, _ := SplitComponentId(componentId)
+	recommendation := getUpgradeRecommendation(name, fixedVersions)
+	if !strings.Contains(vulnerability.Recommendation, recommendation) {
+		vulnerability.Recommendation = strings.TrimPrefix(vulnerability.Recommendation+"\n"+recommendation, "\n")
+	}
+}
+
+// Converts the contextual analysis result of a CVE to a CycloneDX vulnerability analysis.
+func getCycloneDxVulnerabilityAnalysis(applicability *formats.Applicability) *cdx.VulnerabilityAnalysis {
+	if applicability == nil {
+		return nil
+	}
+	switch ApplicabilityStatus(applicability.Status) {
+	case Applicable:
+		return &cdx.VulnerabilityAnalysis{State: cdx.IASExploitable, Detail: applicability.ScannerDescription}
+	case NotApplicable:
+		return &cdx.VulnerabilityAnalysis{State: cdx.IASNotAffected, Justification: cdx.IAJCodeNotReachable, Detail: applicability.ScannerDescription}
+	case ApplicabilityUndetermined:
+		return &cdx.VulnerabilityAnalysis{State: cdx.IASInTriage, Detail: applicability.ScannerDescription}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCycloneDxBom(t *testing.T) {
+	results := NewAuditResults()
+	results.XrayVersion = "3.90.0"
+	results.ScaResults = []ScaScanResult{{
+		DependencyTrees: []*xrayUtils.GraphNode{{
+			Id: "npm://my-project:1.0.0",
+			Nodes: []*xrayUtils.GraphNode{
+				{Id: "npm://express:4.18.0", Nodes: []*xrayUtils.GraphNode{{Id: "npm://qs:6.9.0"}}},
+				{Id: "npm://lodash:4.17.20"},
+			},
+		}},
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{
+				{
+					IssueId:  "XRAY-1",
+					Severity: "High",
+					Summary:  "qs prototype pollution",
+					Cves:     []services.Cve{{Id: "CVE-2022-24999", CvssV3Score: "7.5"}},
+					Components: map[string]services.Component{
+						"npm://qs:6.9.0": {FixedVersions: []string{"[6.9.7]"}},
+					},
+				},
+				{
+					IssueId:    "XRAY-2",
+					Severity:   "Low",
+					Components: map[string]services.Component{"npm://lodash:4.17.20": {}},
+				},
+			},
+		}},
+	}}
+
+	bom := GenerateCycloneDxBom(results)
+	assert.Equal(t, cdx.SpecVersion1_5, bom.SpecVersion)
+	assert.Equal(t, "3.90.0", (*bom.Metadata.Tools.Components)[0].Version)
+
+	require.Len(t, *bom.Components, 4)
+	assert.Equal(t, "npm://express:4.18.0", (*bom.Components)[0].BOMRef)
+	assert.Equal(t, "pkg:npm/express@4.18.0", (*bom.Components)[0].PackageURL)
+
+	require.Len(t, *bom.Dependencies, 4)
+	for _, dependency := range *bom.Dependencies {
+		switch dependency.Ref {
+		case "npm://my-project:1.0.0":
+			assert.ElementsMatch(t, []string{"npm://express:4.18.0", "npm://lodash:4.17.20"}, *dependency.Dependencies)
+		case "npm://express:4.18.0":
+			assert.ElementsMatch(t, []string{"npm://qs:6.9.0"}, *dependency.Dependencies)
+		default:
+			assert.Empty(t, *dependency.Dependencies)
+		}
+	}
+
+	require.Len(t, *bom.Vulnerabilities, 2)
+	cveVulnerability := (*bom.Vulnerabilities)[0]
+	assert.Equal(t, "CVE-2022-24999", cveVulnerability.ID)
+	assert.Equal(t, "XRAY-1", (*cveVulnerability.References)[0].ID)
+	assert.Equal(t, []cdx.Affects{{Ref: "npm://qs:6.9.0"}}, *cveVulnerability.Affects)
+	assert.Equal(t, "Upgrade qs to one of the following versions: [6.9.7]", cveVulnerability.Recommendation)
+	require.Len(t, *cveVulnerability.Ratings, 2)
+	assert.Equal(t, cdx.SeverityHigh, (*cveVulnerability.Ratings)[0].Severity)
+	assert.Equal(t, 7.5, *(*cveVulnerability.Ratings)[1].Score)
+
+	issueVulnerability := (*bom.Vulnerabilities)[1]
+	assert.Equal(t, "XRAY-2", issueVulnerability.ID)
+	assert.Nil(t, issueVulnerability.References)
+	assert.Equal(t, []cdx.Affects{{Ref: "npm://lodash:4.17.20"}}, *issueVulnerability.Affects)
+
+	bomStr, err := ConvertCycloneDxBomToString(bom)
+	assert.NoError(t, err)
+	assert.Contains(t, bomStr, `"specVersion": "1.5"`)
+}
+
+func TestGenerateCycloneDxBomFromImpactPaths(t *testing.T) {
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{{
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{{
+				IssueId:  "XRAY-1",
+				Severity: "Critical",
+				Components: map[string]services.Component{
+					"gav://org.apache.logging.log4j:log4j-core:2.14.1": {ImpactPaths: [][]services.ImpactPathNode{{
+						{ComponentId: "docker://image:latest"},
+						{ComponentId: "gav://org.apache.logging.log4j:log4j-core:2.14.1"},
+					}}},
+				},
+			}},
+		}},
+	}}
+
+	bom := GenerateCycloneDxBom(results)
+	require.Len(t, *bom.Components, 2)
+	mavenComponent := (*bom.Components)[1]
+	assert.Equal(t, "org.apache.logging.log4j", mavenComponent.Group)
+	assert.Equal(t, "log4j-core", mavenComponent.Name)
+	assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", mavenComponent.PackageURL)
+	assert.Equal(t, []string{"gav://org.apache.logging.log4j:log4j-core:2.14.1"}, *(*bom.Dependencies)[0].Dependencies)
+}
+
+func TestGetCycloneDxVulnerabilityAnalysis(t *testing.T) {
+	assert.Nil(t, getCycloneDxVulnerabilityAnalysis(nil))
+	assert.Equal(t, cdx.IASExploitable, getCycloneDxVulnerabilityAnalysis(&formats.Applicability{Status: Applicable.String()}).State)
+	notApplicable := getCycloneDxVulnerabilityAnalysis(&formats.Applicability{Status: NotApplicable.String()})
+	assert.Equal(t, cdx.IASNotAffected, notApplicable.State)
+	assert.Equal(t, cdx.IAJCodeNotReachable, notApplicable.Justification)
+	assert.Equal(t, cdx.IASInTriage, getCycloneDxVulnerabilityAnalysis(&formats.Applicability{Status: ApplicabilityUndetermined.String()}).State)
+	assert.Nil(t, getCycloneDxVulnerabilityAnalysis(&formats.Applicability{Status: NotCovered.String()}))
+}
@@ -0,0 +1,369 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+)
+
+const (
+	mavenDescriptor = "pom.xml"
+	// Maven looks for the parent POM in the parent directory, unless <relativePath> is set.
+	mavenDefaultParentRelativePath = ".."
+	// Limits the parent POMs chain, in case the POMs reference each other.
+	maxMavenParentsDepth = 10
+)
+
+var npmDependenciesSectionPattern = regexp.MustCompile(`"(dependencies|devDependencies|peerDependencies|optionalDependencies)"\s*:\s*\{`)
+
+// The position of a dependency declaration in a descriptor file. Lines and columns are 1-based, and the end column is exclusive, as in SARIF regions.
+type descriptorRegion struct {
+	startLine   int
+	startColumn int
+	endLine     int
+	endColumn   int
+}
+
+// descriptorsLocator finds the lines in which the direct dependencies of a scanned project are declared, in the project's descriptor files.
+type descriptorsLocator struct {
+	tech        coreutils.Technology
+	descriptors []string
+	// The content of the descriptors, read once per file.
+	contents map[string][]byte
+}
+
+func newDescriptorsLocator(scaResult ScaScanResult) *descriptorsLocator {
+	return &descriptorsLocator{
+		tech:        scaResult.Technology,
+		descriptors: getScaScanDescriptors(scaResult),
+		contents:    map[string][]byte{},
+	}
+}
+
+// Returns the descriptor files of the scanned project: the descriptors that were detected in its working directory,
+// or the technology's descriptors that exist in the working directory. For Maven projects, the parent POMs are included too.
+func getScaScanDescriptors(scaResult ScaScanResult) (descriptors []string) {
+	descriptors = append(descriptors, scaResult.Descriptors...)
+	if len(descriptors) == 0 && scaResult.WorkingDirectory != "" {
+		for _, descriptor := range scaResult.Technology.GetPackageDescriptor() {
+			descriptors = append(descriptors, findDescriptorsInDir(scaResult.WorkingDirectory, strings.TrimSpace(descriptor))...)
+		}
+	}
+	if scaResult.Technology == coreutils.Maven {
+		for _, descriptor := range descriptors {
+			descriptors = appendMavenParentPoms(descriptors, descriptor)
+		}
+	}
+	return
+}
+
+// Descriptors that start with a dot are file extensions, such as '.csproj'.
+func findDescriptorsInDir(dir, descriptor string) (found []string) {
+	if !strings.HasPrefix(descriptor, ".") {
+		if exists, err := fileutils.IsFileExists(filepath.Join(dir, descriptor), false); err == nil && exists {
+			found = append(found, filepath.Join(dir, descriptor))
+		}
+		return
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+descriptor))
+	if err != nil {
+		log.Debug("Failed to search for", descriptor, "files in", dir, ":", err.Error())
+		return
+	}
+	return matches
+}
+
+func appendMavenParentPoms(descriptors []string, pomPath string) []string {
+	for i := 0; i < maxMavenParentsDepth; i++ {
+		content, err := os.ReadFile(pomPath)
+		if err != nil {
+			return descriptors
+		}
+		parentPomPath, hasParent := getMavenParentPomPath(pomPath, content)
+		if !hasParent {
+			return descriptors
+		}
+		for _, descriptor := range descriptors {
+			if filepath.Clean(descriptor) == parentPomPath {
+				return descriptors
+			}
+		}
+		if exists, err := fileutils.IsFileExists(parentPomPath, false); err != nil || !exists {
+			return descriptors
+		}
+		descriptors = append(descriptors, parentPomPath)
+		pomPath = parentPomPath
+	}
+	return descriptors
+}
+
+func getMavenParentPomPath(pomPath string, content []byte) (parentPomPath string, hasParent bool) {
+	var pom struct {
+		Parent *struct {
+			RelativePath *string `xml:"relativePath"`
+		} `xml:"parent"`
+	}
+	if err := xml.Unmarshal(content, &pom); err != nil || pom.Parent == nil {
+		return
+	}
+	relativePath := mavenDefaultParentRelativePath
+	if pom.Parent.RelativePath != nil {
+		// An empty <relativePath/> means that the parent should be resolved from the repositories.
+		if relativePath = strings.TrimSpace(*pom.Parent.RelativePath); relativePath == "" {
+			return
+		}
+	}
+	parentPomPath = filepath.Join(filepath.Dir(pomPath), relativePath)
+	if filepath.Base(parentPomPath) != mavenDescriptor {
+		parentPomPath = filepath.Join(parentPomPath, mavenDescriptor)
+	}
+	return filepath.Clean(parentPomPath), true
+}
+
+// Returns the location of the direct dependency declaration.
+// If the declaration can't be found, the location of the project's first descriptor is returned, without a region.
+// If the project has no descriptors, nil is returned.
+func (dl *descriptorsLocator) getDirectDependencyLocation(directDependency formats.ComponentRow) *sarif.Location {
+	if len(dl.descriptors) == 0 {
+		return nil
+	}
+	for _, descriptor := range dl.descriptors {
+		region, err := dl.findDependencyRegion(descriptor, directDependency.Name)
+		if err != nil {
+			log.Debug("Failed to find the declaration of", directDependency.Name, "in", descriptor, ":", err.Error())
+			continue
+		}
+		if region != nil {
+			return sarif.NewLocation().WithPhysicalLocation(
+				sarif.NewPhysicalLocation().
+					WithArtifactLocation(sarif.NewArtifactLocation().WithUri("file://" + descriptor)).
+					WithRegion(sarif.NewRegion().
+						WithStartLine(region.startLine).
+						WithStartColumn(region.startColumn).
+						WithEndLine(region.endLine).
+						WithEndColumn(region.endColumn)),
+			)
+		}
+	}
+	return getXrayIssueLocation(dl.descriptors[0])
+}
+
+func (dl *descriptorsLocator) findDependencyRegion(descriptor, dependencyName string) (*descriptorRegion, error) {
+	content, exists := dl.contents[descriptor]
+	if !exists {
+		var err error
+		if content, err = os.ReadFile(descriptor); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		dl.contents[descriptor] = content
+	}
+	fileName := filepath.Base(descriptor)
+	switch {
+	case fileName == "package.json":
+		return findNpmDependencyRegion(content, dependencyName), nil
+	case fileName == mavenDescriptor:
+		return findMavenDependencyRegion(content, dependencyName)
+	case fileName == "build.gradle" || fileName == "build.gradle.kts":
+		return findGradleDependencyRegion(content, dependencyName), nil
+	case fileName == "go.mod":
+		return findGoDependencyRegion(content, dependencyName), nil
+	case strings.HasSuffix(fileName, ".csproj"):
+		return findNugetDependencyRegion(content, dependencyName), nil
+	case isPythonDescriptor(dl.tech, fileName):
+		return findPythonDependencyRegion(content, dependencyName), nil
+	}
+	return nil, nil
+}
+
+// The requirements file may have a custom name, set by the 'requirements-file' option.
+func isPythonDescriptor(tech coreutils.Technology, fileName string) bool {
+	switch fileName {
+	case "requirements.txt", "setup.py", "Pipfile", "pyproject.toml":
+		return true
+	}
+	return tech == coreutils.Pip && filepath.Ext(fileName) == ".txt"
+}
+
+// Dependencies are matched only inside the dependencies sections, to avoid matching script names and other keys.
+func findNpmDependencyRegion(content []byte, dependencyName string) *descriptorRegion {
+	dependencyPattern := regexp.MustCompile(`"` + regexp.QuoteMeta(dependencyName) + `"\s*:`)
+	inSection, depth := false, 0
+	for i, line := range splitDescriptorLines(content) {
+		// The part of the line that is inside the section.
+		sectionStart := 0
+		if !inSection {
+			sectionIndex := npmDependenciesSectionPattern.FindStringIndex(line)
+			if sectionIndex == nil {
+				continue
+			}
+			inSection, depth, sectionStart = true, 0, sectionIndex[1]-1
+		}
+		if index := dependencyPattern.FindStringIndex(line[sectionStart:]); index != nil {
+			return getLineRegion(i, line, sectionStart+index[0])
+		}
+		depth += strings.Count(line[sectionStart:], "{") - strings.Count(line[sectionStart:], "}")
+		inSection = depth > 0
+	}
+	return nil
+}
+
+// The dependency name is expected in the '<groupId>:<artifactId>' structure. The region covers the whole <dependency> element.
+func findMavenDependencyRegion(content []byte, dependencyName string) (*descriptorRegion, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	var dependencyStart int64
+	var inDependency bool
+	var currentElement, groupId, artifactId string
+	for {
+		tokenStart := decoder.InputOffset()
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "dependency" {
+				inDependency, dependencyStart, groupId, artifactId = true, tokenStart, "", ""
+			}
+			currentElement = element.Name.Local
+		case xml.CharData:
+			if !inDependency {
+				continue
+			}
+			switch currentElement {
+			case "groupId":
+				groupId += strings.TrimSpace(string(element))
+			case "artifactId":
+				artifactId += strings.TrimSpace(string(element))
+			}
+		case xml.EndElement:
+			currentElement = ""
+			if element.Name.Local != "dependency" || !inDependency {
+				continue
+			}
+			inDependency = false
+			if groupId+":"+artifactId == dependencyName {
+				startLine, startColumn := getOffsetPosition(content, dependencyStart)
+				endLine, endColumn := getOffsetPosition(content, decoder.InputOffset())
+				return &descriptorRegion{startLine: startLine, startColumn: startColumn, endLine: endLine, endColumn: endColumn}, nil
+			}
+		}
+	}
+}
+
+// Both the string notation ('group:name:version') and the map notation (group: 'group', name: 'name') are supported.
+func findGradleDependencyRegion(content []byte, dependencyName string) *descriptorRegion {
+	group, name, found := strings.Cut(dependencyName, ":")
+	if !found {
+		return nil
+	}
+	stringNotation := regexp.MustCompile(`["']` + regexp.QuoteMeta(dependencyName) + `[:@"']`)
+	groupNotation := regexp.MustCompile(`group\s*[:=]\s*["']` + regexp.QuoteMeta(group) + `["']`)
+	nameNotation := regexp.MustCompile(`name\s*[:=]\s*["']` + regexp.QuoteMeta(name) + `["']`)
+	for i, line := range splitDescriptorLines(content) {
+		if index := stringNotation.FindStringIndex(line); index != nil {
+			return getLineRegion(i, line, index[0])
+		}
+		if index := groupNotation.FindStringIndex(line); index != nil && nameNotation.MatchString(line) {
+			return getLineRegion(i, line, index[0])
+		}
+	}
+	return nil
+}
+
+// Only the 'require' directives are checked, since replaced modules are declared in their own require directive too.
+func findGoDependencyRegion(content []byte, dependencyName string) *descriptorRegion {
+	inRequireBlock := false
+	for i, line := range splitDescriptorLines(content) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if inRequireBlock && fields[0] == ")" {
+			inRequireBlock = false
+			continue
+		}
+		if fields[0] == "require" {
+			if len(fields) > 1 && fields[1] == "(" {
+				inRequireBlock = true
+				continue
+			}
+			fields = fields[1:]
+		} else if !inRequireBlock {
+			continue
+		}
+		if len(fields) > 0 && fields[0] == dependencyName {
+			return getLineRegion(i, line, strings.Index(line, dependencyName))
+		}
+	}
+	return nil
+}
+
+func findNugetDependencyRegion(content []byte, dependencyName string) *descriptorRegion {
+	referencePattern := regexp.MustCompile(`(?i)<PackageReference\s+[^>]*(Include|Update)\s*=\s*"` + regexp.QuoteMeta(dependencyName) + `"`)
+	for i, line := range splitDescriptorLines(content) {
+		if index := referencePattern.FindStringIndex(line); index != nil {
+			return getLineRegion(i, line, index[0])
+		}
+	}
+	return nil
+}
+
+// Python package names are case-insensitive, and treat runs of '-', '_' and '.' as equal (PEP 503).
+// Supports requirement lines (requirements.txt), quoted requirements (setup.py, PEP 621 pyproject.toml) and TOML keys (Poetry pyproject.toml, Pipfile).
+func findPythonDependencyRegion(content []byte, dependencyName string) *descriptorRegion {
+	var nameParts []string
+	for _, part := range regexp.MustCompile(`[-_.]+`).Split(dependencyName, -1) {
+		nameParts = append(nameParts, regexp.QuoteMeta(part))
+	}
+	namePattern := strings.Join(nameParts, `[-_.]+`)
+	unquotedPattern := regexp.MustCompile(`(?i)^\s*(` + namePattern + `)\s*([\[=<>!~;@]|$)`)
+	quotedPattern := regexp.MustCompile(`(?i)["'](` + namePattern + `)\s*([\[=<>!~;@"'])`)
+	for i, line := range splitDescriptorLines(content) {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if index := unquotedPattern.FindStringSubmatchIndex(line); index != nil {
+			return getLineRegion(i, line, index[2])
+		}
+		if index := quotedPattern.FindStringSubmatchIndex(line); index != nil {
+			return getLineRegion(i, line, index[0])
+		}
+	}
+	return nil
+}
+
+func splitDescriptorLines(content []byte) []string {
+	return strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+}
+
+// Returns a single line region, from the given index to the end of the line.
+func getLineRegion(lineIndex int, line string, startIndex int) *descriptorRegion {
+	return &descriptorRegion{
+		startLine:   lineIndex + 1,
+		startColumn: startIndex + 1,
+		endLine:     lineIndex + 1,
+		endColumn:   len(strings.TrimRight(line, " \t\r")) + 1,
+	}
+}
+
+// Converts a byte offset in the content to its 1-based line and column.
+func getOffsetPosition(content []byte, offset int64) (line, column int) {
+	prefix := content[:offset]
+	lineStart := bytes.LastIndexByte(prefix, '\n') + 1
+	return bytes.Count(prefix, []byte("\n")) + 1, int(offset) - lineStart + 1
+}
@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDependencyRegion(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		tech           coreutils.Technology
+		content        string
+		dependency     string
+		expectedRegion *descriptorRegion
+	}{
+		{
+			name:     "npm dependency",
+			fileName: "package.json",
+			tech:     coreutils.Npm,
+			content: `{
+  "name": "project",
+  "scripts": {
+    "lodash": "lodash"
+  },
+  "devDependencies": {
+    "jest": "^29.0.0"
+  },
+  "dependencies": { "@types/node": "^20.0.0",
+    "lodash": "4.17.0"
+  }
+}`,
+			dependency:     "lodash",
+			expectedRegion: &descriptorRegion{startLine: 10, startColumn: 5, endLine: 10, endColumn: 23},
+		},
+		{
+			name:           "npm scoped dependency in the section line",
+			fileName:       "package.json",
+			tech:           coreutils.Npm,
+			content:        "{\n  \"dependencies\": { \"@types/node\": \"^20.0.0\" }\n}",
+			dependency:     "@types/node",
+			expectedRegion: &descriptorRegion{startLine: 2, startColumn: 21, endLine: 2, endColumn: 47},
+		},
+		{
+			name:     "Maven dependency",
+			fileName: "pom.xml",
+			tech:     coreutils.Maven,
+			content: `<project>
+  <dependencies>
+    <dependency>
+      <groupId>org.other</groupId>
+      <artifactId>commons-io</artifactId>
+    </dependency>
+    <dependency>
+      <artifactId>commons-io</artifactId>
+      <groupId>commons-io</groupId>
+      <version>2.6</version>
+    </dependency>
+  </dependencies>
+</project>`,
+			dependency:     "commons-io:commons-io",
+			expectedRegion: &descriptorRegion{startLine: 7, startColumn: 5, endLine: 11, endColumn: 18},
+		},
+		{
+			name:     "Gradle string notation",
+			fileName: "build.gradle",
+			tech:     coreutils.Gradle,
+			content: `dependencies {
+    implementation 'org.apache.commons:commons-lang3:3.12.0'
+    implementation("junit:junit:4.11")
+}`,
+			dependency:     "junit:junit",
+			expectedRegion: &descriptorRegion{startLine: 3, startColumn: 20, endLine: 3, endColumn: 39},
+		},
+		{
+			name:           "Gradle map notation",
+			fileName:       "build.gradle",
+			tech:           coreutils.Gradle,
+			content:        "dependencies {\n    implementation group: 'junit', name: 'junit', version: '4.11'\n}",
+			dependency:     "junit:junit",
+			expectedRegion: &descriptorRegion{startLine: 2, startColumn: 20, endLine: 2, endColumn: 66},
+		},
+		{
+			name:     "Go module",
+			fileName: "go.mod",
+			tech:     coreutils.Go,
+			content: `module github.com/jfrog/project
+
+require github.com/google/uuid v1.6.0
+
+require (
+	github.com/jfrog/gofrog v1.6.3
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace golang.org/x/text => golang.org/x/text v0.15.0`,
+			dependency:     "golang.org/x/text",
+			expectedRegion: &descriptorRegion{startLine: 7, startColumn: 2, endLine: 7, endColumn: 39},
+		},
+		{
+			name:           "Go module in a single require directive",
+			fileName:       "go.mod",
+			tech:           coreutils.Go,
+			content:        "module github.com/jfrog/project\n\nrequire github.com/google/uuid v1.6.0\n",
+			dependency:     "github.com/google/uuid",
+			expectedRegion: &descriptorRegion{startLine: 3, startColumn: 9, endLine: 3, endColumn: 38},
+		},
+		{
+			name:           "Pip requirements",
+			fileName:       "requirements.txt",
+			tech:           coreutils.Pip,
+			content:        "# pyyaml is needed\nrequests==2.31.0\nPyYAML>=5.4 ; python_version > '3'\n",
+			dependency:     "pyyaml",
+			expectedRegion: &descriptorRegion{startLine: 3, startColumn: 1, endLine: 3, endColumn: 35},
+		},
+		{
+			name:     "Poetry pyproject",
+			fileName: "pyproject.toml",
+			tech:     coreutils.Poetry,
+			content: `[tool.poetry]
+name = "project"
+
+[tool.poetry.dependencies]
+python = "^3.9"
+typing_extensions = "^4.0"`,
+			dependency:     "typing-extensions",
+			expectedRegion: &descriptorRegion{startLine: 6, startColumn: 1, endLine: 6, endColumn: 27},
+		},
+		{
+			name:           "PEP 621 pyproject",
+			fileName:       "pyproject.toml",
+			tech:           coreutils.Poetry,
+			content:        "[project]\ndependencies = [\n    \"httpx[http2]>=0.27\",\n]",
+			dependency:     "httpx",
+			expectedRegion: &descriptorRegion{startLine: 3, startColumn: 5, endLine: 3, endColumn: 26},
+		},
+		{
+			name:     "NuGet package reference",
+			fileName: "project.csproj",
+			tech:     coreutils.Nuget,
+			content: `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.1" />
+  </ItemGroup>
+</Project>`,
+			dependency:     "newtonsoft.json",
+			expectedRegion: &descriptorRegion{startLine: 3, startColumn: 5, endLine: 3, endColumn: 68},
+		},
+		{
+			name:       "Dependency not declared",
+			fileName:   "package.json",
+			tech:       coreutils.Npm,
+			content:    `{"dependencies": {"lodash": "4.17.0"}}`,
+			dependency: "express",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			descriptor := filepath.Join(t.TempDir(), tc.fileName)
+			require.NoError(t, os.WriteFile(descriptor, []byte(tc.content), 0644))
+			locator := &descriptorsLocator{tech: tc.tech, descriptors: []string{descriptor}, contents: map[string][]byte{}}
+			region, err := locator.findDependencyRegion(descriptor, tc.dependency)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRegion, region)
+		})
+	}
+}
+
+func TestGetScaScanDescriptors(t *testing.T) {
+	projectDir := t.TempDir()
+	moduleDir := filepath.Join(projectDir, "module")
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "pom.xml"), []byte("<project></project>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "pom.xml"), []byte("<project><parent><artifactId>parent</artifactId></parent></project>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "build.gradle.kts"), []byte(""), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "module.csproj"), []byte(""), 0644))
+
+	testCases := []struct {
+		name                string
+		scaResult           ScaScanResult
+		expectedDescriptors []string
+	}{
+		{
+			name:                "Detected descriptors with a parent POM",
+			scaResult:           ScaScanResult{Technology: coreutils.Maven, WorkingDirectory: moduleDir, Descriptors: []string{filepath.Join(moduleDir, "pom.xml")}},
+			expectedDescriptors: []string{filepath.Join(moduleDir, "pom.xml"), filepath.Join(projectDir, "pom.xml")},
+		},
+		{
+			name:                "Working directory descriptors",
+			scaResult:           ScaScanResult{Technology: coreutils.Gradle, WorkingDirectory: moduleDir},
+			expectedDescriptors: []string{filepath.Join(moduleDir, "build.gradle.kts")},
+		},
+		{
+			name:                "Working directory descriptors by extension",
+			scaResult:           ScaScanResult{Technology: coreutils.Nuget, WorkingDirectory: moduleDir},
+			expectedDescriptors: []string{filepath.Join(moduleDir, "module.csproj")},
+		},
+		{
+			name:      "No working directory",
+			scaResult: ScaScanResult{Technology: coreutils.Npm},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDescriptors, getScaScanDescriptors(tc.scaResult))
+		})
+	}
+}
+
+func TestGetDirectDependencyLocation(t *testing.T) {
+	descriptor := filepath.Join(t.TempDir(), "package.json")
+	require.NoError(t, os.WriteFile(descriptor, []byte("{\n  \"dependencies\": {\n    \"lodash\": \"4.17.0\"\n  }\n}"), 0644))
+	locator := newDescriptorsLocator(ScaScanResult{Technology: coreutils.Npm, Descriptors: []string{descriptor}})
+
+	location := locator.getDirectDependencyLocation(formats.ComponentRow{Name: "lodash", Version: "4.17.0"})
+	assert.Equal(t, "file://"+descriptor, GetLocationFileName(location))
+	assert.Equal(t, 3, GetLocationStartLine(location))
+	assert.Equal(t, 5, GetLocationStartColumn(location))
+
+	// Dependencies that are not declared in the descriptor point at the whole descriptor.
+	location = locator.getDirectDependencyLocation(formats.ComponentRow{Name: "express", Version: "4.18.0"})
+	assert.Equal(t, "file://"+descriptor, GetLocationFileName(location))
+	assert.Nil(t, location.PhysicalLocation.Region)
+
+	assert.Nil(t, newDescriptorsLocator(ScaScanResult{Technology: coreutils.Npm}).getDirectDependencyLocation(formats.ComponentRow{Name: "lodash"}))
+}
+
+func TestGenerateSarifReportWithDescriptorLocations(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module project\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.0\n)\n"), 0644))
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{{
+		Technology:       coreutils.Go,
+		WorkingDirectory: projectDir,
+		XrayResults: []services.ScanResponse{{Vulnerabilities: []services.Vulnerability{{
+			IssueId:  "XRAY-1",
+			Severity: "High",
+			Cves:     []services.Cve{{Id: "CVE-2023-1234"}},
+			Components: map[string]services.Component{"go://golang.org/x/net:0.1.0": {ImpactPaths: [][]services.ImpactPathNode{{
+				{ComponentId: "go://project"}, {ComponentId: "go://github.com/gin-gonic/gin:1.9.0"}, {ComponentId: "go://golang.org/x/net:0.1.0"},
+			}}}},
+		}}}},
+	}}
+	report, err := GenereateSarifReportFromResults(results, true, false, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Runs[0].Results, 1)
+	require.Len(t, report.Runs[0].Results[0].Locations, 1)
+	location := report.Runs[0].Results[0].Locations[0]
+	assert.Equal(t, "file://"+filepath.Join(projectDir, "go.mod"), GetLocationFileName(location))
+	assert.Equal(t, sarif.NewRegion().WithStartLine(4).WithStartColumn(2).WithEndLine(4).WithEndColumn(33), location.PhysicalLocation.Region)
+}
@@ -0,0 +1,322 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	failFieldType       = "type"
+	failFieldSeverity   = "severity"
+	failFieldCvss       = "cvss"
+	failFieldApplicable = "applicable"
+	failFieldFixable    = "fixable"
+
+	// The number of matched findings that are listed in the summary of each condition.
+	maxFailSummaryFindings = 5
+)
+
+var (
+	failFields             = []string{failFieldType, failFieldSeverity, failFieldCvss, failFieldApplicable, failFieldFixable}
+	failFindingTypes       = []string{"vulnerability", "violation", "secret", "iac", "sast"}
+	failSeverities         = []string{"unknown", "low", "medium", "high", "critical"}
+	failApplicableStatuses = []string{"applicable", "not_applicable", "undetermined", "not_covered", "not_scanned"}
+	failComparisonPattern  = regexp.MustCompile(`^\s*([a-zA-Z_]+)\s*(>=|<=|!=|==|=|>|<)\s*([^\s]+)\s*$`)
+)
+
+// A local condition that fails the build if any of the findings matches it, regardless of the Xray watches.
+// The condition is a disjunction ('||') of conjunctions ('&&') of comparisons, for example: 'severity>=high && applicable!=not_applicable'.
+type FailCondition struct {
+	// Describes the condition in the summary of the failure.
+	name         string
+	alternatives [][]failComparison
+}
+
+type failComparison struct {
+	field    string
+	operator string
+	value    string
+	cvss     float64
+}
+
+// The properties of a finding that the fail conditions are evaluated on.
+type failFinding struct {
+	findingType string
+	severity    string
+	// Negative if the finding has no CVSS score.
+	cvss       float64
+	applicable string
+	fixable    bool
+	// Describes the finding in the summary of the failure.
+	description string
+}
+
+// ParseFailCondition parses an expression of a fail condition.
+// The fields are: type, severity, cvss, applicable and fixable. The operators are: ==, !=, >, >=, < and <=.
+func ParseFailCondition(expression string) (*FailCondition, error) {
+	condition := &FailCondition{name: strings.TrimSpace(expression)}
+	for _, alternative := range strings.Split(expression, "||") {
+		var comparisons []failComparison
+		for _, comparisonVal := range strings.Split(alternative, "&&") {
+			comparison, err := parseFailComparison(comparisonVal)
+			if err != nil {
+				return nil, errorutils.CheckErrorf("invalid fail condition '%s': %s", condition.name, err.Error())
+			}
+			comparisons = append(comparisons, comparison)
+		}
+		condition.alternatives = append(condition.alternatives, comparisons)
+	}
+	return condition, nil
+}
+
+// NewFailOnSecretsCondition returns a condition that fails the build if any secret is found.
+func NewFailOnSecretsCondition() *FailCondition {
+	return &FailCondition{name: "fail on secrets", alternatives: [][]failComparison{{{field: failFieldType, operator: "==", value: "secret"}}}}
+}
+
+// ParseFailOnCvssCondition returns a condition that fails the build if any SCA finding has a CVSS score that is at least the given score.
+func ParseFailOnCvssCondition(score string) (*FailCondition, error) {
+	comparison, err := parseFailComparison(failFieldCvss + ">=" + score)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid CVSS score to fail on: %s", err.Error())
+	}
+	return &FailCondition{name: "fail on CVSS " + score, alternatives: [][]failComparison{{comparison}}}, nil
+}
+
+func parseFailComparison(comparisonVal string) (comparison failComparison, err error) {
+	matches := failComparisonPattern.FindStringSubmatch(comparisonVal)
+	if matches == nil {
+		return comparison, fmt.Errorf("'%s' isn't a <field><operator><value> comparison", strings.TrimSpace(comparisonVal))
+	}
+	comparison = failComparison{field: strings.ToLower(matches[1]), operator: matches[2], value: strings.ToLower(matches[3])}
+	if comparison.operator == "=" {
+		comparison.operator = "=="
+	}
+	isEqualityOperator := comparison.operator == "==" || comparison.operator == "!="
+	switch comparison.field {
+	case failFieldType:
+		return comparison, validateFailValue(comparison, failFindingTypes, isEqualityOperator)
+	case failFieldSeverity:
+		return comparison, validateFailValue(comparison, failSeverities, true)
+	case failFieldApplicable:
+		return comparison, validateFailValue(comparison, failApplicableStatuses, isEqualityOperator)
+	case failFieldFixable:
+		return comparison, validateFailValue(comparison, []string{"true", "false"}, isEqualityOperator)
+	case failFieldCvss:
+		if comparison.cvss, err = strconv.ParseFloat(comparison.value, 64); err != nil || comparison.cvss < 0 || comparison.cvss > 10 {
+			return comparison, fmt.Errorf("invalid CVSS score '%s', expected a number between 0 and 10", matches[3])
+		}
+		return comparison, nil
+	default:
+		return comparison, fmt.Errorf("unknown field '%s', the supported fields are: %s", matches[1], strings.Join(failFields, ", "))
+	}
+}
+
+func validateFailValue(comparison failComparison, supportedValues []string, isOperatorSupported bool) error {
+	if !isOperatorSupported {
+		return fmt.Errorf("the '%s' operator isn't supported for the %s field, only == and != are supported", comparison.operator, comparison.field)
+	}
+	if !slices.Contains(supportedValues, comparison.value) {
+		return fmt.Errorf("invalid %s '%s', the supported values are: %s", comparison.field, comparison.value, strings.Join(supportedValues, ", "))
+	}
+	return nil
+}
+
+func (condition *FailCondition) match(finding failFinding) bool {
+	for _, alternative := range condition.alternatives {
+		matched := true
+		for _, comparison := range alternative {
+			if !comparison.match(finding) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (comparison failComparison) match(finding failFinding) bool {
+	switch comparison.field {
+	case failFieldType:
+		return compareFailValues(comparison.operator, finding.findingType == comparison.value, 0)
+	case failFieldApplicable:
+		return compareFailValues(comparison.operator, finding.applicable == comparison.value, 0)
+	case failFieldFixable:
+		return compareFailValues(comparison.operator, strconv.FormatBool(finding.fixable) == comparison.value, 0)
+	case failFieldSeverity:
+		order := slices.Index(failSeverities, finding.severity) - slices.Index(failSeverities, comparison.value)
+		return compareFailValues(comparison.operator, order == 0, order)
+	case failFieldCvss:
+		if finding.cvss < 0 {
+			// The findings without a CVSS score match only the inequality.
+			return comparison.operator == "!="
+		}
+		order := 0
+		if finding.cvss > comparison.cvss {
+			order = 1
+		} else if finding.cvss < comparison.cvss {
+			order = -1
+		}
+		return compareFailValues(comparison.operator, order == 0, order)
+	}
+	return false
+}
+
+// Returns the result of the operator, according to the equality and the order of the compared values.
+func compareFailValues(operator string, equal bool, order int) bool {
+	switch operator {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	}
+	return false
+}
+
+// CheckFailConditions evaluates the fail conditions over the SCA and JAS findings of the results.
+// Returns an error with the ExitCodeVulnerableBuild exit code and a summary of the matched conditions, if any of the conditions matched.
+func CheckFailConditions(results *Results, conditions []*FailCondition) error {
+	if len(conditions) == 0 {
+		return nil
+	}
+	findings, err := getFailFindings(results)
+	if err != nil {
+		return err
+	}
+	var summaries []string
+	for _, condition := range conditions {
+		var matched []string
+		for _, finding := range findings {
+			if condition.match(finding) && !slices.Contains(matched, finding.description) {
+				matched = append(matched, finding.description)
+			}
+		}
+		if len(matched) > 0 {
+			// The order of the SCA findings isn't stable between runs, so the matched findings are sorted.
+			slices.Sort(matched)
+			summaries = append(summaries, getFailConditionSummary(condition, matched))
+		}
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+	return coreutils.CliError{ExitCode: coreutils.ExitCodeVulnerableBuild, ErrorMsg: "The build failed by the following fail conditions:\n" + strings.Join(summaries, "\n")}
+}
+
+func getFailConditionSummary(condition *FailCondition, matched []string) string {
+	findingsWord := "findings"
+	if len(matched) == 1 {
+		findingsWord = "finding"
+	}
+	listed := matched
+	if len(listed) > maxFailSummaryFindings {
+		listed = append(slices.Clone(matched[:maxFailSummaryFindings]), fmt.Sprintf("and %d more", len(matched)-maxFailSummaryFindings))
+	}
+	return fmt.Sprintf("- '%s' matched %d %s: %s", condition.name, len(matched), findingsWord, strings.Join(listed, ", "))
+}
+
+func getFailFindings(results *Results) (findings []failFinding, err error) {
+	jsonTable, err := ConvertXrayScanToSimpleJson(results, true, false, false, nil)
+	if err != nil {
+		return
+	}
+	for _, vulnerability := range jsonTable.Vulnerabilities {
+		findings = append(findings, newScaFailFinding("vulnerability", vulnerability))
+	}
+	for _, violation := range jsonTable.SecurityViolations {
+		findings = append(findings, newScaFailFinding("violation", violation))
+	}
+	for _, license := range jsonTable.LicensesViolations {
+		findings = append(findings, failFinding{
+			findingType: "violation",
+			severity:    normalizeFailSeverity(license.Severity),
+			cvss:        -1,
+			applicable:  "not_scanned",
+			description: fmt.Sprintf("%s in %s", license.LicenseKey, getDependencyDisplayName(license.ImpactedDependencyName, license.ImpactedDependencyVersion)),
+		})
+	}
+	for _, operationalRisk := range jsonTable.OperationalRiskViolations {
+		findings = append(findings, failFinding{
+			findingType: "violation",
+			severity:    normalizeFailSeverity(operationalRisk.Severity),
+			cvss:        -1,
+			applicable:  "not_scanned",
+			description: "operational risk in " + getDependencyDisplayName(operationalRisk.ImpactedDependencyName, operationalRisk.ImpactedDependencyVersion),
+		})
+	}
+	extended := results.ExtendedScanResults
+	findings = append(findings, newSourceCodeFailFindings("secret", PrepareSecrets(extended.SecretsScanResults))...)
+	findings = append(findings, newSourceCodeFailFindings("iac", PrepareIacs(extended.IacScanResults))...)
+	findings = append(findings, newSourceCodeFailFindings("sast", PrepareSast(extended.SastScanResults))...)
+	return
+}
+
+func newScaFailFinding(findingType string, row formats.VulnerabilityOrViolationRow) failFinding {
+	finding := failFinding{
+		findingType: findingType,
+		severity:    normalizeFailSeverity(row.Severity),
+		cvss:        -1,
+		applicable:  normalizeFailApplicability(row.Applicable),
+		fixable:     len(row.FixedVersions) > 0,
+		description: fmt.Sprintf("%s in %s", GetIssueIdentifier(row.Cves, row.IssueId), getDependencyDisplayName(row.ImpactedDependencyName, row.ImpactedDependencyVersion)),
+	}
+	// The highest score of the CVEs of the finding, preferring CVSS v3.
+	for _, cve := range row.Cves {
+		scoreVal := cve.CvssV3
+		if scoreVal == "" {
+			scoreVal = cve.CvssV2
+		}
+		if score, err := strconv.ParseFloat(scoreVal, 64); err == nil && score > finding.cvss {
+			finding.cvss = score
+		}
+	}
+	return finding
+}
+
+func newSourceCodeFailFindings(findingType string, rows []formats.SourceCodeRow) (findings []failFinding) {
+	for _, row := range rows {
+		findings = append(findings, failFinding{
+			findingType: findingType,
+			severity:    normalizeFailSeverity(row.Severity),
+			cvss:        -1,
+			applicable:  "not_scanned",
+			description: fmt.Sprintf("%s in %s:%d", findingType, row.File, row.StartLine),
+		})
+	}
+	return
+}
+
+// Converts a severity to the severities of the fail conditions. A finding without a known severity has the 'unknown' severity.
+func normalizeFailSeverity(severity string) string {
+	if severity = strings.ToLower(strings.TrimSpace(severity)); slices.Contains(failSeverities, severity) {
+		return severity
+	}
+	return "unknown"
+}
+
+// Converts an applicability status to the statuses of the fail conditions, for example: 'Not Applicable' to 'not_applicable'.
+// A finding without an applicability status wasn't scanned by the contextual analysis.
+func normalizeFailApplicability(applicability string) string {
+	if applicability == "" {
+		return "not_scanned"
+	}
+	return strings.ReplaceAll(strings.ToLower(applicability), " ", "_")
+}
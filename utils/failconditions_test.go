@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailCondition(t *testing.T) {
+	condition, err := ParseFailCondition("severity>=high && applicable!=not_applicable || type = secret")
+	require.NoError(t, err)
+	assert.Equal(t, [][]failComparison{
+		{{field: "severity", operator: ">=", value: "high"}, {field: "applicable", operator: "!=", value: "not_applicable"}},
+		{{field: "type", operator: "==", value: "secret"}},
+	}, condition.alternatives)
+
+	condition, err = ParseFailOnCvssCondition("9")
+	require.NoError(t, err)
+	assert.Equal(t, [][]failComparison{{{field: "cvss", operator: ">=", value: "9", cvss: 9}}}, condition.alternatives)
+
+	testCases := []struct {
+		expression    string
+		expectedError string
+	}{
+		{expression: "severity", expectedError: "'severity' isn't a <field><operator><value> comparison"},
+		{expression: "severity>=high &&", expectedError: "'' isn't a <field><operator><value> comparison"},
+		{expression: "score>=9", expectedError: "unknown field 'score', the supported fields are: type, severity, cvss, applicable, fixable"},
+		{expression: "severity>=severe", expectedError: "invalid severity 'severe', the supported values are: unknown, low, medium, high, critical"},
+		{expression: "applicable>applicable", expectedError: "the '>' operator isn't supported for the applicable field"},
+		{expression: "type==license", expectedError: "invalid type 'license'"},
+		{expression: "fixable==yes", expectedError: "invalid fixable 'yes'"},
+		{expression: "cvss>=11", expectedError: "invalid CVSS score '11', expected a number between 0 and 10"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			_, err := ParseFailCondition(testCase.expression)
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+	_, err = ParseFailOnCvssCondition("high")
+	assert.ErrorContains(t, err, "invalid CVSS score to fail on: invalid CVSS score 'high'")
+}
+
+func TestFailConditionMatch(t *testing.T) {
+	criticalApplicable := failFinding{findingType: "vulnerability", severity: "critical", cvss: 9.8, applicable: "applicable", fixable: true}
+	highNotApplicable := failFinding{findingType: "vulnerability", severity: "high", cvss: 7.5, applicable: "not_applicable"}
+	mediumWithoutScore := failFinding{findingType: "violation", severity: "medium", cvss: -1, applicable: "not_scanned"}
+	secret := failFinding{findingType: "secret", severity: "medium", cvss: -1, applicable: "not_scanned"}
+	withoutSeverity := failFinding{findingType: "violation", severity: normalizeFailSeverity(""), cvss: -1, applicable: normalizeFailApplicability("")}
+
+	testCases := []struct {
+		expression string
+		expected   []bool
+	}{
+		{expression: "severity>=high && applicable!=not_applicable", expected: []bool{true, false, false, false, false}},
+		{expression: "severity<high", expected: []bool{false, false, true, true, true}},
+		{expression: "severity<=unknown", expected: []bool{false, false, false, false, true}},
+		{expression: "severity>=low", expected: []bool{true, true, true, true, false}},
+		{expression: "severity==medium && type!=secret", expected: []bool{false, false, true, false, false}},
+		{expression: "cvss>=7.5", expected: []bool{true, true, false, false, false}},
+		{expression: "cvss<9", expected: []bool{false, true, false, false, false}},
+		{expression: "cvss!=9.8", expected: []bool{false, true, true, true, true}},
+		{expression: "fixable==true || type==secret", expected: []bool{true, false, false, true, false}},
+		{expression: "applicable==not_scanned", expected: []bool{false, false, true, true, true}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			condition, err := ParseFailCondition(testCase.expression)
+			require.NoError(t, err)
+			for i, finding := range []failFinding{criticalApplicable, highNotApplicable, mediumWithoutScore, secret, withoutSeverity} {
+				assert.Equal(t, testCase.expected[i], condition.match(finding), "finding %d", i)
+			}
+		})
+	}
+}
+
+func TestCheckFailConditions(t *testing.T) {
+	results := createSuppressionsTestResults(t.TempDir())
+	results.ScaResults[0].XrayResults[0].Vulnerabilities[0].Cves[0].CvssV3Score = "9.1"
+
+	highSeverity, err := ParseFailCondition("severity>=high && type==vulnerability")
+	require.NoError(t, err)
+	onCvss, err := ParseFailOnCvssCondition("9.5")
+	require.NoError(t, err)
+	assert.NoError(t, CheckFailConditions(results, nil))
+	assert.NoError(t, CheckFailConditions(results, []*FailCondition{onCvss}))
+
+	err = CheckFailConditions(results, []*FailCondition{highSeverity, onCvss, NewFailOnSecretsCondition()})
+	var cliError coreutils.CliError
+	require.True(t, errors.As(err, &cliError))
+	assert.Equal(t, coreutils.ExitCodeVulnerableBuild, cliError.ExitCode)
+	assert.Equal(t, "The build failed by the following fail conditions:\n"+
+		"- 'severity>=high && type==vulnerability' matched 2 findings: CVE-2024-1234 in lodash:4.17.0, CVE-2024-1234 in lodash:4.17.21\n"+
+		"- 'fail on secrets' matched 2 findings: secret in src/config.js:1, secret in tests/fixture.env:1", cliError.ErrorMsg)
+}
+
+func TestNormalizeFailValues(t *testing.T) {
+	assert.Equal(t, "critical", normalizeFailSeverity("Critical"))
+	assert.Equal(t, "unknown", normalizeFailSeverity(""))
+	assert.Equal(t, "unknown", normalizeFailSeverity("Information"))
+	assert.Equal(t, "not_applicable", normalizeFailApplicability("Not Applicable"))
+	assert.Equal(t, "not_scanned", normalizeFailApplicability(""))
+}
+
+func TestGetFailConditionSummary(t *testing.T) {
+	var matched []string
+	for i := 1; i <= 7; i++ {
+		matched = append(matched, fmt.Sprintf("CVE-%d in a:1.0.0", i))
+	}
+	condition := &FailCondition{name: "cvss>=9"}
+	assert.Equal(t, "- 'cvss>=9' matched 7 findings: CVE-1 in a:1.0.0, CVE-2 in a:1.0.0, CVE-3 in a:1.0.0, CVE-4 in a:1.0.0, CVE-5 in a:1.0.0, and 2 more", getFailConditionSummary(condition, matched))
+	assert.Equal(t, "- 'cvss>=9' matched 1 finding: CVE-1 in a:1.0.0", getFailConditionSummary(condition, matched[:1]))
+	assert.Len(t, matched, 7)
+}
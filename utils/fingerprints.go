@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+)
+
+// The key of the fingerprint in the SARIF results' partialFingerprints. The version should be bumped if the fingerprint's calculation changes.
+const JfrogFingerprintKey = "jfrogFingerprint/v1"
+
+// GetScaFingerprint returns a stable fingerprint of an SCA issue that is introduced by a direct dependency.
+// The versions are not included, so the fingerprint is kept when a dependency is upgraded to a version that is still vulnerable.
+func GetScaFingerprint(issueId, impactedDependencyName, directDependencyName string) string {
+	return calculateFingerprint(issueId, impactedDependencyName, directDependencyName)
+}
+
+// GetSourceCodeFingerprint returns a stable fingerprint of a Secrets, IaC, SAST or Contextual Analysis finding.
+// The line numbers are not included, so the fingerprint is kept when the finding moves to another line.
+func GetSourceCodeFingerprint(ruleId, relativeFilePath, snippet string) string {
+	return calculateFingerprint(ruleId, filepath.ToSlash(relativeFilePath), normalizeSnippet(snippet))
+}
+
+func calculateFingerprint(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Changes in the indentation or line breaks of the snippet shouldn't change the fingerprint.
+func normalizeSnippet(snippet string) string {
+	return strings.Join(strings.Fields(snippet), " ")
+}
+
+// Sets the fingerprint of each direct dependency of the security issues and license violations in the simple-json results.
+func setScaFingerprints(jsonTable *formats.SimpleJsonResults) {
+	for _, issues := range [][]formats.VulnerabilityOrViolationRow{jsonTable.Vulnerabilities, jsonTable.SecurityViolations} {
+		for i := range issues {
+			issues[i].Components = getDirectDependenciesWithFingerprints(GetIssueIdentifier(issues[i].Cves, issues[i].IssueId), issues[i].ImpactedDependencyName, issues[i].Components)
+		}
+	}
+	for i := range jsonTable.LicensesViolations {
+		license := &jsonTable.LicensesViolations[i]
+		license.Components = getDirectDependenciesWithFingerprints(license.LicenseKey, license.ImpactedDependencyName, license.Components)
+	}
+}
+
+// The components are copied, since the same slice may be shared by several rows.
+func getDirectDependenciesWithFingerprints(issueId, impactedDependencyName string, directDependencies []formats.ComponentRow) []formats.ComponentRow {
+	if len(directDependencies) == 0 {
+		return directDependencies
+	}
+	withFingerprints := make([]formats.ComponentRow, 0, len(directDependencies))
+	for _, directDependency := range directDependencies {
+		directDependency.Fingerprint = GetScaFingerprint(issueId, impactedDependencyName, directDependency.Name)
+		withFingerprints = append(withFingerprints, directDependency)
+	}
+	return withFingerprints
+}
+
+// Sets the partial fingerprints of the source code findings, according to the first location of each result.
+func setSourceCodeSarifFingerprints(runs []*sarif.Run) {
+	for _, run := range runs {
+		for _, result := range run.Results {
+			if len(result.Locations) == 0 {
+				continue
+			}
+			location := result.Locations[0]
+			setResultFingerprint(result, GetSourceCodeFingerprint(GetResultRuleId(result), GetRelativeLocationFileName(location, run.Invocations), GetLocationSnippet(location)))
+		}
+	}
+}
+
+func setResultFingerprint(result *sarif.Result, fingerprint string) {
+	if result.PartialFingerprints == nil {
+		result.PartialFingerprints = map[string]interface{}{}
+	}
+	result.SetPartialFingerPrint(JfrogFingerprintKey, fingerprint)
+}
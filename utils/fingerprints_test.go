@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSourceCodeFingerprint(t *testing.T) {
+	fingerprint := GetSourceCodeFingerprint("rule1", "src/main.go", "password := \"secret\"")
+	assert.Len(t, fingerprint, 64)
+	assert.Equal(t, fingerprint, GetSourceCodeFingerprint("rule1", "src/main.go", "  password :=\n\t\"secret\" "))
+	assert.NotEqual(t, fingerprint, GetSourceCodeFingerprint("rule2", "src/main.go", "password := \"secret\""))
+	assert.NotEqual(t, fingerprint, GetSourceCodeFingerprint("rule1", "src/other.go", "password := \"secret\""))
+	assert.NotEqual(t, fingerprint, GetSourceCodeFingerprint("rule1", "src/main.go", "token := \"secret\""))
+}
+
+func TestGetScaFingerprint(t *testing.T) {
+	fingerprint := GetScaFingerprint("CVE-2024-1234", "lodash", "express")
+	assert.Equal(t, fingerprint, GetScaFingerprint("CVE-2024-1234", "lodash", "express"))
+	assert.NotEqual(t, fingerprint, GetScaFingerprint("CVE-2024-1234", "lodash", "react"))
+	assert.NotEqual(t, fingerprint, GetScaFingerprint("CVE-2024-5678", "lodash", "express"))
+	// The parts are separated, so different splits of the same text don't collide.
+	assert.NotEqual(t, GetScaFingerprint("a", "bc", "d"), GetScaFingerprint("ab", "c", "d"))
+}
+
+func TestSarifAndSimpleJsonFingerprints(t *testing.T) {
+	results := NewAuditResults()
+	results.ExtendedScanResults.EntitledForJas = true
+	results.ScaResults = []ScaScanResult{{
+		Technology: coreutils.Npm,
+		XrayResults: []services.ScanResponse{{Vulnerabilities: []services.Vulnerability{{
+			IssueId:  "XRAY-1",
+			Severity: "High",
+			Cves:     []services.Cve{{Id: "CVE-2024-1234"}},
+			Components: map[string]services.Component{"npm://lodash:4.17.0": {ImpactPaths: [][]services.ImpactPathNode{
+				{{ComponentId: "npm://root:1.0.0"}, {ComponentId: "npm://express:4.0.0"}, {ComponentId: "npm://lodash:4.17.0"}},
+			}}},
+		}}}},
+	}}
+	invocation := sarif.NewInvocation().WithWorkingDirectory(sarif.NewSimpleArtifactLocation("wd"))
+	results.ExtendedScanResults.SecretsScanResults = []*sarif.Run{
+		CreateRunWithDummyResults(CreateResultWithOneLocation("file://wd/file", 1, 2, 3, 4, "secret-snippet", "secret-rule", "note")).WithInvocations([]*sarif.Invocation{invocation}),
+	}
+
+	writer := NewResultsWriter(results).SetIncludeVulnerabilities(true).SetIsMultipleRootProject(true)
+	jsonTable, err := writer.convertScanToSimpleJson()
+	require.NoError(t, err)
+	require.Len(t, jsonTable.Vulnerabilities, 1)
+	require.Len(t, jsonTable.Vulnerabilities[0].Components, 1)
+	require.Len(t, jsonTable.Secrets, 1)
+	scaFingerprint := jsonTable.Vulnerabilities[0].Components[0].Fingerprint
+	assert.Equal(t, GetScaFingerprint("CVE-2024-1234", "lodash", "express"), scaFingerprint)
+	secretFingerprint := jsonTable.Secrets[0].Fingerprint
+	assert.Equal(t, GetSourceCodeFingerprint("secret-rule", "file", "secret-snippet"), secretFingerprint)
+
+	report, err := GenereateSarifReportFromResults(results, true, false, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Runs[0].Results, 1)
+	assert.Equal(t, scaFingerprint, report.Runs[0].Results[0].PartialFingerprints[JfrogFingerprintKey])
+	secretsRun := report.Runs[len(report.Runs)-1]
+	require.Len(t, secretsRun.Results, 1)
+	assert.Equal(t, secretFingerprint, secretsRun.Results[0].PartialFingerprints[JfrogFingerprintKey])
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Returns the absolute paths of the files that changed in the git repositories of the given directories since the given git reference.
+// The changes are compared to the merge base of the reference and HEAD, so only the changes of the current branch are returned.
+// Uncommitted and untracked files are included, as well as deleted files, since removing a file may change the scan results too.
+func GetChangedFiles(dirs []string, ref string) ([]string, error) {
+	changedFiles := datastructures.MakeSet[string]()
+	for _, dir := range dirs {
+		// The root is resolved relative to the directory, so the paths of the files are in the same form as the given directories.
+		pathToRoot, err := runGitCommand(dir, "rev-parse", "--show-cdup")
+		if err != nil {
+			return nil, err
+		}
+		repoRoot := filepath.Join(dir, filepath.FromSlash(pathToRoot))
+		mergeBase, err := runGitCommand(dir, "merge-base", ref, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		diff, err := runGitCommand(dir, "diff", "--name-only", "--no-renames", mergeBase, "--")
+		if err != nil {
+			return nil, err
+		}
+		untracked, err := runGitCommand(dir, "ls-files", "--others", "--exclude-standard", "--full-name")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range append(splitGitOutputLines(diff), splitGitOutputLines(untracked)...) {
+			changedFiles.Add(filepath.Join(repoRoot, filepath.FromSlash(file)))
+		}
+	}
+	files := changedFiles.ToSlice()
+	sort.Strings(files)
+	return files, nil
+}
+
+func runGitCommand(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errorutils.CheckErrorf("'git %s' failed in '%s': %s %s", strings.Join(args, " "), dir, err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func splitGitOutputLines(output string) (lines []string) {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return
+}
+
+// Returns the directories of the changed files that are in one of the given roots, to limit the scanned roots to the changed files.
+// Directories that are inside another returned directory are omitted, so each file is scanned once.
+func GetChangedDirs(roots []string, changedFiles []string) []string {
+	changedDirs := datastructures.MakeSet[string]()
+	for _, file := range changedFiles {
+		for _, root := range roots {
+			if IsPathInDir(file, root) {
+				changedDirs.Add(filepath.Dir(file))
+				break
+			}
+		}
+	}
+	var dirs []string
+	for _, dir := range changedDirs.ToSlice() {
+		if exists, err := fileutils.IsDirExists(dir, false); err != nil || !exists {
+			// The directory of a deleted file may be deleted as well.
+			continue
+		}
+		isNested := false
+		for _, other := range changedDirs.ToSlice() {
+			if other != dir && IsPathInDir(dir, other) {
+				isNested = true
+				break
+			}
+		}
+		if !isNested {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Returns true if the path is the given directory or inside it.
+func IsPathInDir(path, dir string) bool {
+	relative, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return relative == "." || (relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChangedFiles(t *testing.T) {
+	repoDir := t.TempDir()
+	runGit := func(args ...string) {
+		output, err := exec.Command("git", append([]string{"-C", repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+		assert.NoError(t, err, string(output))
+	}
+	writeFile := func(path, content string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repoDir, path)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(repoDir, path), []byte(content), 0644))
+	}
+	runGit("init", "-q", "-b", "main")
+	writeFile("README.md", "readme")
+	writeFile("web/package.json", "{}")
+	writeFile("api/go.mod", "module api")
+	runGit("add", "-A")
+	runGit("commit", "-q", "-m", "initial")
+	runGit("checkout", "-q", "-b", "feature")
+	writeFile("web/package.json", `{"name": "web"}`)
+	runGit("commit", "-q", "-am", "change web")
+	// Changes of the reference after the merge base aren't included.
+	runGit("checkout", "-q", "main")
+	writeFile("api/go.mod", "module api\n\ngo 1.21")
+	runGit("commit", "-q", "-am", "change api")
+	runGit("checkout", "-q", "feature")
+	// Uncommitted and untracked changes are included.
+	writeFile("README.md", "changed readme")
+	writeFile("api/main.go", "package main")
+
+	changedFiles, err := GetChangedFiles([]string{repoDir, filepath.Join(repoDir, "web")}, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repoDir, "README.md"), filepath.Join(repoDir, "api", "main.go"), filepath.Join(repoDir, "web", "package.json")}, changedFiles)
+
+	_, err = GetChangedFiles([]string{repoDir}, "no-such-ref")
+	assert.Error(t, err)
+}
+
+func TestGetChangedDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"web", filepath.Join("web", "src"), "api", "docs"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+	changedFiles := []string{
+		filepath.Join(root, "web", "src", "index.js"),
+		filepath.Join(root, "web", "package.json"),
+		filepath.Join(root, "api", "main.go"),
+		filepath.Join(root, "deleted", "file.go"),
+		filepath.Join(root, "docs", "README.md"),
+		filepath.Join(filepath.Dir(root), "outside.txt"),
+	}
+	assert.Equal(t, []string{filepath.Join(root, "api"), filepath.Join(root, "web")}, GetChangedDirs([]string{filepath.Join(root, "web"), filepath.Join(root, "api")}, changedFiles))
+	assert.Empty(t, GetChangedDirs([]string{filepath.Join(root, "other")}, changedFiles))
+}
+
+func TestIsPathInDir(t *testing.T) {
+	dir := filepath.Join("root", "dir")
+	assert.True(t, IsPathInDir(dir, dir))
+	assert.True(t, IsPathInDir(filepath.Join(dir, "file"), dir))
+	assert.True(t, IsPathInDir(filepath.Join(dir, "..file"), dir))
+	assert.False(t, IsPathInDir(filepath.Join("root", "dir2", "file"), dir))
+	assert.False(t, IsPathInDir("root", dir))
+}
@@ -0,0 +1,325 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+const (
+	gitlabReportSchemaVersion    = "15.0.7"
+	gitlabDependencyScanningType = "dependency_scanning"
+	gitlabSastType               = "sast"
+	gitlabSecretDetectionType    = "secret_detection"
+	gitlabTimeFormat             = "2006-01-02T15:04:05"
+	gitlabScanStatusSuccess      = "success"
+	gitlabScanStatusFailure      = "failure"
+	gitlabScanMessageLevelFatal  = "fatal"
+	// Set by GitLab CI to the commit being scanned. Secret detection findings must reference a commit.
+	gitlabCommitShaEnv     = "CI_COMMIT_SHA"
+	gitlabUnknownCommitSha = "0000000"
+	jfrogVendorName        = "JFrog"
+)
+
+// The structs below describe the GitLab security report schemas (dependency scanning, SAST and secret detection).
+// See https://gitlab.com/gitlab-org/security-products/security-report-schemas
+type GitlabReport struct {
+	Version         string                  `json:"version"`
+	Scan            GitlabScan              `json:"scan"`
+	Vulnerabilities []GitlabVulnerability   `json:"vulnerabilities"`
+	DependencyFiles *[]GitlabDependencyFile `json:"dependency_files,omitempty"`
+}
+
+type GitlabScan struct {
+	Analyzer  GitlabScanner       `json:"analyzer"`
+	Scanner   GitlabScanner       `json:"scanner"`
+	Type      string              `json:"type"`
+	StartTime string              `json:"start_time"`
+	EndTime   string              `json:"end_time"`
+	Status    string              `json:"status"`
+	Messages  []GitlabScanMessage `json:"messages,omitempty"`
+}
+
+type GitlabScanMessage struct {
+	Level string `json:"level"`
+	Value string `json:"value"`
+}
+
+type GitlabScanner struct {
+	Id      string       `json:"id"`
+	Name    string       `json:"name"`
+	Version string       `json:"version"`
+	Vendor  GitlabVendor `json:"vendor"`
+}
+
+type GitlabVendor struct {
+	Name string `json:"name"`
+}
+
+type GitlabVulnerability struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Severity    string             `json:"severity"`
+	Solution    string             `json:"solution,omitempty"`
+	Identifiers []GitlabIdentifier `json:"identifiers"`
+	Links       []GitlabLink       `json:"links,omitempty"`
+	Location    GitlabLocation     `json:"location"`
+}
+
+type GitlabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Url   string `json:"url,omitempty"`
+}
+
+type GitlabLink struct {
+	Url string `json:"url"`
+}
+
+type GitlabLocation struct {
+	File       string            `json:"file,omitempty"`
+	StartLine  int               `json:"start_line,omitempty"`
+	EndLine    int               `json:"end_line,omitempty"`
+	Dependency *GitlabDependency `json:"dependency,omitempty"`
+	Commit     *GitlabCommit     `json:"commit,omitempty"`
+}
+
+type GitlabDependency struct {
+	Package GitlabPackage `json:"package"`
+	Version string        `json:"version"`
+	Direct  bool          `json:"direct,omitempty"`
+}
+
+type GitlabPackage struct {
+	Name string `json:"name"`
+}
+
+type GitlabCommit struct {
+	Sha string `json:"sha"`
+}
+
+type GitlabDependencyFile struct {
+	Path           string             `json:"path"`
+	PackageManager string             `json:"package_manager"`
+	Dependencies   []GitlabDependency `json:"dependencies"`
+}
+
+// GenerateGitlabDependencyScanningReport converts the SCA vulnerabilities and security violations to a GitLab dependency scanning report.
+// The findings are located in the descriptor of the project they were found in, and the Xray fixed versions are set as their solution.
+// If the scan of a project failed, the status of the report is 'failure' and the scan error is added to its messages.
+func GenerateGitlabDependencyScanningReport(results *Results, isMultipleRoots bool) (*GitlabReport, error) {
+	report := newGitlabReport(gitlabDependencyScanningType, xrayToolName, results.XrayVersion)
+	dependencyFiles := []GitlabDependencyFile{}
+	reportedIds := datastructures.MakeSet[string]()
+	for _, scaResult := range results.ScaResults {
+		if scaResult.ScanError != nil {
+			report.Scan.Status = gitlabScanStatusFailure
+			report.Scan.Messages = append(report.Scan.Messages, GitlabScanMessage{
+				Level: gitlabScanMessageLevelFatal,
+				Value: fmt.Sprintf("The scan of %s failed: %s", getGitlabRelativePath(scaResult.WorkingDirectory), scaResult.ScanError.Error()),
+			})
+			continue
+		}
+		scanResults := &Results{ScaResults: []ScaScanResult{scaResult}, ExtendedScanResults: results.ExtendedScanResults}
+		xrayJson, err := ConvertXrayScanToSimpleJson(scanResults, isMultipleRoots, false, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		descriptorPath := getGitlabDescriptorPath(scaResult)
+		for _, issue := range append(xrayJson.Vulnerabilities, xrayJson.SecurityViolations...) {
+			issueId := GetIssueIdentifier(issue.Cves, issue.IssueId)
+			id := getGitlabVulnerabilityId(descriptorPath, issueId, issue.ImpactedDependencyName, issue.ImpactedDependencyVersion)
+			if reportedIds.Exists(id) {
+				// The same issue may be returned both as a vulnerability and as a violation.
+				continue
+			}
+			reportedIds.Add(id)
+			vulnerability := GitlabVulnerability{
+				Id:          id,
+				Name:        fmt.Sprintf("%s in %s", issueId, getDependencyDisplayName(issue.ImpactedDependencyName, issue.ImpactedDependencyVersion)),
+				Description: issue.Summary,
+				Severity:    toGitlabSeverity(issue.Severity),
+				Solution:    getGitlabSolution(issue.ImpactedDependencyName, issue.FixedVersions),
+				Location: GitlabLocation{
+					File: descriptorPath,
+					Dependency: &GitlabDependency{
+						Package: GitlabPackage{Name: issue.ImpactedDependencyName},
+						Version: issue.ImpactedDependencyVersion,
+						Direct:  isDirectComponent(issue.ImpactedDependencyName, issue.ImpactedDependencyVersion, issue.Components),
+					},
+				},
+			}
+			for _, cve := range issue.Cves {
+				if cve.Id != "" {
+					vulnerability.Identifiers = append(vulnerability.Identifiers, GitlabIdentifier{Type: "cve", Name: cve.Id, Value: cve.Id, Url: nvdVulnerabilityUrl + cve.Id})
+				}
+			}
+			if issue.IssueId != "" {
+				vulnerability.Identifiers = append(vulnerability.Identifiers, GitlabIdentifier{Type: "jfrog_xray", Name: issue.IssueId, Value: issue.IssueId})
+			}
+			for _, reference := range issue.References {
+				vulnerability.Links = append(vulnerability.Links, GitlabLink{Url: reference})
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, vulnerability)
+		}
+		dependencyFiles = append(dependencyFiles, getGitlabDependencyFile(scanResults, descriptorPath))
+	}
+	report.DependencyFiles = &dependencyFiles
+	return report, nil
+}
+
+// GenerateGitlabSastReport converts the SAST findings to a GitLab SAST report.
+func GenerateGitlabSastReport(results *Results) *GitlabReport {
+	report := newGitlabReport(gitlabSastType, "JFrog SAST", GetAnalyzerManagerVersion())
+	report.Vulnerabilities = getGitlabSourceCodeVulnerabilities(results.ExtendedScanResults.SastScanResults, "jfrog_sast", nil)
+	return report
+}
+
+// GenerateGitlabSecretDetectionReport converts the secrets findings to a GitLab secret detection report.
+func GenerateGitlabSecretDetectionReport(results *Results) *GitlabReport {
+	report := newGitlabReport(gitlabSecretDetectionType, "JFrog Secrets Detection", GetAnalyzerManagerVersion())
+	commitSha := os.Getenv(gitlabCommitShaEnv)
+	if commitSha == "" {
+		commitSha = gitlabUnknownCommitSha
+	}
+	report.Vulnerabilities = getGitlabSourceCodeVulnerabilities(results.ExtendedScanResults.SecretsScanResults, "jfrog_secrets", &GitlabCommit{Sha: commitSha})
+	return report
+}
+
+func ConvertGitlabReportToString(report *GitlabReport) (string, error) {
+	out, err := json.Marshal(report)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return clientUtils.IndentJson(out), nil
+}
+
+func newGitlabReport(scanType, scannerName, scannerVersion string) *GitlabReport {
+	scanner := GitlabScanner{
+		Id:      strings.ReplaceAll(strings.ToLower(scannerName), " ", "-"),
+		Name:    scannerName,
+		Version: scannerVersion,
+		Vendor:  GitlabVendor{Name: jfrogVendorName},
+	}
+	now := time.Now().UTC().Format(gitlabTimeFormat)
+	return &GitlabReport{
+		Version:         gitlabReportSchemaVersion,
+		Scan:            GitlabScan{Analyzer: scanner, Scanner: scanner, Type: scanType, StartTime: now, EndTime: now, Status: gitlabScanStatusSuccess},
+		Vulnerabilities: []GitlabVulnerability{},
+	}
+}
+
+func getGitlabSourceCodeVulnerabilities(runs []*sarif.Run, identifierType string, commit *GitlabCommit) []GitlabVulnerability {
+	vulnerabilities := []GitlabVulnerability{}
+	for _, run := range runs {
+		for _, result := range run.Results {
+			ruleId := ""
+			if result.RuleID != nil {
+				ruleId = *result.RuleID
+			}
+			description := ""
+			if rule, err := run.GetRuleById(ruleId); err == nil {
+				description = GetRuleFullDescription(rule)
+			}
+			for _, location := range result.Locations {
+				file := getGitlabRelativePath(GetLocationFileName(location))
+				vulnerabilities = append(vulnerabilities, GitlabVulnerability{
+					Id:          getGitlabVulnerabilityId(file, ruleId, fmt.Sprint(GetLocationStartLine(location)), fmt.Sprint(GetLocationStartColumn(location))),
+					Name:        GetResultMsgText(result),
+					Description: description,
+					Severity:    toGitlabSeverity(GetResultSeverity(result)),
+					Identifiers: []GitlabIdentifier{{Type: identifierType, Name: ruleId, Value: ruleId}},
+					Location: GitlabLocation{
+						File:      file,
+						StartLine: GetLocationStartLine(location),
+						EndLine:   GetLocationEndLine(location),
+						Commit:    commit,
+					},
+				})
+			}
+		}
+	}
+	return vulnerabilities
+}
+
+func getGitlabDependencyFile(scanResults *Results, descriptorPath string) GitlabDependencyFile {
+	packageManager := scanResults.ScaResults[0].Technology.String()
+	if packageManager == "" {
+		packageManager = "generic"
+	}
+	dependencyFile := GitlabDependencyFile{Path: descriptorPath, PackageManager: packageManager, Dependencies: []GitlabDependency{}}
+	for _, componentId := range buildComponentsGraph(scanResults).componentIds() {
+		name, version, _ := SplitComponentId(componentId)
+		dependencyFile.Dependencies = append(dependencyFile.Dependencies, GitlabDependency{Package: GitlabPackage{Name: name}, Version: version})
+	}
+	return dependencyFile
+}
+
+// GitLab expects the locations to be relative to the repository root, which is the working directory of the CI job.
+func getGitlabDescriptorPath(scaResult ScaScanResult) string {
+	if len(scaResult.Descriptors) > 0 {
+		return getGitlabRelativePath(scaResult.Descriptors[0])
+	}
+	if descriptors := scaResult.Technology.GetPackageDescriptor(); len(descriptors) > 0 && scaResult.WorkingDirectory != "" {
+		return getGitlabRelativePath(filepath.Join(scaResult.WorkingDirectory, strings.TrimSpace(descriptors[0])))
+	}
+	return getGitlabRelativePath(scaResult.WorkingDirectory)
+}
+
+func getGitlabRelativePath(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "file:///private"), "file://")
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if relativePath, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(relativePath, "..") {
+			return filepath.ToSlash(relativePath)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// The id of a finding should stay the same between scans, so GitLab can track it.
+func getGitlabVulnerabilityId(parts ...string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(strings.Join(parts, "|"))).String()
+}
+
+func getGitlabSolution(dependencyName string, fixedVersions []string) string {
+	if len(fixedVersions) == 0 {
+		return ""
+	}
+	return getUpgradeRecommendation(dependencyName, fixedVersions)
+}
+
+func isDirectComponent(name, version string, directComponents []formats.ComponentRow) bool {
+	for _, component := range directComponents {
+		if component.Name == name && component.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func toGitlabSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high", "medium", "low":
+		return cases.Title(language.Und).String(severity)
+	case "information", "info":
+		return "Info"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateGitlabDependencyScanningReport(t *testing.T) {
+	vulnerability := services.Vulnerability{
+		IssueId:    "XRAY-1",
+		Severity:   "Critical",
+		Summary:    "Prototype pollution",
+		Cves:       []services.Cve{{Id: "CVE-2024-0001"}},
+		References: []string{"https://example.com/advisory"},
+		Components: map[string]services.Component{
+			"npm://lodash:4.17.20": {FixedVersions: []string{"[4.17.21]"}, ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "npm://project:1.0.0"}, {ComponentId: "npm://lodash:4.17.20"}}}},
+		},
+	}
+	results := NewAuditResults()
+	results.XrayVersion = "3.90.0"
+	results.ScaResults = []ScaScanResult{{
+		Technology:  coreutils.Npm,
+		Descriptors: []string{filepath.Join("frontend", "package.json")},
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{vulnerability},
+			Violations:      []services.Violation{{IssueId: "XRAY-1", Severity: "Critical", ViolationType: "security", Cves: vulnerability.Cves, Components: vulnerability.Components}},
+		}},
+	}}
+
+	report, err := GenerateGitlabDependencyScanningReport(results, true)
+	require.NoError(t, err)
+	assert.Equal(t, gitlabDependencyScanningType, report.Scan.Type)
+	assert.Equal(t, "3.90.0", report.Scan.Scanner.Version)
+	assert.Equal(t, gitlabScanStatusSuccess, report.Scan.Status)
+	assert.Empty(t, report.Scan.Messages)
+	require.Len(t, report.Vulnerabilities, 1)
+	gitlabVulnerability := report.Vulnerabilities[0]
+	assert.Equal(t, "CVE-2024-0001 in lodash:4.17.20", gitlabVulnerability.Name)
+	assert.Equal(t, "Critical", gitlabVulnerability.Severity)
+	assert.Equal(t, "Upgrade lodash to one of the following versions: [4.17.21]", gitlabVulnerability.Solution)
+	assert.Equal(t, []GitlabIdentifier{
+		{Type: "cve", Name: "CVE-2024-0001", Value: "CVE-2024-0001", Url: nvdVulnerabilityUrl + "CVE-2024-0001"},
+		{Type: "jfrog_xray", Name: "XRAY-1", Value: "XRAY-1"},
+	}, gitlabVulnerability.Identifiers)
+	assert.Equal(t, "frontend/package.json", gitlabVulnerability.Location.File)
+	assert.Equal(t, &GitlabDependency{Package: GitlabPackage{Name: "lodash"}, Version: "4.17.20", Direct: true}, gitlabVulnerability.Location.Dependency)
+
+	// The id must be stable between scans
+	secondReport, err := GenerateGitlabDependencyScanningReport(results, true)
+	require.NoError(t, err)
+	assert.Equal(t, gitlabVulnerability.Id, secondReport.Vulnerabilities[0].Id)
+
+	require.NotNil(t, report.DependencyFiles)
+	require.Len(t, *report.DependencyFiles, 1)
+	dependencyFile := (*report.DependencyFiles)[0]
+	assert.Equal(t, "frontend/package.json", dependencyFile.Path)
+	assert.Equal(t, "npm", dependencyFile.PackageManager)
+	assert.ElementsMatch(t, []GitlabDependency{{Package: GitlabPackage{Name: "project"}, Version: "1.0.0"}, {Package: GitlabPackage{Name: "lodash"}, Version: "4.17.20"}}, dependencyFile.Dependencies)
+
+	// A failed scan fails the report, with the scan error in its messages.
+	results.ScaResults = append(results.ScaResults, ScaScanResult{Technology: coreutils.Go, WorkingDirectory: "backend", ScanError: NewScaScanError(DependencyTreePhase, errors.New("go mod graph failed"))})
+	report, err = GenerateGitlabDependencyScanningReport(results, true)
+	require.NoError(t, err)
+	assert.Equal(t, gitlabScanStatusFailure, report.Scan.Status)
+	require.Len(t, report.Scan.Messages, 1)
+	assert.Equal(t, gitlabScanMessageLevelFatal, report.Scan.Messages[0].Level)
+	assert.Equal(t, "The scan of backend failed: the dependency tree build failed: go mod graph failed", report.Scan.Messages[0].Value)
+	assert.Len(t, report.Vulnerabilities, 1)
+}
+
+func TestGenerateGitlabSecretDetectionReport(t *testing.T) {
+	t.Setenv(gitlabCommitShaEnv, "abc123")
+	results := NewAuditResults()
+	results.ExtendedScanResults.SecretsScanResults = []*sarif.Run{
+		CreateRunWithDummyResults(CreateResultWithLocations("Hardcoded secret", "REQ.SECRET.KEYS", "error", CreateLocation("config/app.yaml", 3, 5, 3, 20, "key=***"))),
+	}
+	report := GenerateGitlabSecretDetectionReport(results)
+	assert.Equal(t, gitlabSecretDetectionType, report.Scan.Type)
+	assert.Nil(t, report.DependencyFiles)
+	require.Len(t, report.Vulnerabilities, 1)
+	secret := report.Vulnerabilities[0]
+	assert.Equal(t, "Hardcoded secret", secret.Name)
+	assert.Equal(t, "High", secret.Severity)
+	assert.Equal(t, []GitlabIdentifier{{Type: "jfrog_secrets", Name: "REQ.SECRET.KEYS", Value: "REQ.SECRET.KEYS"}}, secret.Identifiers)
+	assert.Equal(t, GitlabLocation{File: "config/app.yaml", StartLine: 3, EndLine: 3, Commit: &GitlabCommit{Sha: "abc123"}}, secret.Location)
+}
+
+func TestToGitlabSeverity(t *testing.T) {
+	assert.Equal(t, "Critical", toGitlabSeverity("critical"))
+	assert.Equal(t, "Medium", toGitlabSeverity("Medium"))
+	assert.Equal(t, "Info", toGitlabSeverity("Information"))
+	assert.Equal(t, "Unknown", toGitlabSeverity(""))
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const htmlReportTitle = "JFrog Security Scan Report"
+
+//go:embed resources/report.html
+var htmlReportTemplate string
+
+var htmlReportFuncs = template.FuncMap{
+	"join":       strings.Join,
+	"inc":        func(i int) int { return i + 1 },
+	"impactPath": getImpactPathFormatted,
+}
+
+type htmlReportData struct {
+	Title                  string
+	GeneratedAt            string
+	XrayVersion            string
+	EntitledForJas         bool
+	IncludeVulnerabilities bool
+	Results                formats.SimpleJsonResults
+}
+
+// ConvertSimpleJsonToHtmlReport renders the simple-json results as a single HTML page, with no external resources, so it can be viewed offline.
+func ConvertSimpleJsonToHtmlReport(jsonTable formats.SimpleJsonResults, results *Results, includeVulnerabilities bool) (string, error) {
+	reportTemplate, err := template.New("report").Funcs(htmlReportFuncs).Parse(htmlReportTemplate)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	data := htmlReportData{
+		Title:                  htmlReportTitle,
+		GeneratedAt:            time.Now().Format(time.RFC1123),
+		XrayVersion:            results.XrayVersion,
+		EntitledForJas:         results.ExtendedScanResults.EntitledForJas,
+		IncludeVulnerabilities: includeVulnerabilities,
+		Results:                jsonTable,
+	}
+	var out bytes.Buffer
+	if err = reportTemplate.Execute(&out, data); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return out.String(), nil
+}
+
+// WriteHtmlReport writes the HTML report of the scan results to the given file path.
+func (rw *ResultsWriter) WriteHtmlReport(filePath string) error {
+	return rw.writeScanResultsToFile(OutputFile{Format: Html, Path: filePath})
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSimpleJsonToHtmlReport(t *testing.T) {
+	results := NewAuditResults()
+	results.XrayVersion = "3.90.0"
+	results.ExtendedScanResults.EntitledForJas = true
+	jsonTable := formats.SimpleJsonResults{
+		Vulnerabilities: []formats.VulnerabilityOrViolationRow{{
+			ImpactedDependencyDetails: formats.ImpactedDependencyDetails{
+				SeverityDetails:           formats.SeverityDetails{Severity: "High"},
+				ImpactedDependencyName:    "lodash",
+				ImpactedDependencyVersion: "4.17.20",
+				Components:                []formats.ComponentRow{{Name: "lodash", Version: "4.17.20"}},
+			},
+			Summary:       "<script>alert(1)</script>",
+			Applicable:    "Applicable",
+			FixedVersions: []string{"[4.17.21]"},
+			Cves: []formats.CveRow{{Id: "CVE-2024-0001", CvssV3: "7.5", Applicability: &formats.Applicability{
+				Status:   "Applicable",
+				Evidence: []formats.Evidence{{Location: formats.Location{File: "index.js", StartLine: 3, StartColumn: 1, Snippet: "_.merge(a, b)"}}},
+			}}},
+			ImpactPaths: [][]formats.ComponentRow{{{Name: "project", Version: "1.0.0"}, {Name: "lodash", Version: "4.17.20"}}},
+		}},
+		Sast: []formats.SourceCodeRow{{
+			SeverityDetails: formats.SeverityDetails{Severity: "Medium"},
+			Location:        formats.Location{File: "app.js", StartLine: 10, StartColumn: 2},
+			Finding:         "Stored XSS",
+			CodeFlow:        [][]formats.Location{{{File: "input.js", StartLine: 1, StartColumn: 1}, {File: "app.js", StartLine: 10, StartColumn: 2}}},
+		}},
+	}
+	report, err := ConvertSimpleJsonToHtmlReport(jsonTable, results, true)
+	require.NoError(t, err)
+	assert.Contains(t, report, "JFrog Xray 3.90.0")
+	assert.Contains(t, report, "CVE-2024-0001")
+	assert.Contains(t, report, "[4.17.21]")
+	assert.Contains(t, report, "project:1.0.0 &gt; lodash:4.17.20")
+	assert.Contains(t, report, "index.js:3:1")
+	assert.Contains(t, report, "Code flow 1")
+	assert.Contains(t, report, "<h2>Secrets</h2>")
+	assert.NotContains(t, report, "<script>alert(1)</script>")
+	assert.NotContains(t, report, "<h2>Security Violations</h2>")
+}
+
+func TestWriteHtmlReport(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.html")
+	require.NoError(t, NewResultsWriter(NewAuditResults()).SetOutputFormat(format.Table).SetIncludeVulnerabilities(true).WriteHtmlReport(reportPath))
+	content, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "<h2>Vulnerabilities</h2>")
+	assert.Contains(t, string(content), "No issues were found.")
+}
@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+)
+
+const (
+	junitReportName           = "JFrog Xray"
+	junitNoIssuesFoundCase    = "No issues were found"
+	junitScanFailedCase       = "The scan failed"
+	junitScanErrorType        = "Scan Error"
+	scaJunitSuiteName         = "SCA"
+	secretsJunitSuiteName     = "Secrets"
+	iacJunitSuiteName         = "IaC"
+	sastJunitSuiteName        = "SAST"
+	junitLicenseViolationType = "License Violation"
+	junitOperationalRiskType  = "Operational Risk Violation"
+)
+
+// The structs below describe the commonly supported JUnit XML schema, as consumed by Jenkins, GitLab and Azure DevOps.
+type JunitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	TestSuites []JunitTestSuite `xml:"testsuite"`
+}
+
+type JunitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []JunitTestCase `xml:"testcase"`
+}
+
+type JunitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JunitFailure `xml:"failure,omitempty"`
+	// The error element has the same attributes as the failure element.
+	Error *JunitFailure `xml:"error,omitempty"`
+}
+
+type JunitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// GenerateJunitReport converts the scan results to a JUnit report.
+// Each finding becomes a failed test case, and each failed SCA scan becomes an errored test case. The test cases are grouped into test suites by scanner and by working directory.
+func GenerateJunitReport(results *Results, isMultipleRoots, includeLicenses bool) (report *JunitTestSuites, err error) {
+	report = &JunitTestSuites{Name: junitReportName}
+	for _, scaResult := range results.ScaResults {
+		if scaResult.ScanError != nil {
+			// A failed scan has no results, it shouldn't be reported as passed.
+			report.addTestSuite(getScaScanErrorJunitTestSuite(scaResult))
+			continue
+		}
+		var suite *JunitTestSuite
+		if suite, err = getScaJunitTestSuite(scaResult, results, isMultipleRoots, includeLicenses); err != nil {
+			return
+		}
+		report.addTestSuite(suite)
+	}
+	if results.ExtendedScanResults.EntitledForJas {
+		addJasJunitTestSuites(report, secretsJunitSuiteName, results.ExtendedScanResults.SecretsScanResults, PrepareSecrets)
+		addJasJunitTestSuites(report, iacJunitSuiteName, results.ExtendedScanResults.IacScanResults, PrepareIacs)
+		addJasJunitTestSuites(report, sastJunitSuiteName, results.ExtendedScanResults.SastScanResults, PrepareSast)
+	}
+	return
+}
+
+func ConvertJunitReportToString(report *JunitTestSuites) (string, error) {
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return xml.Header + string(out), nil
+}
+
+func (ts *JunitTestSuites) addTestSuite(suite *JunitTestSuite) {
+	if len(suite.TestCases) == 0 {
+		// A scanned target without findings is reported as a single passed test case, so it still shows up in the dashboards.
+		suite.TestCases = append(suite.TestCases, JunitTestCase{Name: junitNoIssuesFoundCase, ClassName: suite.Name})
+	}
+	suite.Tests = len(suite.TestCases)
+	ts.Tests += suite.Tests
+	ts.Failures += suite.Failures
+	ts.Errors += suite.Errors
+	ts.TestSuites = append(ts.TestSuites, *suite)
+}
+
+func (suite *JunitTestSuite) addFailedTestCase(name, className, failureType, message, contents string) {
+	suite.Failures++
+	suite.TestCases = append(suite.TestCases, JunitTestCase{
+		Name:      name,
+		ClassName: className,
+		Failure:   &JunitFailure{Message: message, Type: failureType, Contents: contents},
+	})
+}
+
+func (suite *JunitTestSuite) addErroredTestCase(name, className, errorType, message string) {
+	suite.Errors++
+	suite.TestCases = append(suite.TestCases, JunitTestCase{
+		Name:      name,
+		ClassName: className,
+		Error:     &JunitFailure{Message: message, Type: errorType},
+	})
+}
+
+func getJunitTestSuiteName(scanner, workingDirectory string) string {
+	if workingDirectory == "" {
+		return scanner
+	}
+	return fmt.Sprintf("%s - %s", scanner, workingDirectory)
+}
+
+func getScaJunitTestSuite(scaResult ScaScanResult, results *Results, isMultipleRoots, includeLicenses bool) (*JunitTestSuite, error) {
+	scanResults := &Results{ScaResults: []ScaScanResult{scaResult}, ExtendedScanResults: results.ExtendedScanResults}
+	xrayJson, err := ConvertXrayScanToSimpleJson(scanResults, isMultipleRoots, includeLicenses, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	suite := &JunitTestSuite{Name: getJunitTestSuiteName(scaJunitSuiteName, scaResult.WorkingDirectory)}
+	for _, vulnerability := range xrayJson.Vulnerabilities {
+		addScaJunitTestCase(suite, "Vulnerability", vulnerability)
+	}
+	for _, violation := range xrayJson.SecurityViolations {
+		addScaJunitTestCase(suite, "Security Violation", violation)
+	}
+	for _, license := range xrayJson.LicensesViolations {
+		suite.addFailedTestCase(
+			fmt.Sprintf("%s in %s", license.LicenseKey, getDependencyDisplayName(license.ImpactedDependencyName, license.ImpactedDependencyVersion)),
+			license.ImpactedDependencyName,
+			junitLicenseViolationType,
+			fmt.Sprintf("[%s] %s", license.Severity, getLicenseViolationSummary(license.ImpactedDependencyName, license.ImpactedDependencyVersion, license.LicenseKey)),
+			getJunitDirectDependenciesText(license.Components),
+		)
+	}
+	for _, operationalRisk := range xrayJson.OperationalRiskViolations {
+		suite.addFailedTestCase(
+			fmt.Sprintf("Operational risk in %s", getDependencyDisplayName(operationalRisk.ImpactedDependencyName, operationalRisk.ImpactedDependencyVersion)),
+			operationalRisk.ImpactedDependencyName,
+			junitOperationalRiskType,
+			fmt.Sprintf("[%s] Risk reason: %s", operationalRisk.Severity, operationalRisk.RiskReason),
+			getJunitDirectDependenciesText(operationalRisk.Components),
+		)
+	}
+	return suite, nil
+}
+
+// The test suite of a working directory whose SCA scan failed, with an errored test case that holds the scan error.
+func getScaScanErrorJunitTestSuite(scaResult ScaScanResult) *JunitTestSuite {
+	suite := &JunitTestSuite{Name: getJunitTestSuiteName(scaJunitSuiteName, scaResult.WorkingDirectory)}
+	suite.addErroredTestCase(junitScanFailedCase, suite.Name, junitScanErrorType, scaResult.ScanError.Error())
+	return suite
+}
+
+func addScaJunitTestCase(suite *JunitTestSuite, issueType string, issue formats.VulnerabilityOrViolationRow) {
+	issueId := GetIssueIdentifier(issue.Cves, issue.IssueId)
+	message := fmt.Sprintf("[%s] %s", issue.Severity, getJunitFixVersionsText(issue.FixedVersions))
+	var contents strings.Builder
+	if issue.Summary != "" {
+		contents.WriteString(issue.Summary + "\n")
+	}
+	if issue.Applicable != "" {
+		contents.WriteString(fmt.Sprintf("Contextual Analysis: %s\n", issue.Applicable))
+	}
+	contents.WriteString(getJunitDirectDependenciesText(issue.Components))
+	suite.addFailedTestCase(
+		fmt.Sprintf("%s in %s", issueId, getDependencyDisplayName(issue.ImpactedDependencyName, issue.ImpactedDependencyVersion)),
+		issue.ImpactedDependencyName,
+		issueType,
+		message,
+		contents.String(),
+	)
+}
+
+func addJasJunitTestSuites(report *JunitTestSuites, scanner string, runs []*sarif.Run, prepareRows func([]*sarif.Run) []formats.SourceCodeRow) {
+	for _, run := range runs {
+		workingDirectory := ""
+		if len(run.Invocations) > 0 {
+			workingDirectory = GetInvocationWorkingDirectory(run.Invocations[0])
+		}
+		suite := &JunitTestSuite{Name: getJunitTestSuiteName(scanner, workingDirectory)}
+		for _, row := range prepareRows([]*sarif.Run{run}) {
+			var contents strings.Builder
+			if row.ScannerDescription != "" {
+				contents.WriteString(row.ScannerDescription + "\n")
+			}
+			if row.Snippet != "" {
+				contents.WriteString(fmt.Sprintf("Snippet: %s\n", row.Snippet))
+			}
+			suite.addFailedTestCase(
+				strings.TrimSpace(fmt.Sprintf("%s (%s:%d:%d)", row.Finding, row.File, row.StartLine, row.StartColumn)),
+				row.File,
+				scanner,
+				strings.TrimSpace(fmt.Sprintf("[%s] %s", row.Severity, row.Finding)),
+				contents.String(),
+			)
+		}
+		report.addTestSuite(suite)
+	}
+}
+
+func getJunitFixVersionsText(fixedVersions []string) string {
+	if len(fixedVersions) == 0 {
+		return "No fixed versions are available"
+	}
+	return "Fixed versions: " + strings.Join(fixedVersions, ", ")
+}
+
+func getJunitDirectDependenciesText(components []formats.ComponentRow) string {
+	if len(components) == 0 {
+		return ""
+	}
+	var directDependencies []string
+	for _, component := range components {
+		directDependencies = append(directDependencies, getDependencyDisplayName(component.Name, component.Version))
+	}
+	return "Direct dependencies: " + strings.Join(directDependencies, ", ")
+}
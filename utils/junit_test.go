@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJunitReport(t *testing.T) {
+	secretsRun := CreateRunWithDummyResults(CreateResultWithOneLocation("/root/project/config.yaml", 3, 5, 3, 20, "api_key=***", "secret-rule", "error"))
+	secretsRun.Invocations = []*sarif.Invocation{sarif.NewInvocation().WithWorkingDirectory(sarif.NewSimpleArtifactLocation("/root/project"))}
+	results := NewAuditResults()
+	results.ExtendedScanResults.EntitledForJas = true
+	results.ExtendedScanResults.SecretsScanResults = []*sarif.Run{secretsRun}
+	results.ScaResults = []ScaScanResult{
+		{
+			Technology:       coreutils.Npm,
+			WorkingDirectory: "/root/project",
+			XrayResults: []services.ScanResponse{{
+				Vulnerabilities: []services.Vulnerability{{
+					IssueId:  "XRAY-1",
+					Severity: "High",
+					Summary:  "Prototype pollution",
+					Cves:     []services.Cve{{Id: "CVE-2024-0001"}},
+					Components: map[string]services.Component{
+						"npm://lodash:4.17.20": {FixedVersions: []string{"[4.17.21]"}, ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "npm://project:1.0.0"}, {ComponentId: "npm://lodash:4.17.20"}}}},
+					},
+				}},
+			}},
+		},
+		{Technology: coreutils.Pip, WorkingDirectory: "/root/project/python"},
+		{Technology: coreutils.Go, WorkingDirectory: "/root/project/go", ScanError: NewScaScanError(DependencyTreePhase, errors.New("go mod graph failed"))},
+	}
+
+	report, err := GenerateJunitReport(results, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Tests)
+	assert.Equal(t, 2, report.Failures)
+	assert.Equal(t, 1, report.Errors)
+	require.Len(t, report.TestSuites, 4)
+
+	scaSuite := report.TestSuites[0]
+	assert.Equal(t, "SCA - /root/project", scaSuite.Name)
+	assert.Equal(t, 1, scaSuite.Failures)
+	require.Len(t, scaSuite.TestCases, 1)
+	assert.Equal(t, "CVE-2024-0001 in lodash:4.17.20", scaSuite.TestCases[0].Name)
+	require.NotNil(t, scaSuite.TestCases[0].Failure)
+	assert.Equal(t, "[High] Fixed versions: [4.17.21]", scaSuite.TestCases[0].Failure.Message)
+	assert.Contains(t, scaSuite.TestCases[0].Failure.Contents, "Prototype pollution")
+
+	emptySuite := report.TestSuites[1]
+	assert.Equal(t, "SCA - /root/project/python", emptySuite.Name)
+	assert.Equal(t, 0, emptySuite.Failures)
+	require.Len(t, emptySuite.TestCases, 1)
+	assert.Nil(t, emptySuite.TestCases[0].Failure)
+
+	// A failed scan isn't reported as passed.
+	failedScanSuite := report.TestSuites[2]
+	assert.Equal(t, "SCA - /root/project/go", failedScanSuite.Name)
+	assert.Equal(t, 1, failedScanSuite.Errors)
+	require.Len(t, failedScanSuite.TestCases, 1)
+	assert.Nil(t, failedScanSuite.TestCases[0].Failure)
+	require.NotNil(t, failedScanSuite.TestCases[0].Error)
+	assert.Contains(t, failedScanSuite.TestCases[0].Error.Message, "go mod graph failed")
+
+	secretsSuite := report.TestSuites[3]
+	assert.Equal(t, "Secrets - /root/project", secretsSuite.Name)
+	require.Len(t, secretsSuite.TestCases, 1)
+	assert.Equal(t, "config.yaml", secretsSuite.TestCases[0].ClassName)
+	assert.Equal(t, "Secrets", secretsSuite.TestCases[0].Failure.Type)
+	assert.Equal(t, "(config.yaml:3:5)", secretsSuite.TestCases[0].Name)
+	assert.Equal(t, "[High]", secretsSuite.TestCases[0].Failure.Message)
+}
+
+func TestConvertJunitReportToString(t *testing.T) {
+	suite := &JunitTestSuite{Name: "SCA"}
+	suite.addFailedTestCase("CVE-2024-0001 in lodash:4.17.20", "lodash", "Vulnerability", "[High] <no fix>", "")
+	report := &JunitTestSuites{Name: junitReportName}
+	report.addTestSuite(suite)
+	reportStr, err := ConvertJunitReportToString(report)
+	require.NoError(t, err)
+	assert.Contains(t, reportStr, `<testsuites name="JFrog Xray" tests="1" failures="1" errors="0">`)
+	assert.Contains(t, reportStr, `<failure message="[High] &lt;no fix&gt;" type="Vulnerability"></failure>`)
+}
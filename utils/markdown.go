@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+)
+
+const markdownReportTitle = "### JFrog Security Scan Results"
+
+var markdownSeveritiesOrder = []string{"Critical", "High", "Medium", "Low", "Unknown"}
+
+// A scanner summary row, and the collapsible section that holds its findings.
+type markdownSection struct {
+	title      string
+	docsUrl    string
+	severities []string
+	header     []string
+	rows       [][]string
+}
+
+// ConvertSimpleJsonToMarkdown converts the simple-json results to GitHub/GitLab flavored markdown, to be used in pull request comments and CI job summaries.
+// The output starts with a severity summary table, followed by a collapsible section with the findings of each scanner.
+func ConvertSimpleJsonToMarkdown(jsonTable formats.SimpleJsonResults, includeVulnerabilities, entitledForJas bool) string {
+	var sections []markdownSection
+	if len(jsonTable.SecurityViolations) > 0 || !includeVulnerabilities {
+		sections = append(sections, getSecurityIssuesMarkdownSection("Security Violations", jsonTable.SecurityViolations))
+	}
+	if len(jsonTable.LicensesViolations) > 0 {
+		sections = append(sections, getLicensesMarkdownSection("License Violations", jsonTable.LicensesViolations))
+	}
+	if len(jsonTable.OperationalRiskViolations) > 0 {
+		sections = append(sections, getOperationalRiskMarkdownSection(jsonTable.OperationalRiskViolations))
+	}
+	if len(jsonTable.Vulnerabilities) > 0 || includeVulnerabilities {
+		sections = append(sections, getSecurityIssuesMarkdownSection("Vulnerabilities", jsonTable.Vulnerabilities))
+	}
+	if entitledForJas {
+		sections = append(sections,
+			getSourceCodeMarkdownSection("Secrets", "secrets", jsonTable.Secrets),
+			getSourceCodeMarkdownSection("Infrastructure as Code", "infrastructure-as-code-iac", jsonTable.Iacs),
+			getSourceCodeMarkdownSection("SAST", "sast", jsonTable.Sast),
+		)
+	}
+
+	var markdown strings.Builder
+	markdown.WriteString(markdownReportTitle + "\n\n")
+	markdown.WriteString(getMarkdownSummaryTable(sections))
+	for _, section := range sections {
+		if len(section.rows) == 0 {
+			continue
+		}
+		markdown.WriteString(fmt.Sprintf("\n<details>\n<summary><b>%s (%d)</b></summary>\n\n", section.title, len(section.rows)))
+		markdown.WriteString(toMarkdownTable(section.header, section.rows))
+		markdown.WriteString("\n</details>\n")
+	}
+	return markdown.String()
+}
+
+func getMarkdownSummaryTable(sections []markdownSection) string {
+	header := append(append([]string{"Scanner"}, markdownSeveritiesOrder...), "Total")
+	var rows [][]string
+	for _, section := range sections {
+		counts := map[string]int{}
+		for _, severity := range section.severities {
+			if _, exists := Severities[severity]; !exists {
+				severity = "Unknown"
+			}
+			counts[severity]++
+		}
+		row := []string{fmt.Sprintf("[%s](%s)", section.title, section.docsUrl)}
+		for _, severity := range markdownSeveritiesOrder {
+			row = append(row, fmt.Sprintf("%d", counts[severity]))
+		}
+		rows = append(rows, append(row, fmt.Sprintf("**%d**", len(section.severities))))
+	}
+	return toMarkdownTable(header, rows)
+}
+
+func getSecurityIssuesMarkdownSection(title string, issues []formats.VulnerabilityOrViolationRow) markdownSection {
+	section := markdownSection{
+		title:   title,
+		docsUrl: BaseDocumentationURL + "sca",
+		header:  []string{"Severity", "ID", "Impacted Dependency", "Direct Dependencies", "Fixed Versions", "Contextual Analysis"},
+	}
+	for _, issue := range issues {
+		directDependencies, _ := getDirectDependenciesFormatted(issue.Components)
+		fixedVersions := "No fix available"
+		if len(issue.FixedVersions) > 0 {
+			fixedVersions = strings.Join(issue.FixedVersions, ", ")
+		}
+		section.severities = append(section.severities, issue.Severity)
+		section.rows = append(section.rows, []string{
+			issue.Severity,
+			GetIssueIdentifier(issue.Cves, issue.IssueId),
+			fmt.Sprintf("`%s %s`", issue.ImpactedDependencyName, issue.ImpactedDependencyVersion),
+			directDependencies,
+			fixedVersions,
+			issue.Applicable,
+		})
+	}
+	return section
+}
+
+func getLicensesMarkdownSection(title string, licenses []formats.LicenseRow) markdownSection {
+	section := markdownSection{
+		title:   title,
+		docsUrl: BaseDocumentationURL + "sca",
+		header:  []string{"Severity", "License", "Impacted Dependency", "Direct Dependencies"},
+	}
+	for _, license := range licenses {
+		directDependencies, _ := getDirectDependenciesFormatted(license.Components)
+		section.severities = append(section.severities, license.Severity)
+		section.rows = append(section.rows, []string{
+			license.Severity,
+			license.LicenseKey,
+			fmt.Sprintf("`%s %s`", license.ImpactedDependencyName, license.ImpactedDependencyVersion),
+			directDependencies,
+		})
+	}
+	return section
+}
+
+func getOperationalRiskMarkdownSection(violations []formats.OperationalRiskViolationRow) markdownSection {
+	section := markdownSection{
+		title:   "Operational Risk Violations",
+		docsUrl: BaseDocumentationURL + "sca",
+		header:  []string{"Severity", "Impacted Dependency", "Direct Dependencies", "Risk Reason", "Latest Version"},
+	}
+	for _, violation := range violations {
+		directDependencies, _ := getDirectDependenciesFormatted(violation.Components)
+		section.severities = append(section.severities, violation.Severity)
+		section.rows = append(section.rows, []string{
+			violation.Severity,
+			fmt.Sprintf("`%s %s`", violation.ImpactedDependencyName, violation.ImpactedDependencyVersion),
+			directDependencies,
+			violation.RiskReason,
+			violation.LatestVersion,
+		})
+	}
+	return section
+}
+
+func getSourceCodeMarkdownSection(title, docsUrlSuffix string, findings []formats.SourceCodeRow) markdownSection {
+	section := markdownSection{
+		title:   title,
+		docsUrl: BaseDocumentationURL + docsUrlSuffix,
+		header:  []string{"Severity", "File", "Line:Column", "Finding"},
+	}
+	for _, finding := range findings {
+		section.severities = append(section.severities, finding.Severity)
+		section.rows = append(section.rows, []string{
+			finding.Severity,
+			fmt.Sprintf("`%s`", finding.File),
+			fmt.Sprintf("%d:%d", finding.StartLine, finding.StartColumn),
+			finding.Finding,
+		})
+	}
+	return section
+}
+
+func toMarkdownTable(header []string, rows [][]string) string {
+	var table strings.Builder
+	table.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	table.WriteString(strings.Repeat("| :---: ", len(header)) + "|\n")
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, escapeMarkdownTableCell(cell))
+		}
+		table.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return table.String()
+}
+
+// Pipes and line breaks would break the table structure.
+func escapeMarkdownTableCell(cell string) string {
+	cell = strings.ReplaceAll(cell, "|", "\\|")
+	return strings.ReplaceAll(strings.TrimSpace(cell), "\n", "<br/>")
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertSimpleJsonToMarkdown(t *testing.T) {
+	jsonTable := formats.SimpleJsonResults{
+		Vulnerabilities: []formats.VulnerabilityOrViolationRow{
+			{
+				ImpactedDependencyDetails: formats.ImpactedDependencyDetails{
+					SeverityDetails:           formats.SeverityDetails{Severity: "Critical"},
+					ImpactedDependencyName:    "lodash",
+					ImpactedDependencyVersion: "4.17.20",
+					Components:                []formats.ComponentRow{{Name: "lodash", Version: "4.17.20"}},
+				},
+				FixedVersions: []string{"[4.17.21]"},
+				Cves:          []formats.CveRow{{Id: "CVE-2024-0001"}},
+				Applicable:    "Applicable",
+			},
+			{
+				ImpactedDependencyDetails: formats.ImpactedDependencyDetails{
+					SeverityDetails:           formats.SeverityDetails{Severity: "Low"},
+					ImpactedDependencyName:    "minimist",
+					ImpactedDependencyVersion: "1.2.0",
+				},
+				IssueId: "XRAY-1",
+			},
+		},
+		Secrets: []formats.SourceCodeRow{{
+			SeverityDetails: formats.SeverityDetails{Severity: "High"},
+			Location:        formats.Location{File: "config.yaml", StartLine: 3, StartColumn: 5},
+			Finding:         "Secret | token",
+		}},
+	}
+	markdown := ConvertSimpleJsonToMarkdown(jsonTable, true, true)
+	assert.Contains(t, markdown, markdownReportTitle)
+	assert.Contains(t, markdown, "| [Vulnerabilities]("+BaseDocumentationURL+"sca) | 1 | 0 | 0 | 1 | 0 | **2** |")
+	assert.Contains(t, markdown, "| [Secrets]("+BaseDocumentationURL+"secrets) | 0 | 1 | 0 | 0 | 0 | **1** |")
+	assert.Contains(t, markdown, "| [SAST]("+BaseDocumentationURL+"sast) | 0 | 0 | 0 | 0 | 0 | **0** |")
+	assert.Contains(t, markdown, "<summary><b>Vulnerabilities (2)</b></summary>")
+	assert.Contains(t, markdown, "| Critical | CVE-2024-0001 | `lodash 4.17.20` | `lodash 4.17.20` | [4.17.21] | Applicable |")
+	assert.Contains(t, markdown, "| Low | XRAY-1 | `minimist 1.2.0` |  | No fix available |  |")
+	assert.Contains(t, markdown, "Secret \\| token")
+	assert.NotContains(t, markdown, "<summary><b>SAST")
+	assert.NotContains(t, markdown, "Security Violations")
+}
+
+func TestConvertSimpleJsonToMarkdownWithoutJas(t *testing.T) {
+	markdown := ConvertSimpleJsonToMarkdown(formats.SimpleJsonResults{}, false, false)
+	assert.Contains(t, markdown, "| [Security Violations]("+BaseDocumentationURL+"sca) | 0 | 0 | 0 | 0 | 0 | **0** |")
+	assert.NotContains(t, markdown, "Secrets")
+	assert.NotContains(t, markdown, "<details>")
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	openVexContext   = "https://openvex.dev/ns/v0.2.0"
+	openVexIdPrefix  = "https://jfrog.com/vex/"
+	openVexAuthor    = "JFrog"
+	openVexNoFixText = "No fixed version is available yet."
+)
+
+// Contextual analysis only determines whether the vulnerable code is reachable, so it is the justification of every 'not_affected' status.
+// See https://github.com/openvex/spec/blob/main/OPENVEX-SPEC.md#status-justifications
+const openVexVulnerableCodeNotInExecutePath = "vulnerable_code_not_in_execute_path"
+
+type OpenVexStatus string
+
+const (
+	OpenVexNotAffected        OpenVexStatus = "not_affected"
+	OpenVexAffected           OpenVexStatus = "affected"
+	OpenVexUnderInvestigation OpenVexStatus = "under_investigation"
+)
+
+// The structs below describe the OpenVEX document schema.
+// See https://github.com/openvex/spec
+type OpenVexDocument struct {
+	Context    string             `json:"@context"`
+	Id         string             `json:"@id"`
+	Author     string             `json:"author"`
+	Timestamp  string             `json:"timestamp"`
+	Version    int                `json:"version"`
+	Tooling    string             `json:"tooling,omitempty"`
+	Statements []OpenVexStatement `json:"statements"`
+}
+
+type OpenVexStatement struct {
+	Vulnerability   OpenVexVulnerability `json:"vulnerability"`
+	Products        []OpenVexProduct     `json:"products"`
+	Status          OpenVexStatus        `json:"status"`
+	StatusNotes     string               `json:"status_notes,omitempty"`
+	Justification   string               `json:"justification,omitempty"`
+	ImpactStatement string               `json:"impact_statement,omitempty"`
+	ActionStatement string               `json:"action_statement,omitempty"`
+}
+
+type OpenVexVulnerability struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenVexProduct struct {
+	Id            string             `json:"@id"`
+	Subcomponents []OpenVexComponent `json:"subcomponents,omitempty"`
+}
+
+type OpenVexComponent struct {
+	Id string `json:"@id"`
+}
+
+// GenerateOpenVexDocument converts the contextual analysis results of the scanned CVEs to an OpenVEX document.
+// A statement is added for each CVE and vulnerable component: 'not_affected' if the CVE is not applicable, 'affected' if it is applicable,
+// and 'under_investigation' if its applicability could not be determined. Issues without CVEs are not included.
+func GenerateOpenVexDocument(results *Results) *OpenVexDocument {
+	document := &OpenVexDocument{
+		Context:    openVexContext,
+		Id:         openVexIdPrefix + uuid.NewString(),
+		Author:     openVexAuthor,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Version:    1,
+		Tooling:    xrayToolName + " " + results.XrayVersion,
+		Statements: []OpenVexStatement{},
+	}
+	reported := datastructures.MakeSet[string]()
+	for _, scaResult := range results.ScaResults {
+		for _, xrayResult := range scaResult.XrayResults {
+			for _, vulnerability := range xrayResult.Vulnerabilities {
+				document.Statements = append(document.Statements, getOpenVexStatements(reported, vulnerability.Summary, vulnerability.Cves, vulnerability.Components, results)...)
+			}
+			for _, violation := range xrayResult.Violations {
+				if violation.ViolationType == "security" {
+					document.Statements = append(document.Statements, getOpenVexStatements(reported, violation.Summary, violation.Cves, violation.Components, results)...)
+				}
+			}
+		}
+	}
+	return document
+}
+
+func ConvertOpenVexDocumentToString(document *OpenVexDocument) (string, error) {
+	out, err := json.Marshal(document)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return clientUtils.IndentJson(out), nil
+}
+
+func getOpenVexStatements(reported *datastructures.Set[string], summary string, cves []services.Cve, components map[string]services.Component, results *Results) (statements []OpenVexStatement) {
+	componentIds := maps.Keys(components)
+	slices.Sort(componentIds)
+	for _, cve := range cves {
+		if cve.Id == "" {
+			continue
+		}
+		for _, componentId := range componentIds {
+			// The same CVE may be returned both as a vulnerability and as a violation.
+			if reported.Exists(cve.Id + componentId) {
+				continue
+			}
+			reported.Add(cve.Id + componentId)
+			statement := OpenVexStatement{
+				Vulnerability: OpenVexVulnerability{Name: cve.Id, Description: summary},
+				Products:      getOpenVexProducts(componentId, components[componentId]),
+			}
+			var applicability *formats.Applicability
+			if results.ExtendedScanResults.EntitledForJas {
+				applicability = getCveApplicabilityField(formats.CveRow{Id: cve.Id}, results.ExtendedScanResults.ApplicabilityScanResults, map[string]services.Component{componentId: components[componentId]})
+			}
+			scannerReasons := getApplicabilityScannerReasons(cve.Id, applicability, results.ExtendedScanResults.ApplicabilityScanResults)
+			setOpenVexStatementStatus(&statement, applicability, scannerReasons, componentId, components[componentId].FixedVersions)
+			statements = append(statements, statement)
+		}
+	}
+	return
+}
+
+func setOpenVexStatementStatus(statement *OpenVexStatement, applicability *formats.Applicability, scannerReasons []string, componentId string, fixedVersions []string) {
+	status := ApplicabilityUndetermined
+	if applicability != nil {
+		status = ApplicabilityStatus(applicability.Status)
+	}
+	switch status {
+	case NotApplicable:
+		statement.Status = OpenVexNotAffected
+		statement.Justification = openVexVulnerableCodeNotInExecutePath
+		statement.ImpactStatement = "JFrog Contextual Analysis did not find a usage of the vulnerable code in the scanned project."
+		if len(scannerReasons) > 0 {
+			statement.ImpactStatement += "\n" + strings.Join(scannerReasons, "\n")
+		} else if applicability.ScannerDescription != "" {
+			statement.ImpactStatement += "\n" + applicability.ScannerDescription
+		}
+	case Applicable:
+		statement.Status = OpenVexAffected
+		var evidence []string
+		for _, location := range applicability.Evidence {
+			evidence = append(evidence, strings.TrimSpace(fmt.Sprintf("%s:%d:%d %s", location.File, location.StartLine, location.StartColumn, location.Reason)))
+		}
+		statement.StatusNotes = "JFrog Contextual Analysis found a usage of the vulnerable code in the scanned project."
+		if len(evidence) > 0 {
+			statement.StatusNotes += " Evidence: " + strings.Join(evidence, "; ")
+		}
+		statement.ActionStatement = openVexNoFixText
+		if len(fixedVersions) > 0 {
+			name, _, _ := SplitComponentId(componentId)
+			statement.ActionStatement = getUpgradeRecommendation(name, fixedVersions)
+		}
+	default:
+		statement.Status = OpenVexUnderInvestigation
+		statement.StatusNotes = "The applicability of the vulnerability could not be determined."
+	}
+}
+
+// The reasons of the contextual analysis scanner for its conclusion about the CVE: the reasons of its evidence and the messages of its results.
+func getApplicabilityScannerReasons(cveId string, applicability *formats.Applicability, applicabilityScanResults []*sarif.Run) (reasons []string) {
+	if applicability == nil {
+		return
+	}
+	addReason := func(reason string) {
+		if reason = strings.TrimSpace(reason); reason != "" && !slices.Contains(reasons, reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	for _, evidence := range applicability.Evidence {
+		addReason(evidence.Reason)
+	}
+	for _, applicabilityRun := range applicabilityScanResults {
+		for _, result := range applicabilityRun.Results {
+			if GetResultRuleId(result) == CveToApplicabilityRuleId(cveId) {
+				addReason(GetResultMsgText(result))
+			}
+		}
+	}
+	return
+}
+
+// The products of a statement are the scanned projects (the roots of the impact paths), with the vulnerable component as their subcomponent.
+func getOpenVexProducts(componentId string, component services.Component) (products []OpenVexProduct) {
+	vulnerableComponent := OpenVexComponent{Id: getOpenVexComponentId(componentId)}
+	var roots []string
+	for _, impactPath := range component.ImpactPaths {
+		if len(impactPath) > 1 && !slices.Contains(roots, impactPath[0].ComponentId) {
+			roots = append(roots, impactPath[0].ComponentId)
+		}
+	}
+	if len(roots) == 0 {
+		return []OpenVexProduct{{Id: vulnerableComponent.Id}}
+	}
+	for _, root := range roots {
+		products = append(products, OpenVexProduct{Id: getOpenVexComponentId(root), Subcomponents: []OpenVexComponent{vulnerableComponent}})
+	}
+	return
+}
+
+func getOpenVexComponentId(componentId string) string {
+	if purl := XrayComponentIdToPurl(componentId); purl != "" {
+		return purl
+	}
+	return componentId
+}
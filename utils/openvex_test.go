@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOpenVexDocument(t *testing.T) {
+	impactPaths := [][]services.ImpactPathNode{{{ComponentId: "npm://project:1.0.0"}, {ComponentId: "npm://lodash:4.17.20"}}}
+	results := NewAuditResults()
+	results.XrayVersion = "3.90.0"
+	results.ExtendedScanResults.EntitledForJas = true
+	results.ExtendedScanResults.ApplicabilityScanResults = []*sarif.Run{
+		CreateRunWithDummyResults(
+			CreateResultWithOneLocation("/index.js", 3, 1, 3, 10, "_.merge(a, b)", "applic_CVE-2024-0001", "note"),
+			CreateDummyPassingResult("applic_CVE-2024-0002"),
+		),
+	}
+	notApplicableMessage := "The scanner checks whether the vulnerable function `_.merge` is called with external input."
+	results.ExtendedScanResults.ApplicabilityScanResults[0].Results[1].Message.Text = &notApplicableMessage
+	results.ScaResults = []ScaScanResult{{
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{{
+				IssueId: "XRAY-1",
+				Summary: "Prototype pollution",
+				Cves:    []services.Cve{{Id: "CVE-2024-0001"}, {Id: "CVE-2024-0002"}, {Id: "CVE-2024-0003"}},
+				Components: map[string]services.Component{
+					"npm://lodash:4.17.20": {FixedVersions: []string{"[4.17.21]"}, ImpactPaths: impactPaths},
+				},
+			}},
+			// The same CVE returned as a violation should be reported once
+			Violations: []services.Violation{{
+				IssueId:       "XRAY-1",
+				ViolationType: "security",
+				Cves:          []services.Cve{{Id: "CVE-2024-0001"}},
+				Components:    map[string]services.Component{"npm://lodash:4.17.20": {ImpactPaths: impactPaths}},
+			}},
+		}},
+	}}
+
+	document := GenerateOpenVexDocument(results)
+	assert.Equal(t, openVexContext, document.Context)
+	assert.Equal(t, "JFrog Xray 3.90.0", document.Tooling)
+	require.Len(t, document.Statements, 3)
+	expectedProducts := []OpenVexProduct{{Id: "pkg:npm/project@1.0.0", Subcomponents: []OpenVexComponent{{Id: "pkg:npm/lodash@4.17.20"}}}}
+
+	applicable := document.Statements[0]
+	assert.Equal(t, "CVE-2024-0001", applicable.Vulnerability.Name)
+	assert.Equal(t, expectedProducts, applicable.Products)
+	assert.Equal(t, OpenVexAffected, applicable.Status)
+	assert.Contains(t, applicable.StatusNotes, "index.js:3:1")
+	assert.Equal(t, "Upgrade lodash to one of the following versions: [4.17.21]", applicable.ActionStatement)
+
+	notApplicable := document.Statements[1]
+	assert.Equal(t, "CVE-2024-0002", notApplicable.Vulnerability.Name)
+	assert.Equal(t, OpenVexNotAffected, notApplicable.Status)
+	assert.Equal(t, openVexVulnerableCodeNotInExecutePath, notApplicable.Justification)
+	assert.Contains(t, notApplicable.ImpactStatement, notApplicableMessage)
+
+	undetermined := document.Statements[2]
+	assert.Equal(t, "CVE-2024-0003", undetermined.Vulnerability.Name)
+	assert.Equal(t, OpenVexUnderInvestigation, undetermined.Status)
+}
+
+func TestGenerateOpenVexDocumentNotEntitled(t *testing.T) {
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{{
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{{
+				IssueId:    "XRAY-1",
+				Cves:       []services.Cve{{Id: ""}, {Id: "CVE-2024-0001"}},
+				Components: map[string]services.Component{"gav://org.example:lib:1.0.0": {}},
+			}},
+		}},
+	}}
+	document := GenerateOpenVexDocument(results)
+	require.Len(t, document.Statements, 1)
+	assert.Equal(t, OpenVexUnderInvestigation, document.Statements[0].Status)
+	assert.Equal(t, []OpenVexProduct{{Id: "pkg:maven/org.example/lib@1.0.0"}}, document.Statements[0].Products)
+}
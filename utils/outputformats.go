@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Output formats that are supported only by the security commands, in addition to the common formats defined in jfrog-cli-core.
+const (
+	CycloneDx format.OutputFormat = "cyclonedx"
+	Spdx      format.OutputFormat = "spdx"
+	Junit     format.OutputFormat = "junit"
+	Html      format.OutputFormat = "html"
+	Markdown  format.OutputFormat = "markdown"
+	OpenVex   format.OutputFormat = "openvex"
+	// GitLab security reports
+	GitlabDependencyScanning format.OutputFormat = "gitlab-dependency-scanning"
+	GitlabSast               format.OutputFormat = "gitlab-sast"
+	GitlabSecretDetection    format.OutputFormat = "gitlab-secret-detection"
+)
+
+var OutputFormats = append(append([]string{}, format.OutputFormats...), string(CycloneDx), string(Spdx), string(Junit), string(Html), string(Markdown), string(OpenVex), string(GitlabDependencyScanning), string(GitlabSast), string(GitlabSecretDetection))
+
+// GetOutputFormat returns the output format matching the given flag value, including the security specific formats.
+func GetOutputFormat(formatFlagVal string) (outputFormat format.OutputFormat, err error) {
+	// Default print format is table.
+	outputFormat = format.Table
+	if formatFlagVal == "" {
+		return
+	}
+	for _, supportedFormat := range OutputFormats {
+		if strings.ToLower(formatFlagVal) == supportedFormat {
+			outputFormat = format.OutputFormat(supportedFormat)
+			return
+		}
+	}
+	err = errorutils.CheckErrorf("only the following output formats are supported: " + coreutils.ListToText(OutputFormats))
+	return
+}
+
+// OutputFile is a file in which the scan results are written in the given format.
+type OutputFile struct {
+	Format format.OutputFormat
+	Path   string
+}
+
+// ParseOutputFiles parses a comma-separated list of <format>=<file path> pairs, for example: 'sarif=results.sarif,simple-json=results.json'.
+func ParseOutputFiles(outputFlagVal string) (outputFiles []OutputFile, err error) {
+	if strings.TrimSpace(outputFlagVal) == "" {
+		return
+	}
+	paths := datastructures.MakeSet[string]()
+	for _, outputFileVal := range strings.Split(outputFlagVal, ",") {
+		formatVal, path, found := strings.Cut(strings.TrimSpace(outputFileVal), "=")
+		formatVal, path = strings.TrimSpace(formatVal), strings.TrimSpace(path)
+		if !found || formatVal == "" || path == "" {
+			return nil, errorutils.CheckErrorf("invalid output '%s', expected the <format>=<file path> structure", outputFileVal)
+		}
+		var outputFormat format.OutputFormat
+		if outputFormat, err = GetOutputFormat(formatVal); err != nil {
+			return nil, err
+		}
+		if outputFormat == format.Table {
+			return nil, errorutils.CheckErrorf("the table format can't be written to a file")
+		}
+		if paths.Exists(path) {
+			return nil, errorutils.CheckErrorf("the file '%s' is set as the output of more than one format", path)
+		}
+		paths.Add(path)
+		outputFiles = append(outputFiles, OutputFile{Format: outputFormat, Path: path})
+	}
+	return
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOutputFiles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		flagValue     string
+		expected      []OutputFile
+		expectedError bool
+	}{
+		{name: "Empty value", flagValue: ""},
+		{name: "One file", flagValue: "sarif=results.sarif", expected: []OutputFile{{Format: format.Sarif, Path: "results.sarif"}}},
+		{
+			name:      "Multiple files with spaces",
+			flagValue: " sarif = results.sarif , simple-json=out/results.json,cyclonedx=bom.json",
+			expected: []OutputFile{
+				{Format: format.Sarif, Path: "results.sarif"},
+				{Format: format.SimpleJson, Path: "out/results.json"},
+				{Format: CycloneDx, Path: "bom.json"},
+			},
+		},
+		{name: "Missing path", flagValue: "sarif=", expectedError: true},
+		{name: "Missing separator", flagValue: "results.sarif", expectedError: true},
+		{name: "Unknown format", flagValue: "xml=results.xml", expectedError: true},
+		{name: "Table format", flagValue: "table=results.txt", expectedError: true},
+		{name: "Duplicate path", flagValue: "json=results.json,simple-json=results.json", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputFiles, err := ParseOutputFiles(tc.flagValue)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, outputFiles)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Xray component id package types mapped to their Package URL (purl) type.
+var xrayTypeToPurlType = map[string]string{
+	"gav":      "maven",
+	"npm":      "npm",
+	"go":       "golang",
+	"pypi":     "pypi",
+	"pip":      "pypi",
+	"nuget":    "nuget",
+	"composer": "composer",
+	"cargo":    "cargo",
+	"docker":   "docker",
+	"rpm":      "rpm",
+	"deb":      "deb",
+	"alpine":   "apk",
+	"generic":  "generic",
+}
+
+// XrayComponentIdToPurl converts a Xray component id to a Package URL (https://github.com/package-url/purl-spec).
+// In case the component id's package type is unknown, an empty string is returned.
+// Examples:
+//  1. "gav://org.apache.logging.log4j:log4j-core:2.14.1" -> "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"
+//  2. "npm://@babel/core:7.0.0" -> "pkg:npm/%40babel/core@7.0.0"
+//  3. "go://github.com/gin-gonic/gin:v1.9.0" -> "pkg:golang/github.com/gin-gonic/gin@v1.9.0"
+func XrayComponentIdToPurl(componentId string) string {
+	compIdParts := strings.Split(componentId, "://")
+	if len(compIdParts) != 2 {
+		return ""
+	}
+	purlType, exists := xrayTypeToPurlType[compIdParts[0]]
+	if !exists {
+		return ""
+	}
+	name, version, _ := SplitComponentId(componentId)
+	namespace := ""
+	switch purlType {
+	case "maven":
+		// Maven name: group:artifact
+		if groupEnd := strings.LastIndex(name, ":"); groupEnd != -1 {
+			namespace, name = name[:groupEnd], name[groupEnd+1:]
+		}
+	case "npm", "golang", "composer", "docker":
+		// The namespace is the part before the last '/' (npm scope, go module path, composer vendor or docker registry)
+		if namespaceEnd := strings.LastIndex(name, "/"); namespaceEnd != -1 {
+			namespace, name = name[:namespaceEnd], name[namespaceEnd+1:]
+		}
+	}
+	var purl strings.Builder
+	purl.WriteString("pkg:" + purlType + "/")
+	if namespace != "" {
+		for _, segment := range strings.Split(namespace, "/") {
+			purl.WriteString(escapePurlSegment(segment) + "/")
+		}
+	}
+	purl.WriteString(escapePurlSegment(name))
+	if version != "" {
+		purl.WriteString("@" + escapePurlSegment(version))
+	}
+	return purl.String()
+}
+
+// Percent-encodes a purl segment. '@' and '+' are kept as-is by url.PathEscape but must be encoded in a purl.
+func escapePurlSegment(segment string) string {
+	return strings.NewReplacer("@", "%40", "+", "%2B").Replace(url.PathEscape(segment))
+}
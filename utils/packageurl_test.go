@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXrayComponentIdToPurl(t *testing.T) {
+	testCases := []struct {
+		componentId  string
+		expectedPurl string
+	}{
+		{componentId: "gav://org.apache.logging.log4j:log4j-core:2.14.1", expectedPurl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		{componentId: "npm://lodash:4.17.20", expectedPurl: "pkg:npm/lodash@4.17.20"},
+		{componentId: "npm://@babel/core:7.0.0", expectedPurl: "pkg:npm/%40babel/core@7.0.0"},
+		{componentId: "go://github.com/gin-gonic/gin:v1.9.0", expectedPurl: "pkg:golang/github.com/gin-gonic/gin@v1.9.0"},
+		{componentId: "pypi://requests:2.31.0", expectedPurl: "pkg:pypi/requests@2.31.0"},
+		{componentId: "nuget://Newtonsoft.Json:13.0.1", expectedPurl: "pkg:nuget/Newtonsoft.Json@13.0.1"},
+		{componentId: "composer://monolog/monolog:1.0.0+build", expectedPurl: "pkg:composer/monolog/monolog@1.0.0%2Bbuild"},
+		{componentId: "cargo://serde:1.0.197", expectedPurl: "pkg:cargo/serde@1.0.197"},
+		{componentId: "npm://my-project", expectedPurl: "pkg:npm/my-project"},
+		{componentId: "unknown://component:1.0.0", expectedPurl: ""},
+		{componentId: "invalid-comp-id", expectedPurl: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.componentId, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPurl, XrayComponentIdToPurl(tc.componentId))
+		})
+	}
+}
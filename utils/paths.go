@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 const (
@@ -17,6 +19,7 @@ const (
 	// #nosec G101 -- Not credentials.
 	CurationMavenSupport = "JFROG_CLI_CURATION_MAVEN"
 	CurationPipSupport   = "JFROG_CLI_CURATION_PIP"
+	CurationCargoSupport = "JFROG_CLI_CURATION_CARGO"
 )
 
 func getJfrogCurationFolder() (string, error) {
@@ -65,3 +68,30 @@ func GetCurationPipCacheFolder() (string, error) {
 	}
 	return filepath.Join(curationFolder, "pip"), nil
 }
+
+// Returns true if the slash-separated path matches the glob pattern.
+// The segments of the pattern are matched with path.Match, and a '**' segment matches any number of segments.
+func MatchPathGlob(pattern, slashPath string) bool {
+	return matchPathSegments(strings.Split(pattern, "/"), strings.Split(slashPath, "/"))
+}
+
+func matchPathSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(pathSegments); i++ {
+			if matchPathSegments(patternSegments[1:], pathSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(patternSegments[0], pathSegments[0]); err != nil || !matched {
+		return false
+	}
+	return matchPathSegments(patternSegments[1:], pathSegments[1:])
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	assert.True(t, MatchPathGlob("src/*.js", "src/index.js"))
+	assert.False(t, MatchPathGlob("src/*.js", "src/lib/index.js"))
+	assert.True(t, MatchPathGlob("src/**/*.js", "src/index.js"))
+	assert.True(t, MatchPathGlob("src/**/*.js", "src/lib/util/index.js"))
+	assert.True(t, MatchPathGlob("**/test/**", "a/test/b/c.go"))
+	assert.True(t, MatchPathGlob("frontend/**", "frontend"))
+	assert.False(t, MatchPathGlob("frontend/**", "backend/frontend"))
+	assert.False(t, MatchPathGlob("[", "a"))
+}
@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"fmt"
+
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-security/formats"
 	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
 	"github.com/owenrumney/go-sarif/v2/sarif"
 )
 
@@ -16,6 +20,10 @@ type Results struct {
 	JasError            error
 
 	MultiScanId string
+	// The findings that were suppressed by the rules of the suppressions file. Nil if no findings were suppressed.
+	Suppressed *SuppressedFindings
+	// The findings that exist in the baseline of the scan. Nil if the results weren't compared to a baseline.
+	Existing *ExistingFindings
 }
 
 func NewAuditResults() *Results {
@@ -32,11 +40,39 @@ func (r *Results) GetScaScansXrayResults() (results []services.ScanResponse) {
 func (r *Results) GetScaScannedTechnologies() []coreutils.Technology {
 	technologies := datastructures.MakeSet[coreutils.Technology]()
 	for _, scaResult := range r.ScaResults {
-		technologies.Add(scaResult.Technology)
+		if scaResult.ScanError == nil {
+			technologies.Add(scaResult.Technology)
+		}
 	}
 	return technologies.ToSlice()
 }
 
+// Returns true if at least one of the SCA scans completed without an error.
+func (r *Results) IsAnyScaScanSucceeded() bool {
+	for _, scaResult := range r.ScaResults {
+		if scaResult.ScanError == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the errors of the failed SCA scans, each with the working directory of the scanned project.
+func (r *Results) GetScaScansErrors() (scansErrors []formats.SimpleJsonError) {
+	for _, scaResult := range r.ScaResults {
+		if scaResult.ScanError == nil {
+			continue
+		}
+		scansErrors = append(scansErrors, formats.SimpleJsonError{
+			FilePath:     scaResult.WorkingDirectory,
+			ErrorMessage: scaResult.ScanError.Message,
+			Technology:   scaResult.Technology.String(),
+			Phase:        string(scaResult.ScanError.Phase),
+		})
+	}
+	return
+}
+
 func (r *Results) IsMultipleProject() bool {
 	if len(r.ScaResults) == 0 {
 		return false
@@ -112,6 +148,37 @@ type ScaScanResult struct {
 	XrayResults           []services.ScanResponse `json:"XrayResults,omitempty"`
 	Descriptors           []string                `json:"Descriptors,omitempty"`
 	IsMultipleRootProject *bool                   `json:"IsMultipleRootProject,omitempty"`
+	// The full dependency trees of the scanned project, used to describe the components graph (SBOM formats).
+	DependencyTrees []*xrayUtils.GraphNode `json:"-"`
+	// Set if the scan failed, the scan has no results in this case.
+	ScanError *ScaScanError `json:"ScanError,omitempty"`
+}
+
+// The phases of the SCA scan of a project.
+type ScaScanPhase string
+
+const (
+	DependencyTreePhase ScaScanPhase = "dependency tree build"
+	XrayScanPhase       ScaScanPhase = "Xray scan"
+)
+
+// ScaScanError is the error of a failed SCA scan, with the phase in which the scan failed.
+type ScaScanError struct {
+	Phase   ScaScanPhase `json:"Phase"`
+	Message string       `json:"Message"`
+	err     error
+}
+
+func NewScaScanError(phase ScaScanPhase, err error) *ScaScanError {
+	return &ScaScanError{Phase: phase, Message: err.Error(), err: err}
+}
+
+func (e *ScaScanError) Error() string {
+	return fmt.Sprintf("the %s failed: %s", e.Phase, e.Message)
+}
+
+func (e *ScaScanError) Unwrap() error {
+	return e.err
 }
 
 func (s ScaScanResult) HasInformation() bool {
@@ -314,18 +314,20 @@ func prepareSecrets(secrets []*sarif.Run, isTable bool) []formats.SourceCodeRow
 		for _, secretResult := range secretRun.Results {
 			currSeverity := GetSeverity(GetResultSeverity(secretResult), Applicable)
 			for _, location := range secretResult.Locations {
+				relativeFilePath := GetRelativeLocationFileName(location, secretRun.Invocations)
 				secretsRows = append(secretsRows,
 					formats.SourceCodeRow{
 						SeverityDetails: formats.SeverityDetails{Severity: currSeverity.printableTitle(isTable), SeverityNumValue: currSeverity.NumValue()},
 						Finding:         GetResultMsgText(secretResult),
 						Location: formats.Location{
-							File:        GetRelativeLocationFileName(location, secretRun.Invocations),
+							File:        relativeFilePath,
 							StartLine:   GetLocationStartLine(location),
 							StartColumn: GetLocationStartColumn(location),
 							EndLine:     GetLocationEndLine(location),
 							EndColumn:   GetLocationEndColumn(location),
 							Snippet:     GetLocationSnippet(location),
 						},
+						Fingerprint: GetSourceCodeFingerprint(GetResultRuleId(secretResult), relativeFilePath, GetLocationSnippet(location)),
 					},
 				)
 			}
@@ -339,6 +341,17 @@ func prepareSecrets(secrets []*sarif.Run, isTable bool) []formats.SourceCodeRow
 	return secretsRows
 }
 
+// Prints the SCA scans that failed, with the phase each of them failed in. Nothing is printed if all the scans succeeded.
+func PrintScaScanErrorsTable(results *Results) error {
+	scansErrors := results.GetScaScansErrors()
+	if len(scansErrors) == 0 {
+		return nil
+	}
+	log.Output()
+	title := fmt.Sprintf("SCA Scan Errors (%d of %d projects failed)", len(scansErrors), len(results.ScaResults))
+	return coreutils.PrintTable(formats.ConvertToScaScanErrorTableRow(scansErrors), title, "", false)
+}
+
 func PrintSecretsTable(secrets []*sarif.Run, entitledForSecretsScan bool) error {
 	if entitledForSecretsScan {
 		secretsRows := prepareSecrets(secrets, true)
@@ -364,19 +377,21 @@ func prepareIacs(iacs []*sarif.Run, isTable bool) []formats.SourceCodeRow {
 			}
 			currSeverity := GetSeverity(GetResultSeverity(iacResult), Applicable)
 			for _, location := range iacResult.Locations {
+				relativeFilePath := GetRelativeLocationFileName(location, iacRun.Invocations)
 				iacRows = append(iacRows,
 					formats.SourceCodeRow{
 						SeverityDetails:    formats.SeverityDetails{Severity: currSeverity.printableTitle(isTable), SeverityNumValue: currSeverity.NumValue()},
 						Finding:            GetResultMsgText(iacResult),
 						ScannerDescription: scannerDescription,
 						Location: formats.Location{
-							File:        GetRelativeLocationFileName(location, iacRun.Invocations),
+							File:        relativeFilePath,
 							StartLine:   GetLocationStartLine(location),
 							StartColumn: GetLocationStartColumn(location),
 							EndLine:     GetLocationEndLine(location),
 							EndColumn:   GetLocationEndColumn(location),
 							Snippet:     GetLocationSnippet(location),
 						},
+						Fingerprint: GetSourceCodeFingerprint(GetResultRuleId(iacResult), relativeFilePath, GetLocationSnippet(location)),
 					},
 				)
 			}
@@ -415,6 +430,7 @@ func prepareSast(sasts []*sarif.Run, isTable bool) []formats.SourceCodeRow {
 			currSeverity := GetSeverity(GetResultSeverity(sastResult), Applicable)
 
 			for _, location := range sastResult.Locations {
+				relativeFilePath := GetRelativeLocationFileName(location, sastRun.Invocations)
 				codeFlows := GetLocationRelatedCodeFlowsFromResult(location, sastResult)
 				sastRows = append(sastRows,
 					formats.SourceCodeRow{
@@ -422,14 +438,15 @@ func prepareSast(sasts []*sarif.Run, isTable bool) []formats.SourceCodeRow {
 						ScannerDescription: scannerDescription,
 						Finding:            GetResultMsgText(sastResult),
 						Location: formats.Location{
-							File:        GetRelativeLocationFileName(location, sastRun.Invocations),
+							File:        relativeFilePath,
 							StartLine:   GetLocationStartLine(location),
 							StartColumn: GetLocationStartColumn(location),
 							EndLine:     GetLocationEndLine(location),
 							EndColumn:   GetLocationEndColumn(location),
 							Snippet:     GetLocationSnippet(location),
 						},
-						CodeFlow: codeFlowToLocationFlow(codeFlows, sastRun.Invocations, isTable),
+						Fingerprint: GetSourceCodeFingerprint(GetResultRuleId(sastResult), relativeFilePath, GetLocationSnippet(location)),
+						CodeFlow:    codeFlowToLocationFlow(codeFlows, sastRun.Invocations, isTable),
 					},
 				)
 			}
@@ -600,14 +617,8 @@ func SplitComponentId(componentId string) (string, string, string) {
 func getDirectComponentsAndImpactPaths(impactPaths [][]services.ImpactPathNode) (components []formats.ComponentRow, impactPathsRows [][]formats.ComponentRow) {
 	componentsMap := make(map[string]formats.ComponentRow)
 
-	// The first node in the impact path is the scanned component itself. The second one is the direct dependency.
-	impactPathLevel := 1
 	for _, impactPath := range impactPaths {
-		impactPathIndex := impactPathLevel
-		if len(impactPath) <= impactPathLevel {
-			impactPathIndex = len(impactPath) - 1
-		}
-		componentId := impactPath[impactPathIndex].ComponentId
+		componentId := getDirectDependencyId(impactPath)
 		if _, exist := componentsMap[componentId]; !exist {
 			compName, compVersion, _ := SplitComponentId(componentId)
 			componentsMap[componentId] = formats.ComponentRow{Name: compName, Version: compVersion}
@@ -631,6 +642,15 @@ func getDirectComponentsAndImpactPaths(impactPaths [][]services.ImpactPathNode)
 	return
 }
 
+// The first node in the impact path is the scanned component itself. The second one is the direct dependency.
+func getDirectDependencyId(impactPath []services.ImpactPathNode) string {
+	impactPathIndex := 1
+	if len(impactPath) <= impactPathIndex {
+		impactPathIndex = len(impactPath) - 1
+	}
+	return impactPath[impactPathIndex].ComponentId
+}
+
 type TableSeverity struct {
 	formats.SeverityDetails
 	style color.Style
@@ -813,6 +813,7 @@ func TestPrepareIac(t *testing.T) {
 						EndColumn:   4,
 						Snippet:     "snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule2", "file3", "snippet"),
 				},
 				{
 					SeverityDetails: formats.SeverityDetails{
@@ -828,6 +829,7 @@ func TestPrepareIac(t *testing.T) {
 						EndColumn:   4,
 						Snippet:     "snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule1", "file", "snippet"),
 				},
 				{
 					SeverityDetails: formats.SeverityDetails{
@@ -843,6 +845,7 @@ func TestPrepareIac(t *testing.T) {
 						EndColumn:   8,
 						Snippet:     "other-snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule1", "file2", "other-snippet"),
 				},
 			},
 		},
@@ -910,6 +913,7 @@ func TestPrepareSecrets(t *testing.T) {
 						EndColumn:   4,
 						Snippet:     "some-secret-snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule2", "file3", "some-secret-snippet"),
 				},
 				{
 					SeverityDetails: formats.SeverityDetails{
@@ -925,6 +929,7 @@ func TestPrepareSecrets(t *testing.T) {
 						EndColumn:   4,
 						Snippet:     "some-secret-snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule1", "file", "some-secret-snippet"),
 				},
 				{
 					SeverityDetails: formats.SeverityDetails{
@@ -940,6 +945,7 @@ func TestPrepareSecrets(t *testing.T) {
 						EndColumn:   8,
 						Snippet:     "other-secret-snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule1", "file2", "other-secret-snippet"),
 				},
 			},
 		},
@@ -1016,6 +1022,7 @@ func TestPrepareSast(t *testing.T) {
 						EndColumn:   4,
 						Snippet:     "snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule2", "file3", "snippet"),
 				},
 				{
 					SeverityDetails: formats.SeverityDetails{
@@ -1031,6 +1038,7 @@ func TestPrepareSast(t *testing.T) {
 						EndColumn:   4,
 						Snippet:     "snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule1", "file", "snippet"),
 					CodeFlow: [][]formats.Location{
 						{
 							{
@@ -1084,6 +1092,7 @@ func TestPrepareSast(t *testing.T) {
 						EndColumn:   8,
 						Snippet:     "other-snippet",
 					},
+					Fingerprint: GetSourceCodeFingerprint("rule1", "file2", "other-snippet"),
 				},
 			},
 		},
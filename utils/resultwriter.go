@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -26,6 +27,12 @@ const (
 const MissingCveScore = "0"
 const maxPossibleCve = 10.0
 
+// The SARIF baseline states of the results that are compared to a baseline.
+const (
+	newSarifBaselineState       = "new"
+	unchangedSarifBaselineState = "unchanged"
+)
+
 type ResultsWriter struct {
 	// The scan results.
 	results *Results
@@ -45,6 +52,8 @@ type ResultsWriter struct {
 	scanType services.ScanType
 	// Messages - Option array of messages, to be displayed if the format is Table
 	messages []string
+	// OutputFiles - Files in which the scan results should be written, in addition to the printed output.
+	outputFiles []OutputFile
 }
 
 func NewResultsWriter(scanResults *Results) *ResultsWriter {
@@ -86,6 +95,11 @@ func (rw *ResultsWriter) SetPrintExtendedTable(extendedTable bool) *ResultsWrite
 	return rw
 }
 
+func (rw *ResultsWriter) SetOutputFiles(outputFiles []OutputFile) *ResultsWriter {
+	rw.outputFiles = outputFiles
+	return rw
+}
+
 func (rw *ResultsWriter) SetExtraMessages(messages []string) *ResultsWriter {
 	rw.messages = messages
 	return rw
@@ -94,22 +108,94 @@ func (rw *ResultsWriter) SetExtraMessages(messages []string) *ResultsWriter {
 // PrintScanResults prints the scan results in the specified format.
 // Note that errors are printed only with SimpleJson format.
 func (rw *ResultsWriter) PrintScanResults() error {
-	switch rw.format {
-	case format.Table:
+	if rw.format == format.Table {
 		return rw.printScanResultsTables()
+	}
+	formattedResults, err := rw.convertScanResultsToString(rw.format)
+	if err != nil {
+		return err
+	}
+	log.Output(formattedResults)
+	return nil
+}
+
+// WriteScanResultsToFiles writes the scan results to each of the output files, in the file's format.
+func (rw *ResultsWriter) WriteScanResultsToFiles() error {
+	for _, outputFile := range rw.outputFiles {
+		if err := rw.writeScanResultsToFile(outputFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rw *ResultsWriter) writeScanResultsToFile(outputFile OutputFile) error {
+	formattedResults, err := rw.convertScanResultsToString(outputFile.Format)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckError(os.WriteFile(outputFile.Path, []byte(formattedResults), 0644)); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The scan results were written to %s in the %s format", outputFile.Path, outputFile.Format))
+	return nil
+}
+
+// Returns the scan results in the given format. The table format is printed directly to the terminal and can't be converted.
+func (rw *ResultsWriter) convertScanResultsToString(outputFormat format.OutputFormat) (string, error) {
+	switch outputFormat {
 	case format.SimpleJson:
 		jsonTable, err := rw.convertScanToSimpleJson()
 		if err != nil {
-			return err
+			return "", err
 		}
-		return PrintJson(jsonTable)
+		return ConvertJsonToString(jsonTable)
 	case format.Json:
-		return PrintJson(rw.results.GetScaScansXrayResults())
+		return ConvertJsonToString(rw.results.GetScaScansXrayResults())
 	case format.Sarif:
-		return PrintSarif(rw.results, rw.isMultipleRoots, rw.includeLicenses)
+		sarifReport, err := GenereateSarifReportFromResults(rw.results, rw.isMultipleRoots, rw.includeLicenses, nil)
+		if err != nil {
+			return "", err
+		}
+		return ConvertSarifReportToString(sarifReport)
+	case CycloneDx:
+		return ConvertCycloneDxBomToString(GenerateCycloneDxBom(rw.results))
+	case Spdx:
+		return ConvertSpdxDocumentToString(GenerateSpdxDocument(rw.results))
+	case Junit:
+		junitReport, err := GenerateJunitReport(rw.results, rw.isMultipleRoots, rw.includeLicenses)
+		if err != nil {
+			return "", err
+		}
+		return ConvertJunitReportToString(junitReport)
+	case Html:
+		jsonTable, err := rw.convertScanToSimpleJson()
+		if err != nil {
+			return "", err
+		}
+		return ConvertSimpleJsonToHtmlReport(jsonTable, rw.results, rw.includeVulnerabilities)
+	case Markdown:
+		jsonTable, err := rw.convertScanToSimpleJson()
+		if err != nil {
+			return "", err
+		}
+		return ConvertSimpleJsonToMarkdown(jsonTable, rw.includeVulnerabilities, rw.results.ExtendedScanResults.EntitledForJas), nil
+	case OpenVex:
+		return ConvertOpenVexDocumentToString(GenerateOpenVexDocument(rw.results))
+	case GitlabDependencyScanning:
+		gitlabReport, err := GenerateGitlabDependencyScanningReport(rw.results, rw.isMultipleRoots)
+		if err != nil {
+			return "", err
+		}
+		return ConvertGitlabReportToString(gitlabReport)
+	case GitlabSast:
+		return ConvertGitlabReportToString(GenerateGitlabSastReport(rw.results))
+	case GitlabSecretDetection:
+		return ConvertGitlabReportToString(GenerateGitlabSecretDetectionReport(rw.results))
 	}
-	return nil
+	return "", errorutils.CheckErrorf("unsupported output format: '%s'", outputFormat)
 }
+
 func (rw *ResultsWriter) printScanResultsTables() (err error) {
 	printMessages(rw.messages)
 	violations, vulnerabilities, licenses := SplitScanResults(rw.results.ScaResults)
@@ -129,6 +215,9 @@ func (rw *ResultsWriter) printScanResultsTables() (err error) {
 	if err != nil {
 		return
 	}
+	if err = PrintScaScanErrorsTable(rw.results); err != nil {
+		return
+	}
 	if rw.includeLicenses {
 		if err = PrintLicensesTable(licenses, rw.printExtended, rw.scanType); err != nil {
 			return
@@ -167,14 +256,97 @@ func GenereateSarifReportFromResults(results *Results, isMultipleRoots, includeL
 	}
 
 	report.Runs = append(report.Runs, xrayRun)
+	setSourceCodeSarifFingerprints(results.ExtendedScanResults.ApplicabilityScanResults)
+	setSourceCodeSarifFingerprints(results.ExtendedScanResults.IacScanResults)
+	setSourceCodeSarifFingerprints(results.ExtendedScanResults.SecretsScanResults)
+	setSourceCodeSarifFingerprints(results.ExtendedScanResults.SastScanResults)
+	iacRuns, secretsRuns, sastRuns := results.ExtendedScanResults.IacScanResults, results.ExtendedScanResults.SecretsScanResults, results.ExtendedScanResults.SastScanResults
+	if results.Existing != nil {
+		// Compared to a baseline, the findings are reported as new, and the existing findings are reported as unchanged alongside them.
+		setSarifBaselineState(append([]*sarif.Run{xrayRun}, append(append(slices.Clone(iacRuns), secretsRuns...), sastRuns...)...), newSarifBaselineState)
+		if err = addExistingScaResultsToSarifRun(xrayRun, results.Existing, isMultipleRoots, includeLicenses, allowedLicenses); err != nil {
+			return
+		}
+		existingExtended := results.Existing.Results.ExtendedScanResults
+		for _, existingRuns := range [][]*sarif.Run{existingExtended.IacScanResults, existingExtended.SecretsScanResults, existingExtended.SastScanResults} {
+			setSourceCodeSarifFingerprints(existingRuns)
+			setSarifBaselineState(existingRuns, unchangedSarifBaselineState)
+		}
+		iacRuns = mergeSarifRunsResults(iacRuns, existingExtended.IacScanResults)
+		secretsRuns = mergeSarifRunsResults(secretsRuns, existingExtended.SecretsScanResults)
+		sastRuns = mergeSarifRunsResults(sastRuns, existingExtended.SastScanResults)
+	}
+	if results.Suppressed != nil {
+		// The suppressed findings are reported with their SARIF suppressions, alongside the rest of the findings.
+		if err = addSuppressedScaResultsToSarifRun(xrayRun, results.Suppressed, isMultipleRoots, includeLicenses, allowedLicenses); err != nil {
+			return
+		}
+		suppressedExtended := results.Suppressed.Results.ExtendedScanResults
+		iacRuns = mergeSarifRunsResults(iacRuns, suppressedExtended.IacScanResults)
+		secretsRuns = mergeSarifRunsResults(secretsRuns, suppressedExtended.SecretsScanResults)
+		sastRuns = mergeSarifRunsResults(sastRuns, suppressedExtended.SastScanResults)
+	}
 	report.Runs = append(report.Runs, results.ExtendedScanResults.ApplicabilityScanResults...)
-	report.Runs = append(report.Runs, results.ExtendedScanResults.IacScanResults...)
-	report.Runs = append(report.Runs, results.ExtendedScanResults.SecretsScanResults...)
-	report.Runs = append(report.Runs, results.ExtendedScanResults.SastScanResults...)
+	report.Runs = append(report.Runs, iacRuns...)
+	report.Runs = append(report.Runs, secretsRuns...)
+	report.Runs = append(report.Runs, sastRuns...)
 
 	return
 }
 
+func addSuppressedScaResultsToSarifRun(xrayRun *sarif.Run, suppressed *SuppressedFindings, isMultipleRoots, includeLicenses bool, allowedLicenses []string) error {
+	suppressedRun, err := convertXrayResponsesToSarifRun(suppressed.Results, isMultipleRoots, includeLicenses, allowedLicenses)
+	if err != nil {
+		return err
+	}
+	suppressed.setScaSarifSuppressions(suppressedRun)
+	mergeSarifRunRulesAndResults(xrayRun, suppressedRun)
+	return nil
+}
+
+func addExistingScaResultsToSarifRun(xrayRun *sarif.Run, existing *ExistingFindings, isMultipleRoots, includeLicenses bool, allowedLicenses []string) error {
+	existingRun, err := convertXrayResponsesToSarifRun(existing.Results, isMultipleRoots, includeLicenses, allowedLicenses)
+	if err != nil {
+		return err
+	}
+	setSarifBaselineState([]*sarif.Run{existingRun}, unchangedSarifBaselineState)
+	mergeSarifRunRulesAndResults(xrayRun, existingRun)
+	return nil
+}
+
+func mergeSarifRunRulesAndResults(run, addedRun *sarif.Run) {
+	for _, rule := range addedRun.Tool.Driver.Rules {
+		if existing, _ := run.GetRuleById(rule.ID); existing == nil {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+	}
+	run.Results = append(run.Results, addedRun.Results...)
+}
+
+// Returns copies of the runs with the added results of each run, so the runs of the results aren't changed.
+// The added runs, of the suppressed or the existing results, are in the same order as the runs they were excluded from.
+func mergeSarifRunsResults(runs, addedRuns []*sarif.Run) []*sarif.Run {
+	merged := make([]*sarif.Run, 0, len(runs))
+	for i, run := range runs {
+		if i >= len(addedRuns) || len(addedRuns[i].Results) == 0 {
+			merged = append(merged, run)
+			continue
+		}
+		mergedRun := *run
+		mergedRun.Results = append(slices.Clone(run.Results), addedRuns[i].Results...)
+		merged = append(merged, &mergedRun)
+	}
+	return merged
+}
+
+func setSarifBaselineState(runs []*sarif.Run, state string) {
+	for _, run := range runs {
+		for _, result := range run.Results {
+			result.WithBaselineState(state)
+		}
+	}
+}
+
 func ConvertSarifReportToString(report *sarif.Report) (sarifStr string, err error) {
 	out, err := json.Marshal(report)
 	if err != nil {
@@ -183,47 +355,51 @@ func ConvertSarifReportToString(report *sarif.Report) (sarifStr string, err erro
 	return clientUtils.IndentJson(out), nil
 }
 
+// The issues of each scanned project are converted separately, so their locations point at the descriptors in the project's working directory.
 func convertXrayResponsesToSarifRun(results *Results, isMultipleRoots, includeLicenses bool, allowedLicenses []string) (run *sarif.Run, err error) {
-	xrayJson, err := ConvertXrayScanToSimpleJson(results, isMultipleRoots, includeLicenses, true, allowedLicenses)
-	if err != nil {
-		return
-	}
 	xrayRun := sarif.NewRunWithInformationURI("JFrog Xray SCA", BaseDocumentationURL+"sca")
 	xrayRun.Tool.Driver.Version = &results.XrayVersion
-	if len(xrayJson.Vulnerabilities) > 0 || len(xrayJson.SecurityViolations) > 0 || len(xrayJson.LicensesViolations) > 0 {
-		if err = extractXrayIssuesToSarifRun(xrayRun, xrayJson); err != nil {
+	for _, scaResult := range results.ScaResults {
+		scanResults := &Results{ScaResults: []ScaScanResult{scaResult}, ExtendedScanResults: results.ExtendedScanResults}
+		var xrayJson formats.SimpleJsonResults
+		if xrayJson, err = ConvertXrayScanToSimpleJson(scanResults, isMultipleRoots, includeLicenses, true, allowedLicenses); err != nil {
 			return
 		}
+		if len(xrayJson.Vulnerabilities) > 0 || len(xrayJson.SecurityViolations) > 0 || len(xrayJson.LicensesViolations) > 0 {
+			if err = extractXrayIssuesToSarifRun(xrayRun, xrayJson, newDescriptorsLocator(scaResult)); err != nil {
+				return
+			}
+		}
 	}
 	run = xrayRun
 	return
 }
 
-func extractXrayIssuesToSarifRun(run *sarif.Run, xrayJson formats.SimpleJsonResults) error {
+func extractXrayIssuesToSarifRun(run *sarif.Run, xrayJson formats.SimpleJsonResults, locator *descriptorsLocator) error {
 	for _, vulnerability := range xrayJson.Vulnerabilities {
-		if err := addXrayCveIssueToSarifRun(vulnerability, run); err != nil {
+		if err := addXrayCveIssueToSarifRun(vulnerability, locator, run); err != nil {
 			return err
 		}
 	}
 	for _, violation := range xrayJson.SecurityViolations {
-		if err := addXrayCveIssueToSarifRun(violation, run); err != nil {
+		if err := addXrayCveIssueToSarifRun(violation, locator, run); err != nil {
 			return err
 		}
 	}
 	for _, license := range xrayJson.LicensesViolations {
-		if err := addXrayLicenseViolationToSarifRun(license, run); err != nil {
+		if err := addXrayLicenseViolationToSarifRun(license, locator, run); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func addXrayCveIssueToSarifRun(issue formats.VulnerabilityOrViolationRow, run *sarif.Run) (err error) {
+func addXrayCveIssueToSarifRun(issue formats.VulnerabilityOrViolationRow, locator *descriptorsLocator, run *sarif.Run) (err error) {
 	maxCveScore, err := findMaxCVEScore(issue.Cves)
 	if err != nil {
 		return
 	}
-	location, err := getXrayIssueLocationIfValidExists(issue.Technology, run)
+	defaultLocation, err := getXrayIssueLocationIfValidExists(issue.Technology, run)
 	if err != nil {
 		return
 	}
@@ -243,13 +419,13 @@ func addXrayCveIssueToSarifRun(issue formats.VulnerabilityOrViolationRow, run *s
 		getXrayIssueSarifHeadline(issue.ImpactedDependencyName, issue.ImpactedDependencyVersion, cveId),
 		markdownDescription,
 		issue.Components,
-		location,
+		getDirectDependenciesLocations(issue.Components, locator, defaultLocation),
 		run,
 	)
 	return
 }
 
-func addXrayLicenseViolationToSarifRun(license formats.LicenseRow, run *sarif.Run) (err error) {
+func addXrayLicenseViolationToSarifRun(license formats.LicenseRow, locator *descriptorsLocator, run *sarif.Run) (err error) {
 	formattedDirectDependencies, err := getDirectDependenciesFormatted(license.Components)
 	if err != nil {
 		return
@@ -264,23 +440,40 @@ func addXrayLicenseViolationToSarifRun(license formats.LicenseRow, run *sarif.Ru
 		getXrayLicenseSarifHeadline(license.ImpactedDependencyName, license.ImpactedDependencyVersion, license.LicenseKey),
 		getLicenseViolationMarkdown(license.ImpactedDependencyName, license.ImpactedDependencyVersion, license.LicenseKey, formattedDirectDependencies),
 		license.Components,
-		getXrayIssueLocation(""),
+		getDirectDependenciesLocations(license.Components, locator, getXrayIssueLocation("")),
 		run,
 	)
 	return
 }
 
-func addXrayIssueToSarifRun(issueId, impactedDependencyName, impactedDependencyVersion, severity, severityScore, summary, title, markdownDescription string, components []formats.ComponentRow, location *sarif.Location, run *sarif.Run) {
+// Returns the location of each direct dependency declaration, or the default location if the descriptors of the project are unknown.
+func getDirectDependenciesLocations(directDependencies []formats.ComponentRow, locator *descriptorsLocator, defaultLocation *sarif.Location) []*sarif.Location {
+	locations := make([]*sarif.Location, 0, len(directDependencies))
+	for _, directDependency := range directDependencies {
+		location := locator.getDirectDependencyLocation(directDependency)
+		if location == nil {
+			location = defaultLocation
+		}
+		locations = append(locations, location)
+	}
+	return locations
+}
+
+func addXrayIssueToSarifRun(issueId, impactedDependencyName, impactedDependencyVersion, severity, severityScore, summary, title, markdownDescription string, components []formats.ComponentRow, locations []*sarif.Location, run *sarif.Run) {
 	// Add rule if not exists
 	ruleId := getXrayIssueSarifRuleId(impactedDependencyName, impactedDependencyVersion, issueId)
 	if rule, _ := run.GetRuleById(ruleId); rule == nil {
 		addXrayRule(ruleId, title, severityScore, summary, markdownDescription, run)
 	}
 	// Add result for each component
-	for _, directDependency := range components {
+	for i, directDependency := range components {
 		msg := getXrayIssueSarifHeadline(directDependency.Name, directDependency.Version, issueId)
-		if result := run.CreateResultForRule(ruleId).WithMessage(sarif.NewTextMessage(msg)).WithLevel(ConvertToSarifLevel(severity)); location != nil {
-			result.AddLocation(location)
+		result := run.CreateResultForRule(ruleId).WithMessage(sarif.NewTextMessage(msg)).WithLevel(ConvertToSarifLevel(severity))
+		if locations[i] != nil {
+			result.AddLocation(locations[i])
+		}
+		if directDependency.Fingerprint != "" {
+			setResultFingerprint(result, directDependency.Fingerprint)
 		}
 	}
 
@@ -365,6 +558,7 @@ func ConvertXrayScanToSimpleJson(results *Results, isMultipleRoots, includeLicen
 		jsonTable.LicensesViolations = licViolationsJsonTable
 		jsonTable.OperationalRiskViolations = opRiskViolationsJsonTable
 	}
+	setScaFingerprints(&jsonTable)
 	jsonTable.MultiScanId = results.MultiScanId
 	return jsonTable, nil
 }
@@ -395,7 +589,14 @@ func (rw *ResultsWriter) convertScanToSimpleJson() (formats.SimpleJsonResults, e
 	if len(rw.results.ExtendedScanResults.SastScanResults) > 0 {
 		jsonTable.Sast = PrepareSast(rw.results.ExtendedScanResults.SastScanResults)
 	}
-	jsonTable.Errors = rw.simpleJsonError
+	// The errors of the failed SCA scans are reported alongside the errors that were set on the writer.
+	jsonTable.Errors = append(slices.Clone(rw.simpleJsonError), rw.results.GetScaScansErrors()...)
+	if rw.results.Suppressed != nil {
+		jsonTable.Suppressed = rw.results.Suppressed.Rows
+	}
+	if rw.results.Existing != nil {
+		jsonTable.Existing = rw.results.Existing.Rows
+	}
 
 	return jsonTable, nil
 }
@@ -446,6 +647,25 @@ func getDirectDependenciesFormatted(directDependencies []formats.ComponentRow) (
 	return strings.TrimSuffix(formattedDirectDependencies.String(), "<br/>"), nil
 }
 
+func getDependencyDisplayName(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + ":" + version
+}
+
+func getImpactPathFormatted(impactPath []formats.ComponentRow) string {
+	var nodes []string
+	for _, node := range impactPath {
+		nodes = append(nodes, getDependencyDisplayName(node.Name, node.Version))
+	}
+	return strings.Join(nodes, " > ")
+}
+
+func getUpgradeRecommendation(dependencyName string, fixedVersions []string) string {
+	return fmt.Sprintf("Upgrade %s to one of the following versions: %s", dependencyName, strings.Join(fixedVersions, ", "))
+}
+
 func getSarifTableDescription(formattedDirectDependencies, maxCveScore, applicable string, fixedVersions []string) string {
 	descriptionFixVersions := "No fix available"
 	if len(fixedVersions) > 0 {
@@ -526,14 +746,22 @@ func writeJsonResults(results *Results) (resultsPath string, err error) {
 }
 
 func PrintJson(output interface{}) error {
-	results, err := json.Marshal(output)
+	results, err := ConvertJsonToString(output)
 	if err != nil {
-		return errorutils.CheckError(err)
+		return err
 	}
-	log.Output(clientUtils.IndentJson(results))
+	log.Output(results)
 	return nil
 }
 
+func ConvertJsonToString(output interface{}) (string, error) {
+	results, err := json.Marshal(output)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return clientUtils.IndentJson(results), nil
+}
+
 func PrintSarif(results *Results, isMultipleRoots, includeLicenses bool) error {
 	sarifReport, err := GenereateSarifReportFromResults(results, isMultipleRoots, includeLicenses, nil)
 	if err != nil {
@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
 	"github.com/jfrog/jfrog-cli-security/formats"
@@ -401,3 +404,66 @@ func TestConvertXrayScanToSimpleJson(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteScanResultsToFiles(t *testing.T) {
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{{
+		Technology: coreutils.Npm,
+		XrayResults: []services.ScanResponse{{Vulnerabilities: []services.Vulnerability{{
+			IssueId:    "XRAY-1",
+			Severity:   "High",
+			Cves:       []services.Cve{{Id: "CVE-2024-1234"}},
+			Components: map[string]services.Component{"npm://lodash:4.17.0": {ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "npm://lodash:4.17.0"}}}}},
+		}}}},
+	}}
+	testDir := t.TempDir()
+	sarifPath := filepath.Join(testDir, "results.sarif")
+	simpleJsonPath := filepath.Join(testDir, "results.json")
+	writer := NewResultsWriter(results).
+		SetOutputFormat(format.Table).
+		SetIncludeVulnerabilities(true).
+		SetOutputFiles([]OutputFile{{Format: format.Sarif, Path: sarifPath}, {Format: format.SimpleJson, Path: simpleJsonPath}})
+	assert.NoError(t, writer.WriteScanResultsToFiles())
+
+	content, err := os.ReadFile(sarifPath)
+	assert.NoError(t, err)
+	report, err := sarif.FromBytes(content)
+	assert.NoError(t, err)
+	assert.Len(t, report.Runs, 1)
+
+	content, err = os.ReadFile(simpleJsonPath)
+	assert.NoError(t, err)
+	var simpleJson formats.SimpleJsonResults
+	assert.NoError(t, json.Unmarshal(content, &simpleJson))
+	if assert.Len(t, simpleJson.Vulnerabilities, 1) {
+		assert.Equal(t, "lodash", simpleJson.Vulnerabilities[0].ImpactedDependencyName)
+	}
+
+	assert.Error(t, NewResultsWriter(results).SetOutputFiles([]OutputFile{{Format: format.Table, Path: filepath.Join(testDir, "results.txt")}}).WriteScanResultsToFiles())
+}
+
+func TestScaScanErrorsInSimpleJson(t *testing.T) {
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{
+		{Technology: coreutils.Npm, WorkingDirectory: "/app", XrayResults: []services.ScanResponse{{}}},
+		{Technology: coreutils.Pnpm, WorkingDirectory: "/web", ScanError: NewScaScanError(DependencyTreePhase, errors.New("failed to resolve"))},
+		{Technology: coreutils.Go, WorkingDirectory: "/api", ScanError: NewScaScanError(XrayScanPhase, errors.New("request timed out"))},
+	}
+	assert.True(t, results.IsAnyScaScanSucceeded())
+	assert.Equal(t, []coreutils.Technology{coreutils.Npm}, results.GetScaScannedTechnologies())
+
+	writer := NewResultsWriter(results).SetSimpleJsonError([]formats.SimpleJsonError{{FilePath: "/other", ErrorMessage: "other error"}})
+	simpleJson, err := writer.convertScanToSimpleJson()
+	assert.NoError(t, err)
+	assert.Equal(t, []formats.SimpleJsonError{
+		{FilePath: "/other", ErrorMessage: "other error"},
+		{FilePath: "/web", ErrorMessage: "failed to resolve", Technology: "pnpm", Phase: string(DependencyTreePhase)},
+		{FilePath: "/api", ErrorMessage: "request timed out", Technology: "go", Phase: string(XrayScanPhase)},
+	}, simpleJson.Errors)
+	// The errors of the failed scans aren't added to the errors that were set on the writer.
+	assert.Len(t, writer.simpleJsonError, 1)
+
+	results.ScaResults = results.ScaResults[1:]
+	assert.False(t, results.IsAnyScaScanSucceeded())
+	assert.Empty(t, results.GetScaScannedTechnologies())
+}
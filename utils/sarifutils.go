@@ -121,6 +121,13 @@ func GetResultMsgText(result *sarif.Result) string {
 	return ""
 }
 
+func GetResultRuleId(result *sarif.Result) string {
+	if result.RuleID != nil {
+		return *result.RuleID
+	}
+	return ""
+}
+
 func GetLocationSnippet(location *sarif.Location) string {
 	region := getLocationRegion(location)
 	if region != nil && region.Snippet != nil {
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// The path of the security configuration file, relative to the directory the command runs in.
+var SecurityConfigFilePath = filepath.Join(".jfrog", "security.yml")
+
+// The security configuration of a repository, checked in to the repository to avoid repeating the same options in every pipeline.
+// The schema of the file is in resources/security-config-schema.json.
+type SecurityConfig struct {
+	Audit AuditConfig `yaml:"audit"`
+}
+
+// The defaults of the options of the audit command. The keys are the names of the options of the command.
+// The options that are provided to the command override the values of the configuration.
+type AuditConfig struct {
+	Watches                      []string          `yaml:"watches"`
+	Project                      string            `yaml:"project"`
+	RepoPath                     string            `yaml:"repo-path"`
+	Licenses                     *bool             `yaml:"licenses"`
+	Format                       string            `yaml:"format"`
+	Output                       map[string]string `yaml:"output"`
+	ReportFile                   string            `yaml:"report-file"`
+	CsvDir                       string            `yaml:"csv-dir"`
+	Baseline                     string            `yaml:"baseline"`
+	ChangedSince                 string            `yaml:"changed-since"`
+	ExportDeps                   string            `yaml:"export-deps"`
+	FromDeps                     string            `yaml:"from-deps"`
+	ExcludeTestDeps              *bool             `yaml:"exclude-test-deps"`
+	UseWrapper                   *bool             `yaml:"use-wrapper"`
+	DepType                      string            `yaml:"dep-type"`
+	RequirementsFile             string            `yaml:"requirements-file"`
+	Fail                         *bool             `yaml:"fail"`
+	FailOn                       []string          `yaml:"fail-on"`
+	FailOnSecrets                *bool             `yaml:"fail-on-secrets"`
+	FailOnCvss                   *float64          `yaml:"fail-on-cvss"`
+	ExtendedTable                *bool             `yaml:"extended-table"`
+	WorkingDirs                  []string          `yaml:"working-dirs"`
+	Exclusions                   []string          `yaml:"exclusions"`
+	Technologies                 []string          `yaml:"technologies"`
+	MinSeverity                  string            `yaml:"min-severity"`
+	FixableOnly                  *bool             `yaml:"fixable-only"`
+	ThirdPartyContextualAnalysis *bool             `yaml:"third-party-contextual-analysis"`
+	Threads                      *int              `yaml:"threads"`
+	Scanners                     []string          `yaml:"scanners"`
+	Timeout                      string            `yaml:"timeout"`
+	InsecureTls                  *bool             `yaml:"insecure-tls"`
+}
+
+var npmDepTypes = []string{"all", "devOnly", "prodOnly"}
+
+// Loads and validates the security configuration file in the given path. Returns nil if the file doesn't exist.
+func LoadSecurityConfig(path string) (*SecurityConfig, error) {
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	config, err := parseSecurityConfig(content)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid security configuration file '%s': %s", path, err.Error())
+	}
+	return config, nil
+}
+
+func parseSecurityConfig(content []byte) (*SecurityConfig, error) {
+	config := &SecurityConfig{}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		// An empty file.
+		return config, nil
+	}
+	if err := validateConfigKeys(root.Content[0], reflect.TypeOf(*config), ""); err != nil {
+		return nil, err
+	}
+	if err := root.Decode(config); err != nil {
+		return nil, err
+	}
+	if err := config.Audit.validate(); err != nil {
+		return nil, fmt.Errorf("audit.%s", err.Error())
+	}
+	return config, nil
+}
+
+// Validates that the mapping node has only the keys of the yaml fields of the given struct, recursively, so typos aren't ignored silently.
+// The items of lists of structs are validated as well.
+func validateConfigKeys(node *yaml.Node, structType reflect.Type, keyPrefix string) error {
+	if node.Kind != yaml.MappingNode {
+		if keyPrefix == "" {
+			return fmt.Errorf("line %d: expected a mapping of the configuration sections", node.Line)
+		}
+		return fmt.Errorf("line %d: '%s' must be a mapping", node.Line, strings.TrimSuffix(keyPrefix, "."))
+	}
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.IsExported() {
+			fields[strings.Split(field.Tag.Get("yaml"), ",")[0]] = field
+		}
+	}
+	// The mapping content holds the keys and the values alternately.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, exists := fields[key.Value]
+		if !exists {
+			keys := make([]string, 0, len(fields))
+			for fieldKey := range fields {
+				keys = append(keys, fieldKey)
+			}
+			sort.Strings(keys)
+			return fmt.Errorf("line %d: unknown option '%s%s', the supported options are: %s", key.Line, keyPrefix, key.Value, strings.Join(keys, ", "))
+		}
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			if err := validateConfigKeys(value, field.Type, keyPrefix+key.Value+"."); err != nil {
+				return err
+			}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct && value.Kind == yaml.SequenceNode:
+			for j, item := range value.Content {
+				if err := validateConfigKeys(item, field.Type.Elem(), fmt.Sprintf("%s%s[%d].", keyPrefix, key.Value, j)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validates the values of the options the same way the options of the command are validated.
+// The returned error starts with the name of the invalid option.
+func (ac *AuditConfig) validate() error {
+	contextOptions := 0
+	for _, contextValue := range []string{strings.Join(ac.Watches, ""), ac.Project, ac.RepoPath} {
+		if contextValue != "" {
+			contextOptions++
+		}
+	}
+	if contextOptions > 1 {
+		return fmt.Errorf("watches: only one of the following options can be set: watches, project or repo-path")
+	}
+	if ac.Format != "" {
+		if _, err := GetOutputFormat(ac.Format); err != nil {
+			return fmt.Errorf("format: %s", err.Error())
+		}
+	}
+	if _, err := ParseOutputFiles(ac.OutputFlagValue()); err != nil {
+		return fmt.Errorf("output: %s", err.Error())
+	}
+	for _, expression := range ac.FailOn {
+		if _, err := ParseFailCondition(expression); err != nil {
+			return fmt.Errorf("fail-on: %s", err.Error())
+		}
+	}
+	if ac.FailOnCvss != nil && (*ac.FailOnCvss < 0 || *ac.FailOnCvss > 10) {
+		return fmt.Errorf("fail-on-cvss: expected a CVSS score between 0 and 10, got %v", *ac.FailOnCvss)
+	}
+	if ac.DepType != "" && !slices.Contains(npmDepTypes, ac.DepType) {
+		return fmt.Errorf("dep-type: invalid value '%s', the supported values are: %s", ac.DepType, strings.Join(npmDepTypes, ", "))
+	}
+	if ac.MinSeverity != "" {
+		if _, err := GetSeveritiesFormat(ac.MinSeverity); err != nil {
+			return fmt.Errorf("min-severity: %s", err.Error())
+		}
+	}
+	if ac.Threads != nil && *ac.Threads <= 0 {
+		return fmt.Errorf("threads: the number of threads must be greater than 0, got %d", *ac.Threads)
+	}
+	if _, err := ParseSubScans(strings.Join(ac.Scanners, ",")); err != nil {
+		return fmt.Errorf("scanners: %s", err.Error())
+	}
+	if _, err := ParseAuditTimeouts(ac.Timeout); err != nil {
+		return fmt.Errorf("timeout: %s", err.Error())
+	}
+	return ac.validateTechnologies()
+}
+
+func (ac *AuditConfig) validateTechnologies() error {
+	var supported []string
+	for _, tech := range GetAllTechnologiesList() {
+		supported = append(supported, tech.String())
+	}
+	sort.Strings(supported)
+	for _, tech := range ac.Technologies {
+		if !slices.Contains(supported, tech) {
+			return fmt.Errorf("technologies: unsupported technology '%s', the supported technologies are: %s", tech, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// Returns the output files of the configuration in the format of the 'output' option: a comma-separated list of <format>=<file path> pairs.
+func (ac *AuditConfig) OutputFlagValue() string {
+	formats := make([]string, 0, len(ac.Output))
+	for outputFormat := range ac.Output {
+		formats = append(formats, outputFormat)
+	}
+	sort.Strings(formats)
+	pairs := make([]string, 0, len(formats))
+	for _, outputFormat := range formats {
+		pairs = append(pairs, outputFormat+"="+ac.Output[outputFormat])
+	}
+	return strings.Join(pairs, ",")
+}
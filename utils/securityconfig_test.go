@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecurityConfig(t *testing.T) {
+	config, err := parseSecurityConfig([]byte(`
+audit:
+  watches: [watch1, watch2]
+  format: sarif
+  output:
+    simple-json: results.json
+    html: report.html
+  exclusions:
+    - "*node_modules*"
+    - "*test*"
+  technologies: [npm, go]
+  min-severity: high
+  fail: false
+  threads: 5
+  scanners: [sca, secrets]
+  timeout: total=30m,sca=10m
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"watch1", "watch2"}, config.Audit.Watches)
+	assert.Equal(t, "sarif", config.Audit.Format)
+	assert.Equal(t, "html=report.html,simple-json=results.json", config.Audit.OutputFlagValue())
+	assert.Equal(t, []string{"*node_modules*", "*test*"}, config.Audit.Exclusions)
+	assert.Equal(t, []string{"npm", "go"}, config.Audit.Technologies)
+	require.NotNil(t, config.Audit.Fail)
+	assert.False(t, *config.Audit.Fail)
+	assert.Nil(t, config.Audit.Licenses)
+	require.NotNil(t, config.Audit.Threads)
+	assert.Equal(t, 5, *config.Audit.Threads)
+
+	config, err = parseSecurityConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Equal(t, &SecurityConfig{}, config)
+}
+
+func TestParseSecurityConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "Unknown section", content: "scan:\n  fail: true", expectedError: "line 1: unknown option 'scan', the supported options are: audit"},
+		{name: "Unknown option", content: "audit:\n  fail: true\n  min-severty: High", expectedError: "line 3: unknown option 'audit.min-severty'"},
+		{name: "Not a mapping", content: "audit: true", expectedError: "line 1: 'audit' must be a mapping"},
+		{name: "Wrong type", content: "audit:\n  threads: many", expectedError: "line 2: cannot unmarshal"},
+		{name: "Invalid format", content: "audit:\n  format: pdf", expectedError: "audit.format: only the following output formats are supported"},
+		{name: "Invalid output", content: "audit:\n  output:\n    table: results.txt", expectedError: "audit.output: the table format can't be written to a file"},
+		{name: "Invalid fail condition", content: "audit:\n  fail-on: ['severity>=severe']", expectedError: "audit.fail-on: invalid fail condition 'severity>=severe'"},
+		{name: "Invalid fail CVSS", content: "audit:\n  fail-on-cvss: 11", expectedError: "audit.fail-on-cvss: expected a CVSS score between 0 and 10"},
+		{name: "Invalid dep type", content: "audit:\n  dep-type: dev", expectedError: "audit.dep-type: invalid value 'dev'"},
+		{name: "Invalid severity", content: "audit:\n  min-severity: Severe", expectedError: "audit.min-severity: only the following severities are supported"},
+		{name: "Invalid threads", content: "audit:\n  threads: 0", expectedError: "audit.threads: the number of threads must be greater than 0"},
+		{name: "Invalid scanner", content: "audit:\n  scanners: [sca, dast]", expectedError: "audit.scanners: unsupported scanner 'dast'"},
+		{name: "Invalid timeout", content: "audit:\n  timeout: soon", expectedError: "audit.timeout: invalid timeout duration 'soon'"},
+		{name: "Unsupported technology", content: "audit:\n  technologies: [swift]", expectedError: "audit.technologies: unsupported technology 'swift'"},
+		{name: "Watches with project", content: "audit:\n  watches: [watch]\n  project: key", expectedError: "audit.watches: only one of the following options can be set"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := parseSecurityConfig([]byte(testCase.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
+
+func TestLoadSecurityConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, SecurityConfigFilePath)
+	// A missing file isn't an error.
+	config, err := LoadSecurityConfig(configPath)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte("audit:\n  working-dirs: [frontend, backend]\n"), 0644))
+	config, err = LoadSecurityConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "backend"}, config.Audit.WorkingDirs)
+
+	require.NoError(t, os.WriteFile(configPath, []byte("audit:\n  threads: -1\n"), 0644))
+	_, err = LoadSecurityConfig(configPath)
+	assert.ErrorContains(t, err, "invalid security configuration file '"+configPath+"': audit.threads")
+}
+
+func TestSecurityConfigSchemaMatchesConfig(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("resources", "security-config-schema.json"))
+	require.NoError(t, err)
+	var schema struct {
+		Properties struct {
+			Audit struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"audit"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(content, &schema))
+	configType := reflect.TypeOf(AuditConfig{})
+	var configKeys []string
+	for i := 0; i < configType.NumField(); i++ {
+		configKeys = append(configKeys, strings.Split(configType.Field(i).Tag.Get("yaml"), ",")[0])
+	}
+	var schemaKeys []string
+	for key := range schema.Properties.Audit.Properties {
+		schemaKeys = append(schemaKeys, key)
+	}
+	assert.ElementsMatch(t, configKeys, schemaKeys)
+
+	var technologiesSchema struct {
+		Items struct {
+			Enum []string `json:"enum"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(schema.Properties.Audit.Properties["technologies"], &technologiesSchema))
+	var technologies []string
+	for _, tech := range GetAllTechnologiesList() {
+		technologies = append(technologies, tech.String())
+	}
+	assert.ElementsMatch(t, technologies, technologiesSchema.Items.Enum)
+}
@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfrog/gofrog/datastructures"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	spdxVersion           = "SPDX-2.3"
+	spdxDataLicense       = "CC0-1.0"
+	spdxDocumentId        = "SPDXRef-DOCUMENT"
+	spdxPackageIdPrefix   = "SPDXRef-Package-"
+	spdxLicenseRefPrefix  = "LicenseRef-"
+	spdxNoAssertion       = "NOASSERTION"
+	spdxDocumentNamespace = "https://jfrog.com/spdx/"
+	spdxDescribes         = "DESCRIBES"
+	spdxDependsOn         = "DEPENDS_ON"
+	unknownLicenseKey     = "Unknown"
+)
+
+var spdxInvalidIdChars = regexp.MustCompile(`[^a-zA-Z0-9.\-]`)
+
+// The structs below describe the subset of the SPDX 2.3 JSON schema that is generated from the scan results.
+// See https://spdx.github.io/spdx-spec/v2.3/
+type SpdxDocument struct {
+	SpdxVersion                string                       `json:"spdxVersion"`
+	DataLicense                string                       `json:"dataLicense"`
+	SpdxId                     string                       `json:"SPDXID"`
+	Name                       string                       `json:"name"`
+	DocumentNamespace          string                       `json:"documentNamespace"`
+	CreationInfo               SpdxCreationInfo             `json:"creationInfo"`
+	DocumentDescribes          []string                     `json:"documentDescribes,omitempty"`
+	Packages                   []SpdxPackage                `json:"packages"`
+	Relationships              []SpdxRelationship           `json:"relationships"`
+	HasExtractedLicensingInfos []SpdxExtractedLicensingInfo `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type SpdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SpdxPackage struct {
+	Name             string            `json:"name"`
+	SpdxId           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	ExternalRefs     []SpdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type SpdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SpdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+type SpdxExtractedLicensingInfo struct {
+	LicenseId     string `json:"licenseId"`
+	Name          string `json:"name"`
+	ExtractedText string `json:"extractedText"`
+}
+
+// GenerateSpdxDocument converts the SCA scan results to an SPDX 2.3 document.
+// Each component of the scanned dependency trees becomes an SPDX package, and each edge of the trees becomes a DEPENDS_ON relationship.
+// The licenses found by Xray for each component are set as the package's concluded license.
+func GenerateSpdxDocument(results *Results) *SpdxDocument {
+	graph := buildComponentsGraph(results)
+	componentsLicenses, extractedLicenses := getSpdxComponentsLicenses(results)
+	document := &SpdxDocument{
+		SpdxVersion:       spdxVersion,
+		DataLicense:       spdxDataLicense,
+		SpdxId:            spdxDocumentId,
+		Name:              getSpdxDocumentName(graph),
+		DocumentNamespace: spdxDocumentNamespace + uuid.NewString(),
+		CreationInfo: SpdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + xrayToolName + "-" + results.XrayVersion},
+		},
+		Packages:                   []SpdxPackage{},
+		Relationships:              []SpdxRelationship{},
+		HasExtractedLicensingInfos: extractedLicenses,
+	}
+	spdxIds := getSpdxPackagesIds(graph.componentIds())
+	for _, componentId := range graph.componentIds() {
+		document.Packages = append(document.Packages, toSpdxPackage(componentId, spdxIds[componentId], componentsLicenses[componentId]))
+	}
+	for _, rootId := range graph.rootIds() {
+		document.DocumentDescribes = append(document.DocumentDescribes, spdxIds[rootId])
+		document.Relationships = append(document.Relationships, SpdxRelationship{SpdxElementId: spdxDocumentId, RelationshipType: spdxDescribes, RelatedSpdxElement: spdxIds[rootId]})
+	}
+	for _, componentId := range graph.componentIds() {
+		for _, dependencyId := range graph.dependencies(componentId) {
+			document.Relationships = append(document.Relationships, SpdxRelationship{SpdxElementId: spdxIds[componentId], RelationshipType: spdxDependsOn, RelatedSpdxElement: spdxIds[dependencyId]})
+		}
+	}
+	return document
+}
+
+func ConvertSpdxDocumentToString(document *SpdxDocument) (string, error) {
+	out, err := json.Marshal(document)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return clientUtils.IndentJson(out), nil
+}
+
+func getSpdxDocumentName(graph *componentsGraph) string {
+	if roots := graph.rootIds(); len(roots) == 1 {
+		name, version, _ := SplitComponentId(roots[0])
+		return strings.TrimSuffix(name+"-"+version, "-")
+	}
+	return "jfrog-xray-scan"
+}
+
+// Generates a unique SPDX identifier for each component. Characters that are not allowed in SPDX identifiers are replaced with '-'.
+func getSpdxPackagesIds(componentIds []string) map[string]string {
+	spdxIds := map[string]string{}
+	usedIds := datastructures.MakeSet[string]()
+	for _, componentId := range componentIds {
+		spdxId := spdxPackageIdPrefix + spdxInvalidIdChars.ReplaceAllString(componentId, "-")
+		for i := 1; usedIds.Exists(spdxId); i++ {
+			spdxId = fmt.Sprintf("%s%s-%d", spdxPackageIdPrefix, spdxInvalidIdChars.ReplaceAllString(componentId, "-"), i)
+		}
+		usedIds.Add(spdxId)
+		spdxIds[componentId] = spdxId
+	}
+	return spdxIds
+}
+
+func toSpdxPackage(componentId, spdxId string, licenses []string) SpdxPackage {
+	name, version, _ := SplitComponentId(componentId)
+	spdxPackage := SpdxPackage{
+		Name:             name,
+		SpdxId:           spdxId,
+		VersionInfo:      version,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: toSpdxLicenseExpression(licenses),
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+	}
+	if purl := XrayComponentIdToPurl(componentId); purl != "" {
+		spdxPackage.ExternalRefs = []SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl}}
+	}
+	return spdxPackage
+}
+
+// Multiple licenses found for the same component are all required, so they are joined with 'AND'.
+func toSpdxLicenseExpression(licenses []string) string {
+	switch len(licenses) {
+	case 0:
+		return spdxNoAssertion
+	case 1:
+		return licenses[0]
+	}
+	sortedLicenses := slices.Clone(licenses)
+	slices.Sort(sortedLicenses)
+	return "(" + strings.Join(sortedLicenses, " AND ") + ")"
+}
+
+// Returns the SPDX license identifiers of each component, from the licenses and license violations returned by Xray.
+// Custom licenses are referenced with a 'LicenseRef-' identifier, and are returned as extracted licensing info.
+func getSpdxComponentsLicenses(results *Results) (componentsLicenses map[string][]string, extractedLicenses []SpdxExtractedLicensingInfo) {
+	componentsLicenses = map[string][]string{}
+	extractedIds := datastructures.MakeSet[string]()
+	addLicense := func(licenseKey, licenseName string, custom bool, components map[string]services.Component) {
+		if licenseKey == "" || licenseKey == unknownLicenseKey {
+			return
+		}
+		licenseId := licenseKey
+		if custom || spdxInvalidIdChars.MatchString(licenseKey) {
+			licenseId = spdxLicenseRefPrefix + spdxInvalidIdChars.ReplaceAllString(licenseKey, "-")
+			if !extractedIds.Exists(licenseId) {
+				extractedIds.Add(licenseId)
+				if licenseName == "" {
+					licenseName = licenseKey
+				}
+				extractedLicenses = append(extractedLicenses, SpdxExtractedLicensingInfo{LicenseId: licenseId, Name: licenseName, ExtractedText: spdxNoAssertion})
+			}
+		}
+		for componentId := range components {
+			if !slices.Contains(componentsLicenses[componentId], licenseId) {
+				componentsLicenses[componentId] = append(componentsLicenses[componentId], licenseId)
+			}
+		}
+	}
+	for _, scaResult := range results.ScaResults {
+		for _, xrayResult := range scaResult.XrayResults {
+			for _, license := range xrayResult.Licenses {
+				addLicense(license.Key, license.Name, license.Custom, license.Components)
+			}
+			for _, violation := range xrayResult.Violations {
+				if violation.ViolationType == "license" {
+					addLicense(violation.LicenseKey, violation.LicenseName, false, violation.Components)
+				}
+			}
+		}
+	}
+	return
+}
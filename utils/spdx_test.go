@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSpdxDocument(t *testing.T) {
+	results := NewAuditResults()
+	results.XrayVersion = "3.90.0"
+	results.ScaResults = []ScaScanResult{{
+		DependencyTrees: []*xrayUtils.GraphNode{{
+			Id: "npm://my-project:1.0.0",
+			Nodes: []*xrayUtils.GraphNode{
+				{Id: "npm://express:4.18.0", Nodes: []*xrayUtils.GraphNode{{Id: "npm://@types/qs:6.9.0"}}},
+			},
+		}},
+		XrayResults: []services.ScanResponse{{
+			Licenses: []services.License{
+				{Key: "MIT", Components: map[string]services.Component{"npm://express:4.18.0": {}, "npm://@types/qs:6.9.0": {}}},
+				{Key: "Apache-2.0", Components: map[string]services.Component{"npm://express:4.18.0": {}}},
+				{Key: "My Custom License", Name: "Custom", Custom: true, Components: map[string]services.Component{"npm://@types/qs:6.9.0": {}}},
+				{Key: "Unknown", Components: map[string]services.Component{"npm://my-project:1.0.0": {}}},
+			},
+		}},
+	}}
+
+	document := GenerateSpdxDocument(results)
+	assert.Equal(t, "SPDX-2.3", document.SpdxVersion)
+	assert.Equal(t, "my-project-1.0.0", document.Name)
+	assert.Equal(t, []string{"Tool: JFrog Xray-3.90.0"}, document.CreationInfo.Creators)
+	assert.Equal(t, []string{"SPDXRef-Package-npm---my-project-1.0.0"}, document.DocumentDescribes)
+
+	require.Len(t, document.Packages, 3)
+	typesPackage := document.Packages[0]
+	assert.Equal(t, "@types/qs", typesPackage.Name)
+	assert.Equal(t, "6.9.0", typesPackage.VersionInfo)
+	assert.Equal(t, "SPDXRef-Package-npm----types-qs-6.9.0", typesPackage.SpdxId)
+	assert.Equal(t, "(LicenseRef-My-Custom-License AND MIT)", typesPackage.LicenseConcluded)
+	assert.Equal(t, []SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:npm/%40types/qs@6.9.0"}}, typesPackage.ExternalRefs)
+	assert.Equal(t, "(Apache-2.0 AND MIT)", document.Packages[1].LicenseConcluded)
+	assert.Equal(t, spdxNoAssertion, document.Packages[2].LicenseConcluded)
+
+	assert.Equal(t, []SpdxExtractedLicensingInfo{{LicenseId: "LicenseRef-My-Custom-License", Name: "Custom", ExtractedText: spdxNoAssertion}}, document.HasExtractedLicensingInfos)
+	assert.ElementsMatch(t, []SpdxRelationship{
+		{SpdxElementId: spdxDocumentId, RelationshipType: spdxDescribes, RelatedSpdxElement: "SPDXRef-Package-npm---my-project-1.0.0"},
+		{SpdxElementId: "SPDXRef-Package-npm---my-project-1.0.0", RelationshipType: spdxDependsOn, RelatedSpdxElement: "SPDXRef-Package-npm---express-4.18.0"},
+		{SpdxElementId: "SPDXRef-Package-npm---express-4.18.0", RelationshipType: spdxDependsOn, RelatedSpdxElement: "SPDXRef-Package-npm----types-qs-6.9.0"},
+	}, document.Relationships)
+}
+
+func TestGetSpdxPackagesIds(t *testing.T) {
+	spdxIds := getSpdxPackagesIds([]string{"npm://a/b:1.0.0", "npm://a:b:1.0.0"})
+	assert.Equal(t, "SPDXRef-Package-npm---a-b-1.0.0", spdxIds["npm://a/b:1.0.0"])
+	assert.Equal(t, "SPDXRef-Package-npm---a-b-1.0.0-1", spdxIds["npm://a:b:1.0.0"])
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+// SubScanType is one of the scanners that the audit command runs.
+type SubScanType string
+
+const (
+	ScaScan                SubScanType = "sca"
+	ContextualAnalysisScan SubScanType = "contextual-analysis"
+	SecretsScan            SubScanType = "secrets"
+	IacScan                SubScanType = "iac"
+	SastScan               SubScanType = "sast"
+)
+
+func GetAllSubScanTypes() []SubScanType {
+	return []SubScanType{ScaScan, ContextualAnalysisScan, SecretsScan, IacScan, SastScan}
+}
+
+// ParseSubScans parses a comma-separated list of scanners, for example: 'sca,secrets'.
+// An empty value stands for all the scanners.
+func ParseSubScans(subScansFlagVal string) (subScans []SubScanType, err error) {
+	if strings.TrimSpace(subScansFlagVal) == "" {
+		return
+	}
+	supported := []string{}
+	for _, subScan := range GetAllSubScanTypes() {
+		supported = append(supported, string(subScan))
+	}
+	for _, subScanVal := range strings.Split(subScansFlagVal, ",") {
+		subScan := SubScanType(strings.ToLower(strings.TrimSpace(subScanVal)))
+		if !slices.Contains(GetAllSubScanTypes(), subScan) {
+			return nil, errorutils.CheckErrorf("unsupported scanner '%s', only the following scanners are supported: %s", subScanVal, coreutils.ListToText(supported))
+		}
+		if !slices.Contains(subScans, subScan) {
+			subScans = append(subScans, subScan)
+		}
+	}
+	// The contextual analysis scanner determines the applicability of the CVEs that are found by the SCA scanner.
+	if slices.Contains(subScans, ContextualAnalysisScan) && !slices.Contains(subScans, ScaScan) {
+		return nil, errorutils.CheckErrorf("the '%s' scanner can't run without the '%s' scanner", ContextualAnalysisScan, ScaScan)
+	}
+	return
+}
+
+// IsSubScanRequested returns true if the scanner should run, according to the requested scanners. No requested scanners means that all the scanners should run.
+func IsSubScanRequested(requestedSubScans []SubScanType, subScan SubScanType) bool {
+	return len(requestedSubScans) == 0 || slices.Contains(requestedSubScans, subScan)
+}
+
+// IsJasSubScanRequested returns true if at least one of the JFrog Advanced Security scanners should run.
+func IsJasSubScanRequested(requestedSubScans []SubScanType) bool {
+	for _, subScan := range []SubScanType{ContextualAnalysisScan, SecretsScan, IacScan, SastScan} {
+		if IsSubScanRequested(requestedSubScans, subScan) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSubScansEntitlement verifies that the requested scanners can run when the server isn't entitled for JFrog Advanced Security.
+// The JFrog Advanced Security scanners that were requested are skipped with a warning, and if none of the requested scanners can run, an error is returned.
+func CheckSubScansEntitlement(requestedSubScans []SubScanType, entitledForJas bool) error {
+	if entitledForJas || len(requestedSubScans) == 0 || !IsJasSubScanRequested(requestedSubScans) {
+		return nil
+	}
+	var skippedSubScans []string
+	for _, subScan := range requestedSubScans {
+		if subScan != ScaScan {
+			skippedSubScans = append(skippedSubScans, string(subScan))
+		}
+	}
+	message := fmt.Sprintf("JFrog Advanced Security isn't enabled on the server, so the requested %s scanners can't run", coreutils.ListToText(skippedSubScans))
+	if !IsSubScanRequested(requestedSubScans, ScaScan) {
+		return errorutils.CheckErrorf("none of the requested scanners can run: %s", message)
+	}
+	log.Warn(message)
+	return nil
+}
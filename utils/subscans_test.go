@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSubScans(t *testing.T) {
+	testCases := []struct {
+		flagVal          string
+		expectedSubScans []SubScanType
+		expectedError    bool
+	}{
+		{flagVal: ""},
+		{flagVal: "secrets", expectedSubScans: []SubScanType{SecretsScan}},
+		{flagVal: " SCA, contextual-analysis,sca ", expectedSubScans: []SubScanType{ScaScan, ContextualAnalysisScan}},
+		{flagVal: "sca,secrets,iac,sast,contextual-analysis", expectedSubScans: []SubScanType{ScaScan, SecretsScan, IacScan, SastScan, ContextualAnalysisScan}},
+		{flagVal: "secrets,dast", expectedError: true},
+		{flagVal: "contextual-analysis", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.flagVal, func(t *testing.T) {
+			subScans, err := ParseSubScans(tc.flagVal)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSubScans, subScans)
+		})
+	}
+}
+
+func TestIsSubScanRequested(t *testing.T) {
+	assert.True(t, IsSubScanRequested(nil, SastScan))
+	assert.True(t, IsSubScanRequested([]SubScanType{SecretsScan, SastScan}, SastScan))
+	assert.False(t, IsSubScanRequested([]SubScanType{SecretsScan}, ScaScan))
+
+	assert.True(t, IsJasSubScanRequested(nil))
+	assert.True(t, IsJasSubScanRequested([]SubScanType{ScaScan, IacScan}))
+	assert.False(t, IsJasSubScanRequested([]SubScanType{ScaScan}))
+}
+
+func TestCheckSubScansEntitlement(t *testing.T) {
+	assert.NoError(t, CheckSubScansEntitlement([]SubScanType{SecretsScan}, true))
+	// Without JFrog Advanced Security, the scan fails only if none of the requested scanners can run.
+	assert.NoError(t, CheckSubScansEntitlement(nil, false))
+	assert.NoError(t, CheckSubScansEntitlement([]SubScanType{ScaScan}, false))
+	assert.NoError(t, CheckSubScansEntitlement([]SubScanType{ScaScan, SecretsScan}, false))
+	assert.ErrorContains(t, CheckSubScansEntitlement([]SubScanType{SecretsScan, IacScan}, false), "none of the requested scanners can run")
+}
@@ -0,0 +1,482 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+// The name of the suppressions file, in the directory the command runs in.
+const SuppressionsFileName = ".jfrog-ignore.yml"
+
+const expiryDateLayout = "2006-01-02"
+
+// The suppressions file holds rules that suppress findings of all the scanners, without requiring an Xray ignore rule.
+type SuppressionsFile struct {
+	Rules []SuppressionRule `yaml:"rules"`
+}
+
+// A suppression rule matches the findings that match all of its criteria.
+type SuppressionRule struct {
+	// A CVE id, an Xray issue id or a license key of an SCA finding.
+	Issue string `yaml:"issue"`
+	// The name of the impacted component of an SCA finding, for example: 'lodash'.
+	Component string `yaml:"component"`
+	// A range of the versions of the component, for example: '>=2.0.0 <2.17.1'. All the versions match if empty.
+	Version string `yaml:"version"`
+	// A glob of the file of the finding, relative to the directory of the suppressions file. '**' matches any number of directories.
+	// The SCA findings are matched by the descriptors and the working directory of their project.
+	Path string `yaml:"path"`
+	// The rule id of a secrets, IaC or SAST finding.
+	Rule string `yaml:"rule"`
+	// Mandatory. The reason the findings are suppressed.
+	Reason string `yaml:"reason"`
+	// The last day the rule is applied, in the YYYY-MM-DD format. The rule never expires if empty.
+	Expires string `yaml:"expires"`
+
+	versionRange []versionConstraint
+	line         int
+}
+
+type versionConstraint struct {
+	operator string
+	version  string
+}
+
+// The active rules of a suppressions file.
+type Suppressions struct {
+	rules []SuppressionRule
+	// The paths of the rules are relative to this directory.
+	baseDir string
+}
+
+// The findings that were suppressed, and excluded from the rest of the results.
+type SuppressedFindings struct {
+	// The suppressed findings, in the structure of the scan results.
+	Results *Results
+	Rows    []formats.SuppressedFindingRow
+	// The rules that suppressed the SCA findings, by the fingerprints of the findings.
+	scaRules map[string]*SuppressionRule
+}
+
+// LoadSuppressions reads and validates the suppressions file in the given path. Returns nil if the file doesn't exist.
+// The rules that expired are skipped with a warning.
+func LoadSuppressions(filePath string) (*Suppressions, error) {
+	exists, err := fileutils.IsFileExists(filePath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	rules, err := parseSuppressionRules(content)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid suppressions file '%s': %s", filePath, err.Error())
+	}
+	absolutePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	suppressions := &Suppressions{baseDir: filepath.Dir(absolutePath)}
+	now := time.Now()
+	for _, rule := range rules {
+		if rule.isExpired(now) {
+			log.Warn(fmt.Sprintf("The suppression rule in line %d of '%s' expired on %s and is no longer applied.", rule.line, filePath, rule.Expires))
+			continue
+		}
+		suppressions.rules = append(suppressions.rules, rule)
+	}
+	return suppressions, nil
+}
+
+func parseSuppressionRules(content []byte) ([]SuppressionRule, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	if err := validateConfigKeys(root.Content[0], reflect.TypeOf(SuppressionsFile{}), ""); err != nil {
+		return nil, err
+	}
+	// The rules are decoded one by one, to report the line of an invalid rule.
+	var rulesNodes struct {
+		Rules []yaml.Node `yaml:"rules"`
+	}
+	if err := root.Decode(&rulesNodes); err != nil {
+		return nil, err
+	}
+	rules := make([]SuppressionRule, 0, len(rulesNodes.Rules))
+	for i := range rulesNodes.Rules {
+		rule := SuppressionRule{line: rulesNodes.Rules[i].Line}
+		if err := rulesNodes.Rules[i].Decode(&rule); err != nil {
+			return nil, err
+		}
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: rules[%d]: %s", rule.line, i, err.Error())
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (rule *SuppressionRule) validate() (err error) {
+	if strings.TrimSpace(rule.Reason) == "" {
+		return fmt.Errorf("the reason is mandatory")
+	}
+	if rule.Issue == "" && rule.Component == "" && rule.Path == "" && rule.Rule == "" {
+		return fmt.Errorf("at least one of the following criteria must be set: issue, component, path or rule")
+	}
+	if rule.Rule != "" && (rule.Issue != "" || rule.Component != "") {
+		return fmt.Errorf("the rule criterion matches secrets, IaC and SAST findings, and can't be combined with the issue or component criteria of SCA findings")
+	}
+	if rule.Version != "" {
+		if rule.Component == "" {
+			return fmt.Errorf("the version criterion requires the component criterion")
+		}
+		if rule.versionRange, err = parseVersionRange(rule.Version); err != nil {
+			return
+		}
+	}
+	if rule.Path != "" {
+		if _, err = path.Match(rule.Path, ""); err != nil {
+			return fmt.Errorf("invalid path glob '%s': %s", rule.Path, err.Error())
+		}
+	}
+	if rule.Expires != "" {
+		if _, err = time.Parse(expiryDateLayout, rule.Expires); err != nil {
+			return fmt.Errorf("invalid expiry date '%s', expected the YYYY-MM-DD format", rule.Expires)
+		}
+	}
+	return nil
+}
+
+// The rule is applied until the end of its expiry date.
+func (rule *SuppressionRule) isExpired(now time.Time) bool {
+	if rule.Expires == "" {
+		return false
+	}
+	expires, err := time.ParseInLocation(expiryDateLayout, rule.Expires, now.Location())
+	return err == nil && !now.Before(expires.AddDate(0, 0, 1))
+}
+
+// Parses a space-separated list of constraints, for example: '>=2.0.0 <2.17.1'.
+// The supported operators are: =, >, >=, < and <=. A version without an operator must be equal.
+func parseVersionRange(versionRange string) (constraints []versionConstraint, err error) {
+	for _, constraintVal := range strings.Fields(versionRange) {
+		constraint := versionConstraint{operator: "="}
+		for _, operator := range []string{">=", "<=", ">", "<", "="} {
+			if versionVal, found := strings.CutPrefix(constraintVal, operator); found {
+				constraint.operator, constraintVal = operator, versionVal
+				break
+			}
+		}
+		if constraint.version = normalizeVersion(constraintVal); constraint.version == "" {
+			return nil, fmt.Errorf("invalid version range '%s', expected a space-separated list of constraints such as '>=2.0.0 <2.17.1'", versionRange)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return
+}
+
+func matchVersionRange(constraints []versionConstraint, componentVersion string) bool {
+	componentVersion = normalizeVersion(componentVersion)
+	if componentVersion == "" {
+		return false
+	}
+	for _, constraint := range constraints {
+		// Compare returns a positive number if the component version is greater than the version of the constraint.
+		compare := version.NewVersion(constraint.version).Compare(componentVersion)
+		var matched bool
+		switch constraint.operator {
+		case ">=":
+			matched = compare >= 0
+		case "<=":
+			matched = compare <= 0
+		case ">":
+			matched = compare > 0
+		case "<":
+			matched = compare < 0
+		default:
+			matched = compare == 0
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeVersion(versionVal string) string {
+	return strings.TrimPrefix(strings.TrimSpace(versionVal), "v")
+}
+
+func (rule *SuppressionRule) matchScaFinding(issueIds []string, componentName, componentVersion string, paths []string) bool {
+	if rule.Rule != "" {
+		return false
+	}
+	if rule.Issue != "" && !containsIgnoreCase(issueIds, rule.Issue) {
+		return false
+	}
+	if rule.Component != "" && rule.Component != componentName {
+		return false
+	}
+	if len(rule.versionRange) > 0 && !matchVersionRange(rule.versionRange, componentVersion) {
+		return false
+	}
+	return rule.matchAnyPath(paths)
+}
+
+func (rule *SuppressionRule) matchSourceCodeFinding(ruleId, filePath string) bool {
+	if rule.Issue != "" || rule.Component != "" {
+		return false
+	}
+	if rule.Rule != "" && rule.Rule != ruleId {
+		return false
+	}
+	return rule.matchAnyPath([]string{filePath})
+}
+
+func (rule *SuppressionRule) matchAnyPath(paths []string) bool {
+	if rule.Path == "" {
+		return true
+	}
+	for _, filePath := range paths {
+		if filePath != "" && MatchPathGlob(rule.Path, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIgnoreCase(values []string, value string) bool {
+	for _, current := range values {
+		if strings.EqualFold(current, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the path relative to the base directory in the slash-separated form of the rules, or the absolute path if it isn't in the base directory.
+func (s *Suppressions) toRulePath(filePath string) string {
+	if filePath == "" {
+		return ""
+	}
+	if IsPathInDir(filePath, s.baseDir) {
+		if relative, err := filepath.Rel(s.baseDir, filePath); err == nil {
+			return filepath.ToSlash(relative)
+		}
+	}
+	return filepath.ToSlash(filePath)
+}
+
+func (s *Suppressions) findScaRule(issueIds []string, componentId string, paths []string) *SuppressionRule {
+	name, componentVersion, _ := SplitComponentId(componentId)
+	for i := range s.rules {
+		if s.rules[i].matchScaFinding(issueIds, name, componentVersion, paths) {
+			return &s.rules[i]
+		}
+	}
+	return nil
+}
+
+// SuppressFindings moves the findings that match the rules from the results to results.Suppressed, and returns their count.
+// The SCA findings are suppressed per impacted component. The Contextual Analysis results are kept, since they describe the SCA findings.
+func (s *Suppressions) SuppressFindings(results *Results) (suppressed int) {
+	suppressedFindings := &SuppressedFindings{Results: &Results{XrayVersion: results.XrayVersion, MultiScanId: results.MultiScanId}, scaRules: map[string]*SuppressionRule{}}
+	for i := range results.ScaResults {
+		scaResult := &results.ScaResults[i]
+		paths := []string{s.toRulePath(scaResult.WorkingDirectory)}
+		for _, descriptor := range scaResult.Descriptors {
+			paths = append(paths, s.toRulePath(descriptor))
+		}
+		suppressedScaResult := *scaResult
+		suppressedScaResult.XrayResults = nil
+		for j := range scaResult.XrayResults {
+			xrayResult := &scaResult.XrayResults[j]
+			suppressedXrayResult := services.ScanResponse{ScanId: xrayResult.ScanId, XrayDataUrl: xrayResult.XrayDataUrl}
+			xrayResult.Vulnerabilities, suppressedXrayResult.Vulnerabilities = s.suppressVulnerabilities(xrayResult.Vulnerabilities, paths, suppressedFindings)
+			xrayResult.Violations, suppressedXrayResult.Violations = s.suppressViolations(xrayResult.Violations, paths, suppressedFindings)
+			if len(suppressedXrayResult.Vulnerabilities) > 0 || len(suppressedXrayResult.Violations) > 0 {
+				suppressedScaResult.XrayResults = append(suppressedScaResult.XrayResults, suppressedXrayResult)
+			}
+		}
+		if len(suppressedScaResult.XrayResults) > 0 {
+			suppressedFindings.Results.ScaResults = append(suppressedFindings.Results.ScaResults, suppressedScaResult)
+		}
+	}
+	extended := results.ExtendedScanResults
+	suppressedFindings.Results.ExtendedScanResults = &ExtendedScanResults{ApplicabilityScanResults: extended.ApplicabilityScanResults, EntitledForJas: extended.EntitledForJas}
+	suppressedExtended := suppressedFindings.Results.ExtendedScanResults
+	suppressedExtended.SecretsScanResults = s.suppressSourceCodeFindings(extended.SecretsScanResults, "secret", suppressedFindings)
+	suppressedExtended.IacScanResults = s.suppressSourceCodeFindings(extended.IacScanResults, "iac", suppressedFindings)
+	suppressedExtended.SastScanResults = s.suppressSourceCodeFindings(extended.SastScanResults, "sast", suppressedFindings)
+	suppressed = len(suppressedFindings.Rows)
+	if suppressed > 0 {
+		results.Suppressed = suppressedFindings
+		log.Info(fmt.Sprintf("%d findings are suppressed by the rules of the %s file.", suppressed, SuppressionsFileName))
+	}
+	return
+}
+
+func (s *Suppressions) suppressVulnerabilities(vulnerabilities []services.Vulnerability, paths []string, suppressedFindings *SuppressedFindings) (kept, suppressed []services.Vulnerability) {
+	for _, vulnerability := range vulnerabilities {
+		issueIds := []string{vulnerability.IssueId}
+		for _, cve := range vulnerability.Cves {
+			issueIds = append(issueIds, cve.Id)
+		}
+		keptComponents, suppressedComponents := s.suppressComponents(vulnerability.Components, "vulnerability", issueIds, GetIssueIdentifier(convertCves(vulnerability.Cves), vulnerability.IssueId), paths, suppressedFindings)
+		if len(suppressedComponents) > 0 {
+			suppressedVulnerability := vulnerability
+			suppressedVulnerability.Components = suppressedComponents
+			suppressed = append(suppressed, suppressedVulnerability)
+		}
+		if len(suppressedComponents) == 0 {
+			kept = append(kept, vulnerability)
+		} else if len(keptComponents) > 0 {
+			vulnerability.Components = keptComponents
+			kept = append(kept, vulnerability)
+		}
+	}
+	return
+}
+
+func (s *Suppressions) suppressViolations(violations []services.Violation, paths []string, suppressedFindings *SuppressedFindings) (kept, suppressed []services.Violation) {
+	for _, violation := range violations {
+		issueIds := []string{violation.IssueId}
+		// The fingerprints of the license violations are calculated by their license keys.
+		fingerprintIssueId := violation.LicenseKey
+		if violation.LicenseKey != "" {
+			issueIds = append(issueIds, violation.LicenseKey)
+		}
+		if violation.ViolationType != "license" {
+			fingerprintIssueId = GetIssueIdentifier(convertCves(violation.Cves), violation.IssueId)
+			for _, cve := range violation.Cves {
+				issueIds = append(issueIds, cve.Id)
+			}
+		}
+		keptComponents, suppressedComponents := s.suppressComponents(violation.Components, "violation", issueIds, fingerprintIssueId, paths, suppressedFindings)
+		if len(suppressedComponents) > 0 {
+			suppressedViolation := violation
+			suppressedViolation.Components = suppressedComponents
+			suppressed = append(suppressed, suppressedViolation)
+		}
+		if len(suppressedComponents) == 0 {
+			kept = append(kept, violation)
+		} else if len(keptComponents) > 0 {
+			violation.Components = keptComponents
+			kept = append(kept, violation)
+		}
+	}
+	return
+}
+
+// Splits the impacted components of an SCA finding to the components that are kept and the components that are suppressed.
+func (s *Suppressions) suppressComponents(components map[string]services.Component, findingType string, issueIds []string, fingerprintIssueId string, paths []string, suppressedFindings *SuppressedFindings) (kept, suppressed map[string]services.Component) {
+	// The components are sorted to keep the order of the suppressed rows stable.
+	componentsIds := maps.Keys(components)
+	sort.Strings(componentsIds)
+	for _, componentId := range componentsIds {
+		component := components[componentId]
+		rule := s.findScaRule(issueIds, componentId, paths)
+		if rule == nil {
+			if kept == nil {
+				kept = map[string]services.Component{}
+			}
+			kept[componentId] = component
+			continue
+		}
+		if suppressed == nil {
+			suppressed = map[string]services.Component{}
+		}
+		suppressed[componentId] = component
+		name, componentVersion, _ := SplitComponentId(componentId)
+		for _, impactPath := range component.ImpactPaths {
+			directDependencyName, _, _ := SplitComponentId(getDirectDependencyId(impactPath))
+			suppressedFindings.scaRules[GetScaFingerprint(fingerprintIssueId, name, directDependencyName)] = rule
+		}
+		suppressedFindings.Rows = append(suppressedFindings.Rows, formats.SuppressedFindingRow{
+			Type:                      findingType,
+			IssueId:                   fingerprintIssueId,
+			ImpactedDependencyName:    name,
+			ImpactedDependencyVersion: componentVersion,
+			Reason:                    rule.Reason,
+			Expires:                   rule.Expires,
+		})
+	}
+	return
+}
+
+// Removes the suppressed results from the runs, and returns runs with the suppressed results, in the same order.
+// The suppressed results are marked with the SARIF suppressions, with the reason of the rule as the justification.
+func (s *Suppressions) suppressSourceCodeFindings(runs []*sarif.Run, findingType string, suppressedFindings *SuppressedFindings) (suppressedRuns []*sarif.Run) {
+	for _, run := range runs {
+		suppressedRun := *run
+		suppressedRun.Results = nil
+		var keptResults []*sarif.Result
+		for _, result := range run.Results {
+			filePath := ""
+			if len(result.Locations) > 0 {
+				filePath = s.toRulePath(GetFullLocationFileName(GetRelativeLocationFileName(result.Locations[0], run.Invocations), run.Invocations))
+			}
+			ruleId := GetResultRuleId(result)
+			var matchedRule *SuppressionRule
+			for i := range s.rules {
+				if s.rules[i].matchSourceCodeFinding(ruleId, filePath) {
+					matchedRule = &s.rules[i]
+					break
+				}
+			}
+			if matchedRule == nil {
+				keptResults = append(keptResults, result)
+				continue
+			}
+			result.AddSuppression(newSarifSuppression(matchedRule))
+			suppressedRun.Results = append(suppressedRun.Results, result)
+			suppressedFindings.Rows = append(suppressedFindings.Rows, formats.SuppressedFindingRow{
+				Type:    findingType,
+				File:    filePath,
+				RuleId:  ruleId,
+				Reason:  matchedRule.Reason,
+				Expires: matchedRule.Expires,
+			})
+		}
+		run.Results = keptResults
+		suppressedRuns = append(suppressedRuns, &suppressedRun)
+	}
+	return
+}
+
+func newSarifSuppression(rule *SuppressionRule) *sarif.Suppression {
+	suppression := sarif.NewSuppression("external").WithStatus("accepted")
+	if rule != nil {
+		suppression.WithJustifcation(rule.Reason)
+	}
+	return suppression
+}
+
+// Marks the SCA results of the suppressed findings with the SARIF suppressions, according to their fingerprints.
+func (sf *SuppressedFindings) setScaSarifSuppressions(run *sarif.Run) {
+	for _, result := range run.Results {
+		fingerprint, _ := result.PartialFingerprints[JfrogFingerprintKey].(string)
+		result.AddSuppression(newSarifSuppression(sf.scaRules[fingerprint]))
+	}
+}
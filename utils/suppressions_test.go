@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-security/formats"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSuppressionRules(t *testing.T) {
+	rules, err := parseSuppressionRules([]byte(`
+rules:
+  - issue: CVE-2021-44228
+    component: org.apache.logging.log4j:log4j-core
+    version: ">=2.0.0 <2.17.1"
+    reason: Not exploitable, JNDI lookups are disabled
+    expires: 2030-12-31
+  - rule: REQ.SECRET.GENERIC.CODE
+    path: "tests/**"
+    reason: Test fixtures
+`))
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "2030-12-31", rules[0].Expires)
+	assert.Equal(t, []versionConstraint{{operator: ">=", version: "2.0.0"}, {operator: "<", version: "2.17.1"}}, rules[0].versionRange)
+	assert.Equal(t, 3, rules[0].line)
+	assert.Equal(t, "tests/**", rules[1].Path)
+
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "Unknown option", content: "rules:\n  - issue: CVE-1\n    reasn: typo", expectedError: "line 3: unknown option 'rules[0].reasn'"},
+		{name: "Missing reason", content: "rules:\n  - issue: CVE-1", expectedError: "line 2: rules[0]: the reason is mandatory"},
+		{name: "No criteria", content: "rules:\n  - reason: everything", expectedError: "at least one of the following criteria must be set"},
+		{name: "Rule with issue", content: "rules:\n  - rule: id\n    issue: CVE-1\n    reason: r", expectedError: "can't be combined with the issue or component criteria"},
+		{name: "Version without component", content: "rules:\n  - issue: CVE-1\n    version: 1.0.0\n    reason: r", expectedError: "the version criterion requires the component criterion"},
+		{name: "Invalid version range", content: "rules:\n  - component: a\n    version: '>='\n    reason: r", expectedError: "invalid version range '>='"},
+		{name: "Invalid path", content: "rules:\n  - path: '['\n    reason: r", expectedError: "invalid path glob '['"},
+		{name: "Invalid expiry date", content: "rules:\n  - issue: CVE-1\n    reason: r\n    expires: 31/12/2030", expectedError: "invalid expiry date '31/12/2030'"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := parseSuppressionRules([]byte(testCase.content))
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestLoadSuppressions(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, SuppressionsFileName)
+	suppressions, err := LoadSuppressions(filePath)
+	assert.NoError(t, err)
+	assert.Nil(t, suppressions)
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(expiryDateLayout)
+	today := time.Now().Format(expiryDateLayout)
+	content := "rules:\n  - issue: CVE-1\n    reason: expired\n    expires: " + yesterday + "\n  - issue: CVE-2\n    reason: expires today\n    expires: " + today + "\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+	suppressions, err = LoadSuppressions(filePath)
+	require.NoError(t, err)
+	require.Len(t, suppressions.rules, 1)
+	assert.Equal(t, "CVE-2", suppressions.rules[0].Issue)
+	assert.Equal(t, tempDir, suppressions.baseDir)
+
+	require.NoError(t, os.WriteFile(filePath, []byte("rules:\n  - issue: CVE-1\n"), 0644))
+	_, err = LoadSuppressions(filePath)
+	assert.ErrorContains(t, err, "invalid suppressions file '"+filePath+"': line 2: rules[0]: the reason is mandatory")
+}
+
+func TestMatchVersionRange(t *testing.T) {
+	versionRange, err := parseVersionRange(">=2.0.0 <2.17.1")
+	require.NoError(t, err)
+	assert.True(t, matchVersionRange(versionRange, "2.0.0"))
+	assert.True(t, matchVersionRange(versionRange, "2.14.1"))
+	assert.False(t, matchVersionRange(versionRange, "2.17.1"))
+	assert.False(t, matchVersionRange(versionRange, "1.2.17"))
+	assert.False(t, matchVersionRange(versionRange, ""))
+
+	versionRange, err = parseVersionRange("v1.5.0")
+	require.NoError(t, err)
+	assert.True(t, matchVersionRange(versionRange, "1.5.0"))
+	assert.True(t, matchVersionRange(versionRange, "v1.5.0"))
+	assert.False(t, matchVersionRange(versionRange, "1.5.1"))
+}
+
+func createSuppressionsTestResults(baseDir string) *Results {
+	impactPath := func(componentId string) [][]services.ImpactPathNode {
+		return [][]services.ImpactPathNode{{{ComponentId: "npm://root:1.0.0"}, {ComponentId: componentId}}}
+	}
+	results := NewAuditResults()
+	results.ScaResults = []ScaScanResult{{
+		Technology:       coreutils.Npm,
+		WorkingDirectory: filepath.Join(baseDir, "frontend"),
+		Descriptors:      []string{filepath.Join(baseDir, "frontend", "package.json")},
+		XrayResults: []services.ScanResponse{{
+			Vulnerabilities: []services.Vulnerability{
+				{
+					IssueId:  "XRAY-1",
+					Severity: "High",
+					Cves:     []services.Cve{{Id: "CVE-2024-1234"}},
+					Components: map[string]services.Component{
+						"npm://lodash:4.17.0":  {ImpactPaths: impactPath("npm://lodash:4.17.0")},
+						"npm://lodash:4.17.21": {ImpactPaths: impactPath("npm://lodash:4.17.21")},
+					},
+				},
+				{
+					IssueId:    "XRAY-2",
+					Severity:   "Low",
+					Components: map[string]services.Component{"npm://axios:1.6.0": {ImpactPaths: impactPath("npm://axios:1.6.0")}},
+				},
+			},
+			Violations: []services.Violation{{
+				IssueId:       "XRAY-3",
+				ViolationType: "license",
+				LicenseKey:    "GPL-3.0",
+				Severity:      "Medium",
+				Components:    map[string]services.Component{"npm://left-pad:1.0.0": {ImpactPaths: impactPath("npm://left-pad:1.0.0")}},
+			}},
+		}},
+	}}
+	invocation := sarif.NewInvocation().WithWorkingDirectory(sarif.NewSimpleArtifactLocation(baseDir))
+	results.ExtendedScanResults.SecretsScanResults = []*sarif.Run{
+		CreateRunWithDummyResults(
+			CreateResultWithOneLocation("file://"+filepath.Join(baseDir, "tests", "fixture.env"), 1, 2, 3, 4, "snippet", "secret-rule", "note"),
+			CreateResultWithOneLocation("file://"+filepath.Join(baseDir, "src", "config.js"), 1, 2, 3, 4, "snippet", "secret-rule", "note"),
+		).WithInvocations([]*sarif.Invocation{invocation}),
+	}
+	return results
+}
+
+func TestSuppressFindings(t *testing.T) {
+	baseDir := t.TempDir()
+	rules, err := parseSuppressionRules([]byte(`
+rules:
+  - issue: cve-2024-1234
+    component: lodash
+    version: "<4.17.21"
+    reason: Not reachable
+  - issue: GPL-3.0
+    path: "frontend/**"
+    reason: Internal tool
+    expires: 2099-01-01
+  - rule: secret-rule
+    path: "tests/**"
+    reason: Test fixtures
+  - issue: XRAY-2
+    path: "backend/**"
+    reason: Not in this project
+`))
+	require.NoError(t, err)
+	suppressions := &Suppressions{rules: rules, baseDir: baseDir}
+	results := createSuppressionsTestResults(baseDir)
+	assert.Equal(t, 3, suppressions.SuppressFindings(results))
+
+	// The vulnerable version of lodash and the license violation are suppressed, the fixed version of lodash and axios are kept.
+	xrayResult := results.ScaResults[0].XrayResults[0]
+	require.Len(t, xrayResult.Vulnerabilities, 2)
+	assert.Contains(t, xrayResult.Vulnerabilities[0].Components, "npm://lodash:4.17.21")
+	assert.NotContains(t, xrayResult.Vulnerabilities[0].Components, "npm://lodash:4.17.0")
+	assert.Equal(t, "XRAY-2", xrayResult.Vulnerabilities[1].IssueId)
+	assert.Empty(t, xrayResult.Violations)
+	secretsResults := results.ExtendedScanResults.SecretsScanResults[0].Results
+	require.Len(t, secretsResults, 1)
+	assert.Empty(t, secretsResults[0].Suppressions)
+
+	require.NotNil(t, results.Suppressed)
+	assert.Equal(t, []formats.SuppressedFindingRow{
+		{Type: "vulnerability", IssueId: "CVE-2024-1234", ImpactedDependencyName: "lodash", ImpactedDependencyVersion: "4.17.0", Reason: "Not reachable"},
+		{Type: "violation", IssueId: "GPL-3.0", ImpactedDependencyName: "left-pad", ImpactedDependencyVersion: "1.0.0", Reason: "Internal tool", Expires: "2099-01-01"},
+		{Type: "secret", File: "tests/fixture.env", RuleId: "secret-rule", Reason: "Test fixtures"},
+	}, results.Suppressed.Rows)
+
+	// The suppressed findings are reported as suppressed in the simple-json and SARIF formats.
+	simpleJson, err := NewResultsWriter(results).SetIncludeVulnerabilities(true).SetIsMultipleRootProject(true).convertScanToSimpleJson()
+	require.NoError(t, err)
+	assert.Len(t, simpleJson.Suppressed, 3)
+	assert.Len(t, simpleJson.Vulnerabilities, 2)
+	assert.Len(t, simpleJson.Secrets, 1)
+
+	report, err := GenereateSarifReportFromResults(results, true, false, nil)
+	require.NoError(t, err)
+	justifications := map[string]int{}
+	resultsCount := 0
+	for _, run := range report.Runs {
+		for _, result := range run.Results {
+			resultsCount++
+			for _, suppression := range result.Suppressions {
+				assert.Equal(t, "external", suppression.Kind)
+				justifications[*suppression.Justification]++
+			}
+		}
+	}
+	assert.Equal(t, map[string]int{"Not reachable": 1, "Internal tool": 1, "Test fixtures": 1}, justifications)
+	assert.Equal(t, 6, resultsCount)
+	// The runs of the results aren't changed by the report.
+	assert.Len(t, results.ExtendedScanResults.SecretsScanResults[0].Results, 1)
+	content, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"suppressions":[{"kind":"external","status":"accepted"`)
+}
+
+func TestSuppressFindingsWithoutMatches(t *testing.T) {
+	baseDir := t.TempDir()
+	suppressions := &Suppressions{rules: []SuppressionRule{{Rule: "other-rule", Reason: "r"}}, baseDir: baseDir}
+	results := createSuppressionsTestResults(baseDir)
+	assert.Zero(t, suppressions.SuppressFindings(results))
+	assert.Nil(t, results.Suppressed)
+	assert.Equal(t, createSuppressionsTestResults(baseDir), results)
+}
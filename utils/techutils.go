@@ -2,6 +2,17 @@ package utils
 
 import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 
+// Technologies that are supported by the audit, but aren't defined by jfrog-cli-core yet.
+const (
+	Cargo    coreutils.Technology = "cargo"
+	Composer coreutils.Technology = "composer"
+)
+
+// Returns the technologies of jfrog-cli-core and the technologies that are defined only by the audit.
+func GetAllTechnologiesList() []coreutils.Technology {
+	return append(coreutils.GetAllTechnologiesList(), Cargo, Composer)
+}
+
 func TechnologyToLanguage(technology coreutils.Technology) CodeLanguage {
 	languageMap := map[coreutils.Technology]CodeLanguage{
 		coreutils.Npm:    JavaScript,
@@ -1,18 +1,26 @@
 package utils
 
 import (
+	"context"
+
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	clientconfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/xray"
 )
 
 func CreateXrayServiceManager(serverDetails *config.ServerDetails) (*xray.XrayServicesManager, error) {
+	return CreateXrayServiceManagerWithContext(context.Background(), serverDetails)
+}
+
+// CreateXrayServiceManagerWithContext creates an Xray service manager whose requests are canceled when the context is done.
+func CreateXrayServiceManagerWithContext(ctx context.Context, serverDetails *config.ServerDetails) (*xray.XrayServicesManager, error) {
 	xrayDetails, err := serverDetails.CreateXrayAuthConfig()
 	if err != nil {
 		return nil, err
 	}
 	serviceConfig, err := clientconfig.NewConfigBuilder().
 		SetServiceDetails(xrayDetails).
+		SetContext(ctx).
 		Build()
 	if err != nil {
 		return nil, err